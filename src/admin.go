@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"strings"
+	"the-wedding-game-api/models"
+)
+
+// setAdminPassword creates the admin with the username or resets their password, the password is read from stdin
+// so it does not end up in the shell history
+func setAdminPassword(args []string) {
+	if len(args) != 1 {
+		log.Fatal("Usage: set-admin-password <username>")
+	}
+
+	log.Println("Enter the new password:")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		log.Fatal("Could not read password: ", err)
+	}
+
+	user, err := models.SetAdminPassword(args[0], strings.TrimRight(password, "\r\n"))
+	if err != nil {
+		log.Fatal("Could not set admin password: ", err)
+	}
+
+	log.Printf("Password of admin %s set\n", user.Username)
+}
@@ -1,3 +1,67 @@
 package config
 
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
 var MAX_UPLOAD_SIZE = 1024 * 1024 * 1 // 10MB
+
+var DEFAULT_MAX_VIDEO_UPLOAD_SIZE = 1024 * 1024 * 100 // 100MB
+
+var DEFAULT_MAX_AUDIO_UPLOAD_SIZE = 1024 * 1024 * 20 // 20MB
+
+// GetMaxVideoUploadSize returns the maximum size of a video in bytes, configured with MAX_VIDEO_UPLOAD_SIZE.
+// Falls back to 100MB if the variable is not set or is not a positive number.
+func GetMaxVideoUploadSize() int64 {
+	size, err := strconv.ParseInt(os.Getenv("MAX_VIDEO_UPLOAD_SIZE"), 10, 64)
+	if err != nil || size <= 0 {
+		return int64(DEFAULT_MAX_VIDEO_UPLOAD_SIZE)
+	}
+	return size
+}
+
+// GetMaxAudioUploadSize returns the maximum size of an audio message in bytes, configured with MAX_AUDIO_UPLOAD_SIZE.
+// Falls back to 20MB if the variable is not set or is not a positive number.
+func GetMaxAudioUploadSize() int64 {
+	size, err := strconv.ParseInt(os.Getenv("MAX_AUDIO_UPLOAD_SIZE"), 10, 64)
+	if err != nil || size <= 0 {
+		return int64(DEFAULT_MAX_AUDIO_UPLOAD_SIZE)
+	}
+	return size
+}
+
+// GetEventLocation returns the timezone of the wedding, configured with EVENT_TIMEZONE (e.g. "Europe/Berlin").
+// Falls back to UTC if the variable is not set or is not a known timezone.
+func GetEventLocation() *time.Location {
+	location, err := time.LoadLocation(os.Getenv("EVENT_TIMEZONE"))
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// GetAppURL returns the address of the web app guests use, configured with APP_URL (e.g. "https://game.example.com").
+// Used to build the deep links printed on QR codes, falls back to the local development server.
+func GetAppURL() string {
+	appURL := strings.TrimRight(os.Getenv("APP_URL"), "/")
+	if appURL == "" {
+		return "http://localhost:3000"
+	}
+	return appURL
+}
+
+// GetTrustedProxies returns the addresses or CIDR ranges of the reverse proxies in front of the API, configured with
+// TRUSTED_PROXIES as a comma-separated list (e.g. "10.0.0.0/8,127.0.0.1"). Only these proxies may set the client IP
+// with X-Forwarded-For, none are trusted if the variable is not set.
+func GetTrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
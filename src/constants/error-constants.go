@@ -7,9 +7,87 @@ import (
 
 var InvalidChallengeTypeError = "invalid challenge type"
 var AnswerRequiredError = "answer is required for answer question challenges"
+var InvalidAnswerPatternError = "answer must be a valid regular expression for regex answer matching"
+var InvalidNumericAnswerError = "answer must be a number for numeric answer matching"
+var AnswerRequiredForMatchModeError = "answer is required when using regex or numeric answer matching"
+var AnswersNotSupportedError = "answers can only be managed for answer question challenges"
+var LastAnswerDeletionError = "cannot delete the last answer of a challenge"
+var InvalidAnswerIDError = "invalid answer id"
+var OptionsRequiredError = "at least two options are required for multiple choice challenges"
+var CorrectOptionRequiredError = "at least one option must be marked as correct"
+var InvalidOptionSelectionError = "invalid option selection"
+var InvalidAvailableFromError = "available_from must be a valid timestamp"
+var InvalidAvailableUntilError = "available_until must be a valid timestamp"
+var InvalidAvailabilityWindowError = "available_from must be before available_until"
+var ChallengeNotYetAvailableError = "challenge is not available yet"
+var ChallengeNoLongerAvailableError = "challenge is no longer available"
+var ChallengeLockedError = "challenge is locked until its prerequisites are completed"
+var InvalidPrerequisiteError = "prerequisites must reference existing challenges"
+var PrerequisiteCycleError = "prerequisites cannot form a cycle"
+var NoHintsLeftError = "no hints left for this challenge"
+var HintsForCompletedChallengeError = "hints cannot be revealed for a completed challenge"
+var InvalidHintCostError = "total hint cost cannot exceed the points of the challenge"
+var NoAttemptsLeftError = "no attempts left for this challenge"
+var InvalidFirstSolverBonusError = "first_solver_bonus and first_solver_count must be set together"
+var InvalidPointsDecayError = "decay_points and decay_interval_minutes are required when points decay"
+var InvalidMinimumPointsError = "minimum_points cannot exceed the points of the challenge"
+var InvalidTeamIDError = "invalid team id"
+var InvalidUserIDError = "invalid user id"
+var InvalidRefreshTokenError = "invalid refresh token"
+var InvalidJoinCodeError = "invalid join code"
+var TeamNameTakenError = "team name is already taken"
+var JoinCodeTakenError = "join code is already taken"
+var InvalidPasswordError = "invalid password"
+var PasswordLengthError = "password must be between 8 and 72 bytes long"
+var TooManyLoginAttemptsError = "too many failed login attempts, try again later"
+var LockoutKeyRequiredError = "lockout key is required"
+var PasswordRequiredForRoleError = "a password is required for admins and moderators"
+var ChangeOwnRoleError = "you cannot change your own role"
+var InviteCodeRequiredError = "invite code is required"
+var InvalidInviteCodeError = "invalid invite code"
+var InviteCodeUsedError = "invite code was already used by another guest"
+var InviteCodeTakenError = "invite code is already taken"
+var QRCodeNotAvailableError = "qr codes are only available for scan code challenges"
+var InvalidSubmissionIDError = "invalid submission id"
+var InvalidSubmissionStateError = "state must be one of pending, approved or rejected"
+var SubmissionNotModeratedError = "only photo and video submissions can be moderated"
+var UploadNotFoundError = "answer must be a file you uploaded"
+var WrongMediaTypeError = "upload does not match the type of the challenge"
+var VideoTooLongError = "video is longer than the maximum duration of the challenge"
+var AudioTooLongError = "audio message is longer than the maximum duration of the challenge"
+var UploadAlreadyUsedError = "file was already used for another submission"
+var InvalidItemOrderError = "answer must list the ids of all items exactly once"
+var ItemsRequiredError = "at least two items are required"
+var NotAPredictionError = "only prediction challenges can be resolved"
+var PredictionResolvedError = "the outcome of this prediction was already posted"
+var PredictionMustBeNumberError = "prediction must be a number"
+var PredictionOutcomeMustBeNumberError = "outcome must be a number"
+var PredictionLostReason = "the guess did not win the prediction"
+var InvalidBingoBoardError = "challenges must fill every cell of the board with a different challenge"
+var ChallengeOnBingoBoardError = "Cannot delete a challenge that is on the bingo board"
+var InvalidQuizRoundIDError = "invalid quiz round id"
+var QuizChallengeTypeError = "quiz rounds can only ask answer question and multiple choice challenges"
+var InvalidQuizRoundError = "challenges must exist, be different and not be part of another quiz round"
+var QuizRoundAlreadyStartedError = "quiz round was already started"
+var ChallengeInQuizRoundError = "Cannot delete a challenge that is part of a quiz round"
+var QuizRoundRunningError = "another quiz round is running"
+var QuizQuestionClosedError = "question is no longer open"
+var QuizQuestionAnsweredError = "question was already answered"
+var QuizChallengeError = "this challenge can only be answered during its quiz round"
+var LocationRequiredError = "location with a radius is required"
+var InvalidDeviceLocationError = "answer must be the latitude, longitude and accuracy of the device"
+var LocationNotAccurateError = "location is not accurate enough, try again when the device has a better fix"
 var InvalidImageURLError = "invalid image url"
 var InvalidChallengeIDError = "invalid challenge id"
 var ImageIsRequiredError = "image is required"
 var FileMustBeAnImageError = "file must be an image"
 var FileIsEmptyError = "file is empty"
+var VideoIsRequiredError = "video is required"
+var FileMustBeAVideoError = "file must be an mp4, mov or webm video"
+var InvalidVideoError = "could not read the duration of the video"
+var MaxVideoFileSizeError = "maximum video size is %d bytes"
+var AudioIsRequiredError = "audio is required"
+var FileMustBeAnAudioError = "file must be an m4a, mp3, ogg or webm audio message"
+var InvalidAudioError = "could not read the duration of the audio message"
+var MaxAudioFileSizeError = "maximum audio size is %d bytes"
 var MaxFileSizeError = fmt.Sprintf("maximum file size is %d bytes", config.MAX_UPLOAD_SIZE) // 10 MB
@@ -67,3 +67,25 @@ func IsAccessTokenNotFoundError(err error) bool {
 	ok := errors.As(err, &accessTokenNotFoundError)
 	return ok
 }
+
+type AccessTokenExpiredError struct {
+	code    string
+	Message string
+}
+
+func (e AccessTokenExpiredError) Error() string {
+	return e.Message
+}
+
+func NewAccessTokenExpiredError() AccessTokenExpiredError {
+	return AccessTokenExpiredError{
+		code:    "AccessTokenExpiredError",
+		Message: "access token expired",
+	}
+}
+
+func IsAccessTokenExpiredError(err error) bool {
+	var accessTokenExpiredError AccessTokenExpiredError
+	ok := errors.As(err, &accessTokenExpiredError)
+	return ok
+}
@@ -142,3 +142,27 @@ func TestIsAccessTokenNotFoundErrorNegative(t *testing.T) {
 		t.Errorf("expected false but got true")
 	}
 }
+
+func TestCreateAccessTokenExpiredError(t *testing.T) {
+	accessTokenExpiredError := NewAccessTokenExpiredError()
+	if accessTokenExpiredError.Message != "access token expired" {
+		t.Errorf("expected access token expired but got %s", accessTokenExpiredError.Message)
+	}
+	if accessTokenExpiredError.code != "AccessTokenExpiredError" {
+		t.Errorf("expected AccessTokenExpiredError but got %s", accessTokenExpiredError.code)
+	}
+	if !IsAccessTokenExpiredError(accessTokenExpiredError) {
+		t.Errorf("expected true but got false")
+	}
+}
+
+func TestIsAccessTokenExpiredErrorNegative(t *testing.T) {
+	accessTokenNotFoundError := NewAccessTokenNotFoundError()
+	if IsAccessTokenExpiredError(accessTokenNotFoundError) {
+		t.Errorf("expected false but got true")
+	}
+
+	if IsAuthenticationError(NewAccessTokenExpiredError()) {
+		t.Errorf("expected false but got true")
+	}
+}
@@ -0,0 +1,30 @@
+package apperrors
+
+import (
+	"errors"
+	"time"
+)
+
+type TooManyRequestsError struct {
+	code       string
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e TooManyRequestsError) Error() string {
+	return e.Message
+}
+
+func NewTooManyRequestsError(message string, retryAfter time.Duration) TooManyRequestsError {
+	return TooManyRequestsError{
+		code:       "TooManyRequestsError",
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
+func IsTooManyRequestsError(err error) bool {
+	var tooManyRequestsError TooManyRequestsError
+	ok := errors.As(err, &tooManyRequestsError)
+	return ok
+}
@@ -0,0 +1,35 @@
+package apperrors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTooManyRequestsError(t *testing.T) {
+	tooManyRequestsError := NewTooManyRequestsError("too many login attempts", time.Minute)
+	if tooManyRequestsError.Message != "too many login attempts" {
+		t.Errorf("expected too many login attempts but got %s", tooManyRequestsError.Message)
+	}
+	if tooManyRequestsError.code != "TooManyRequestsError" {
+		t.Errorf("expected TooManyRequestsError but got %s", tooManyRequestsError.code)
+	}
+	if tooManyRequestsError.RetryAfter != time.Minute {
+		t.Errorf("expected 1m0s but got %s", tooManyRequestsError.RetryAfter)
+	}
+	if !IsTooManyRequestsError(tooManyRequestsError) {
+		t.Errorf("expected true but got false")
+	}
+}
+
+func TestTooManyRequestsErrorMessage(t *testing.T) {
+	tooManyRequestsError := NewTooManyRequestsError("hello there", time.Second)
+	if tooManyRequestsError.Error() != "hello there" {
+		t.Errorf("expected hello there but got %s", tooManyRequestsError.Error())
+	}
+}
+
+func TestIsTooManyRequestsErrorFalse(t *testing.T) {
+	if IsTooManyRequestsError(NewAuthenticationError("invalid password")) {
+		t.Errorf("expected false but got true")
+	}
+}
@@ -0,0 +1,60 @@
+package limiter
+
+import (
+	"the-wedding-game-api/models"
+	"time"
+)
+
+// DatabaseLoginLimiter keeps the failed logins in the database, so every instance sees the same lockouts
+type DatabaseLoginLimiter struct{}
+
+func NewDatabaseLoginLimiter() *DatabaseLoginLimiter {
+	return &DatabaseLoginLimiter{}
+}
+
+func (d *DatabaseLoginLimiter) GetLockout(key string) (*Lockout, error) {
+	loginLockout, err := models.GetLoginLockout(key)
+	if err != nil || loginLockout == nil {
+		return nil, err
+	}
+	lockout := getLockout(*loginLockout)
+	return &lockout, nil
+}
+
+func (d *DatabaseLoginLimiter) GetLockouts() ([]Lockout, error) {
+	loginLockouts, err := models.GetLoginLockouts()
+	if err != nil {
+		return nil, err
+	}
+
+	lockouts := make([]Lockout, len(loginLockouts))
+	for i, loginLockout := range loginLockouts {
+		lockouts[i] = getLockout(loginLockout)
+	}
+	return lockouts, nil
+}
+
+func (d *DatabaseLoginLimiter) Reserve(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (Lockout, bool, error) {
+	loginLockout, reserved, err := models.ReserveLogin(key, now, since, lockoutFor)
+	if err != nil {
+		return Lockout{}, false, err
+	}
+	return getLockout(loginLockout), reserved, nil
+}
+
+func (d *DatabaseLoginLimiter) Release(key string) error {
+	return models.ReleaseLogin(key)
+}
+
+func (d *DatabaseLoginLimiter) Clear(key string) error {
+	return models.DeleteLoginLockout(key)
+}
+
+func getLockout(loginLockout models.LoginLockout) Lockout {
+	return Lockout{
+		Key:           loginLockout.Key,
+		Failures:      loginLockout.Failures,
+		LastFailureAt: loginLockout.LastFailureAt,
+		LockedUntil:   loginLockout.LockedUntil,
+	}
+}
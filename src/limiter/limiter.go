@@ -0,0 +1,156 @@
+package limiter
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"time"
+)
+
+// Lockout is the failed logins of a key, a username or an IP address
+type Lockout struct {
+	Key           string
+	Failures      uint
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginLimiterInterface stores the failed logins, in memory for a single instance or in the database when
+// several instances have to share them
+type LoginLimiterInterface interface {
+	GetLockout(key string) (*Lockout, error)
+	GetLockouts() ([]Lockout, error)
+	// Reserve counts a login attempt of the key as failed unless the key is locked out at now, and locks the key out
+	// for lockoutFor(failures) right away. Both happen in one step, so concurrent attempts cannot all pass the check
+	// before their failures are counted. It returns false with the lockout if the key is locked out.
+	Reserve(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (Lockout, bool, error)
+	// Release takes back a reserved attempt that did not fail on the credentials, together with the lockout it set
+	Release(key string) error
+	Clear(key string) error
+}
+
+var GetLoginLimiter = getConfiguredLoginLimiter
+
+var (
+	loginLimiter     LoginLimiterInterface
+	loginLimiterOnce sync.Once
+)
+
+// getConfiguredLoginLimiter returns the limiter chosen with LOGIN_LIMITER, "database" shares the failed logins
+// between instances, anything else keeps them in memory
+func getConfiguredLoginLimiter() LoginLimiterInterface {
+	loginLimiterOnce.Do(func() {
+		if os.Getenv("LOGIN_LIMITER") == "database" {
+			loginLimiter = NewDatabaseLoginLimiter()
+		} else {
+			loginLimiter = NewMemoryLoginLimiter()
+		}
+	})
+	return loginLimiter
+}
+
+// policy locks a key out once it has more than freeAttempts failed logins, the lockout doubles with every failure
+type policy struct {
+	freeAttempts uint
+	baseLockout  time.Duration
+	maxLockout   time.Duration
+}
+
+const (
+	usernameKeyPrefix = "username:"
+	ipKeyPrefix       = "ip:"
+)
+
+// failureWindow is how long failed logins are remembered after the last one
+const failureWindow = 24 * time.Hour
+
+var usernamePolicy = policy{freeAttempts: 5, baseLockout: 30 * time.Second, maxLockout: time.Hour}
+
+// Guests at the venue share the IP address of its network, so an address gets more attempts than a username
+var ipPolicy = policy{freeAttempts: 20, baseLockout: 30 * time.Second, maxLockout: time.Hour}
+
+func UsernameKey(username string) string {
+	return usernameKeyPrefix + username
+}
+
+func IPKey(ip string) string {
+	return ipKeyPrefix + ip
+}
+
+func getPolicy(key string) policy {
+	if strings.HasPrefix(key, ipKeyPrefix) {
+		return ipPolicy
+	}
+	return usernamePolicy
+}
+
+// getLockoutDuration returns how long a key with the given number of failed logins is locked out, 0 if it is not
+func (p policy) getLockoutDuration(failures uint) time.Duration {
+	if failures < p.freeAttempts {
+		return 0
+	}
+
+	lockout := p.baseLockout
+	for i := p.freeAttempts; i < failures && lockout < p.maxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, p.maxLockout)
+}
+
+// ReserveLogin counts the login attempt as failed for every key before the credentials are checked, so the attempts
+// that can run at the same time are limited too. It returns a too many requests error, that tells when to try again,
+// if any of the keys is locked out; the other keys are released then.
+func ReserveLogin(keys ...string) error {
+	return reserveLogin(GetLoginLimiter(), time.Now(), keys...)
+}
+
+func reserveLogin(limiter LoginLimiterInterface, now time.Time, keys ...string) error {
+	var retryAfter time.Duration
+	var reserved []string
+	for _, key := range keys {
+		lockout, ok, err := limiter.Reserve(key, now, now.Add(-failureWindow), getPolicy(key).getLockoutDuration)
+		if err != nil {
+			_ = releaseLogin(limiter, reserved...)
+			return err
+		}
+		if ok {
+			reserved = append(reserved, key)
+		} else if lockout.LockedUntil != nil {
+			retryAfter = max(retryAfter, lockout.LockedUntil.Sub(now))
+		}
+	}
+
+	if retryAfter > 0 {
+		if err := releaseLogin(limiter, reserved...); err != nil {
+			return err
+		}
+		return apperrors.NewTooManyRequestsError(constants.TooManyLoginAttemptsError, retryAfter)
+	}
+	return nil
+}
+
+// ReleaseLogin takes back the attempts reserved for the keys, for logins that did not fail on their credentials
+func ReleaseLogin(keys ...string) error {
+	return releaseLogin(GetLoginLimiter(), keys...)
+}
+
+func releaseLogin(limiter LoginLimiterInterface, keys ...string) error {
+	for _, key := range keys {
+		if err := limiter.Release(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearLockout forgets the failed logins of the key, after a successful login or when an admin lifts the lockout
+func ClearLockout(key string) error {
+	return GetLoginLimiter().Clear(key)
+}
+
+// GetLockouts returns every key with failed logins, locked out or not
+func GetLockouts() ([]Lockout, error) {
+	return GetLoginLimiter().GetLockouts()
+}
@@ -0,0 +1,139 @@
+package limiter
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"time"
+)
+
+var testNow = time.Date(2025, 6, 14, 18, 0, 0, 0, time.UTC)
+
+func TestGetLockoutDuration(t *testing.T) {
+	tests := []struct {
+		failures uint
+		expected time.Duration
+	}{
+		{failures: 0, expected: 0},
+		{failures: 4, expected: 0},
+		{failures: 5, expected: 30 * time.Second},
+		{failures: 6, expected: time.Minute},
+		{failures: 8, expected: 4 * time.Minute},
+		{failures: 100, expected: time.Hour},
+	}
+
+	for _, test := range tests {
+		if duration := usernamePolicy.getLockoutDuration(test.failures); duration != test.expected {
+			t.Errorf("expected %s for %d failures but got %s", test.expected, test.failures, duration)
+		}
+	}
+}
+
+func TestGetPolicy(t *testing.T) {
+	if getPolicy(IPKey("192.0.2.1")) != ipPolicy {
+		t.Errorf("expected the ip policy for an ip key")
+	}
+	if getPolicy(UsernameKey("admin")) != usernamePolicy {
+		t.Errorf("expected the username policy for a username key")
+	}
+}
+
+func TestReserveLoginLocksOut(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	key := UsernameKey("admin")
+
+	for i := 0; i < 5; i++ {
+		if err := reserveLogin(limiter, testNow, key); err != nil {
+			t.Errorf("expected attempt %d to be reserved but got %s", i+1, err.Error())
+			return
+		}
+	}
+
+	err := reserveLogin(limiter, testNow.Add(10*time.Second), key)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	tooManyRequestsError, ok := err.(apperrors.TooManyRequestsError)
+	if !ok {
+		t.Errorf("expected too many requests error but got %s", err.Error())
+		return
+	}
+	if tooManyRequestsError.RetryAfter != 20*time.Second {
+		t.Errorf("expected to retry after 20s but got %s", tooManyRequestsError.RetryAfter)
+	}
+
+	lockout, _ := limiter.GetLockout(key)
+	if lockout == nil || lockout.Failures != 5 {
+		t.Errorf("expected the refused attempt not to count but got %v", lockout)
+		return
+	}
+
+	if err := reserveLogin(limiter, testNow.Add(30*time.Second), key); err != nil {
+		t.Errorf("expected the lockout to be over but got %s", err.Error())
+	}
+}
+
+func TestReserveLoginReturnsLongestLockout(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	usernameKey, ipKey := UsernameKey("admin"), IPKey("192.0.2.1")
+	_, _, _ = limiter.Reserve(usernameKey, testNow, testNow, func(uint) time.Duration { return time.Minute })
+	_, _, _ = limiter.Reserve(ipKey, testNow, testNow, func(uint) time.Duration { return time.Hour })
+
+	err := reserveLogin(limiter, testNow, usernameKey, ipKey)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if err.(apperrors.TooManyRequestsError).RetryAfter != time.Hour {
+		t.Errorf("expected to retry after 1h but got %s", err.(apperrors.TooManyRequestsError).RetryAfter)
+	}
+}
+
+func TestReserveLoginReleasesOtherKeysWhenLockedOut(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	usernameKey, ipKey := UsernameKey("admin"), IPKey("192.0.2.1")
+	_, _, _ = limiter.Reserve(ipKey, testNow, testNow, func(uint) time.Duration { return time.Hour })
+
+	if err := reserveLogin(limiter, testNow, usernameKey, ipKey); err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if lockout, _ := limiter.GetLockout(usernameKey); lockout != nil {
+		t.Errorf("expected the username not to count a refused attempt but got %v", lockout)
+	}
+}
+
+func TestReleaseLogin(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	key := IPKey("192.0.2.1")
+
+	for i := 0; i < 20; i++ {
+		_ = reserveLogin(limiter, testNow, key)
+	}
+	if err := releaseLogin(limiter, key); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	lockout, _ := limiter.GetLockout(key)
+	if lockout == nil || lockout.Failures != 19 || lockout.LockedUntil != nil {
+		t.Errorf("expected 19 failures without a lockout but got %v", lockout)
+	}
+}
+
+func TestReserveLoginForgetsOldFailures(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	key := UsernameKey("admin")
+
+	for i := 0; i < 4; i++ {
+		_ = reserveLogin(limiter, testNow, key)
+	}
+	_ = reserveLogin(limiter, testNow.Add(failureWindow+time.Minute), key)
+
+	lockout, _ := limiter.GetLockout(key)
+	if lockout == nil || lockout.Failures != 1 {
+		t.Errorf("expected 1 failure but got %v", lockout)
+	}
+}
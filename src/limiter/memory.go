@@ -0,0 +1,104 @@
+package limiter
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryLoginLimiter keeps the failed logins in memory, they are lost on restart and not shared between instances
+type MemoryLoginLimiter struct {
+	mutex    sync.Mutex
+	lockouts map[string]*Lockout
+}
+
+func NewMemoryLoginLimiter() *MemoryLoginLimiter {
+	return &MemoryLoginLimiter{
+		lockouts: make(map[string]*Lockout),
+	}
+}
+
+func (m *MemoryLoginLimiter) GetLockout(key string) (*Lockout, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	lockout, ok := m.lockouts[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *lockout
+	return &copied, nil
+}
+
+func (m *MemoryLoginLimiter) GetLockouts() ([]Lockout, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	lockouts := make([]Lockout, 0, len(m.lockouts))
+	for _, lockout := range m.lockouts {
+		lockouts = append(lockouts, *lockout)
+	}
+	slices.SortFunc(lockouts, func(a, b Lockout) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return lockouts, nil
+}
+
+// Reserve counts a login attempt of the key unless it is locked out, keys without failures since the given time
+// are forgotten first so guessing random usernames cannot grow the map forever
+func (m *MemoryLoginLimiter) Reserve(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (Lockout, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for staleKey, lockout := range m.lockouts {
+		if lockout.LastFailureAt.Before(since) && (lockout.LockedUntil == nil || lockout.LockedUntil.Before(now)) {
+			delete(m.lockouts, staleKey)
+		}
+	}
+
+	lockout, ok := m.lockouts[key]
+	if !ok {
+		lockout = &Lockout{Key: key}
+		m.lockouts[key] = lockout
+	}
+	if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+		return *lockout, false, nil
+	}
+
+	lockout.Failures++
+	lockout.LastFailureAt = now
+	lockout.LockedUntil = nil
+	if duration := lockoutFor(lockout.Failures); duration > 0 {
+		lockedUntil := now.Add(duration)
+		lockout.LockedUntil = &lockedUntil
+	}
+	return *lockout, true, nil
+}
+
+// Release takes back a reserved attempt, keys left without failures are forgotten
+func (m *MemoryLoginLimiter) Release(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	lockout, ok := m.lockouts[key]
+	if !ok {
+		return nil
+	}
+	lockout.LockedUntil = nil
+	if lockout.Failures > 0 {
+		lockout.Failures--
+	}
+	if lockout.Failures == 0 {
+		delete(m.lockouts, key)
+	}
+	return nil
+}
+
+func (m *MemoryLoginLimiter) Clear(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.lockouts, key)
+	return nil
+}
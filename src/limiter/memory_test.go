@@ -0,0 +1,87 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+func noLockout(uint) time.Duration {
+	return 0
+}
+
+func TestMemoryLoginLimiterReserve(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+
+	_, _, _ = limiter.Reserve("username:admin", testNow, testNow.Add(-time.Hour), noLockout)
+	lockout, reserved, err := limiter.Reserve("username:admin", testNow, testNow.Add(-time.Hour), noLockout)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if !reserved || lockout.Failures != 2 || !lockout.LastFailureAt.Equal(testNow) {
+		t.Errorf("expected 2 failures at %s but got %v", testNow, lockout)
+	}
+}
+
+func TestMemoryLoginLimiterForgetsStaleKeys(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+
+	_, _, _ = limiter.Reserve("username:guess1", testNow, testNow, noLockout)
+	_, _, _ = limiter.Reserve("username:guess2", testNow.Add(2*time.Hour), testNow.Add(time.Hour), noLockout)
+
+	lockouts, _ := limiter.GetLockouts()
+	if len(lockouts) != 1 || lockouts[0].Key != "username:guess2" {
+		t.Errorf("expected only username:guess2 but got %v", lockouts)
+	}
+}
+
+func TestMemoryLoginLimiterLockAndClear(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	lockFor := func(uint) time.Duration { return time.Minute }
+
+	if _, reserved, err := limiter.Reserve("ip:192.0.2.1", testNow, testNow, lockFor); err != nil || !reserved {
+		t.Errorf("expected the attempt to be reserved but got %v %v", reserved, err)
+		return
+	}
+	lockout, reserved, _ := limiter.Reserve("ip:192.0.2.1", testNow.Add(time.Second), testNow, lockFor)
+	if reserved || lockout.LockedUntil == nil || !lockout.LockedUntil.Equal(testNow.Add(time.Minute)) {
+		t.Errorf("expected a lockout until %s but got %v", testNow.Add(time.Minute), lockout)
+		return
+	}
+
+	if err := limiter.Clear("ip:192.0.2.1"); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if lockout, _ := limiter.GetLockout("ip:192.0.2.1"); lockout != nil {
+		t.Errorf("expected no lockout but got %v", lockout)
+	}
+}
+
+func TestMemoryLoginLimiterReleaseForgetsKeyWithoutFailures(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	_, _, _ = limiter.Reserve("ip:192.0.2.1", testNow, testNow, noLockout)
+
+	if err := limiter.Release("ip:192.0.2.1"); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if lockout, _ := limiter.GetLockout("ip:192.0.2.1"); lockout != nil {
+		t.Errorf("expected no lockout but got %v", lockout)
+	}
+}
+
+func TestMemoryLoginLimiterGetLockoutsSorted(t *testing.T) {
+	limiter := NewMemoryLoginLimiter()
+	_, _, _ = limiter.Reserve("username:bob", testNow, testNow, noLockout)
+	_, _, _ = limiter.Reserve("ip:192.0.2.1", testNow, testNow, noLockout)
+
+	lockouts, err := limiter.GetLockouts()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(lockouts) != 2 || lockouts[0].Key != "ip:192.0.2.1" || lockouts[1].Key != "username:bob" {
+		t.Errorf("expected lockouts sorted by key but got %v", lockouts)
+	}
+}
@@ -5,11 +5,20 @@ import (
 	"fmt"
 	_ "github.com/joho/godotenv/autoload"
 	"log"
+	"os"
 	"the-wedding-game-api/routes"
+	"time"
+	_ "time/tzdata"
 )
 
 func main() {
 	migrate()
+	if len(os.Args) > 1 && os.Args[1] == "set-admin-password" {
+		setAdminPassword(os.Args[2:])
+		return
+	}
+
+	go sweepExpiredAccessTokens(time.Hour)
 	router := routes.GetRouter()
 
 	port := flag.String("p", "8080", "port to run the server on")
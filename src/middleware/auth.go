@@ -22,24 +22,28 @@ func GetCurrentUser(c *gin.Context) (models.User, error) {
 	return user.(models.User), nil
 }
 
-func CheckIsAdmin(c *gin.Context) error {
+// CheckPermission returns an authorization error unless the role of the current user grants the permission
+func CheckPermission(c *gin.Context, permission types.Permission) error {
 	user, err := GetCurrentUser(c)
 	if err != nil {
 		return err
 	}
 
-	if user.Role != types.Admin {
+	if !user.HasPermission(permission) {
 		return apperrors.NewAuthorizationError()
 	}
 	return nil
 }
 
-func IsAdmin(c *gin.Context) {
-	if err := CheckIsAdmin(c); err != nil {
-		handleError(c, err)
-		return
+// RequirePermission only lets requests through from users whose role grants the permission
+func RequirePermission(permission types.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := CheckPermission(c, permission); err != nil {
+			handleError(c, err)
+			return
+		}
+		c.Next()
 	}
-	c.Next()
 }
 
 func IsLoggedIn(c *gin.Context) {
@@ -51,17 +55,25 @@ func IsLoggedIn(c *gin.Context) {
 	c.Next()
 }
 
-func parseAuthorizationForUser(c *gin.Context) (models.User, error) {
+// GetAccessToken returns the access token the request is made with
+func GetAccessToken(c *gin.Context) (string, error) {
 	accessToken := c.GetHeader("Authorization")
 	if accessToken == "" {
-		return models.User{}, apperrors.NewAuthenticationError("access token is not provided")
+		return "", apperrors.NewAuthenticationError("access token is not provided")
 	}
 
 	if len(accessToken) < 7 || accessToken[:7] != "Bearer " {
-		return models.User{}, apperrors.NewAuthenticationError("invalid access token format")
+		return "", apperrors.NewAuthenticationError("invalid access token format")
 	}
 
-	accessToken = accessToken[7:]
+	return accessToken[7:], nil
+}
+
+func parseAuthorizationForUser(c *gin.Context) (models.User, error) {
+	accessToken, err := GetAccessToken(c)
+	if err != nil {
+		return models.User{}, err
+	}
 
 	user, err := models.GetUserByAccessToken(accessToken)
 	if err != nil {
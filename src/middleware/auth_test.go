@@ -6,12 +6,14 @@ import (
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 var (
-	testAccessToken = models.AccessToken{Token: "test_token", UserID: 1, ExpiresOn: 1}
+	testAccessToken = models.AccessToken{Token: "test_token", UserID: 1, ExpiresOn: time.Now().Add(time.Hour).Unix()}
 	testUser        = models.User{Username: "test_username", Role: types.Player}
 	testUserAdmin   = models.User{Username: "test_username", Role: types.Admin}
+	testModerator   = models.User{Username: "test_username", Role: types.Moderator}
 )
 
 func SetupMockDb() *models.MockDB {
@@ -89,7 +91,7 @@ func TestGetCurrentUserInvalidAccessTokenFormat(t *testing.T) {
 	}
 }
 
-func TestCheckIsAdmin(t *testing.T) {
+func TestCheckPermissionAdmin(t *testing.T) {
 	SetupMockDb()
 	createTestAccessToken(testAccessToken)
 	createTestUser(testUserAdmin)
@@ -97,13 +99,46 @@ func TestCheckIsAdmin(t *testing.T) {
 	request := test.GenerateBasicRequest()
 	request.Request.Header.Set("Authorization", "Bearer test_token")
 
-	err := CheckIsAdmin(request)
+	err := CheckPermission(request, types.ManageChallengesPermission)
 	if err != nil {
 		t.Errorf("expected nil but got %s", err.Error())
 	}
 }
 
-func TestCheckIsAdminNotAdmin(t *testing.T) {
+func TestCheckPermissionModerator(t *testing.T) {
+	SetupMockDb()
+	createTestAccessToken(testAccessToken)
+	createTestUser(testModerator)
+
+	request := test.GenerateBasicRequest()
+	request.Request.Header.Set("Authorization", "Bearer test_token")
+
+	err := CheckPermission(request, types.ModerateSubmissionsPermission)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestCheckPermissionModeratorDenied(t *testing.T) {
+	SetupMockDb()
+	createTestAccessToken(testAccessToken)
+	createTestUser(testModerator)
+
+	request := test.GenerateBasicRequest()
+	request.Request.Header.Set("Authorization", "Bearer test_token")
+
+	err := CheckPermission(request, types.ViewAnswersPermission)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsAuthorizationError(err) {
+		t.Errorf("expected authorization error but got %s", err.Error())
+	}
+}
+
+func TestCheckPermissionPlayer(t *testing.T) {
 	SetupMockDb()
 	createTestAccessToken(testAccessToken)
 	createTestUser(testUser)
@@ -111,7 +146,7 @@ func TestCheckIsAdminNotAdmin(t *testing.T) {
 	request := test.GenerateBasicRequest()
 	request.Request.Header.Set("Authorization", "Bearer test_token")
 
-	err := CheckIsAdmin(request)
+	err := CheckPermission(request, types.ViewSubmissionsPermission)
 	if err == nil {
 		t.Errorf("expected error but got nil")
 		return
@@ -126,9 +161,9 @@ func TestCheckIsAdminNotAdmin(t *testing.T) {
 	}
 }
 
-func TestCheckIsAdminNoAccessToken(t *testing.T) {
+func TestCheckPermissionNoAccessToken(t *testing.T) {
 	request := test.GenerateBasicRequest()
-	err := CheckIsAdmin(request)
+	err := CheckPermission(request, types.ManageChallengesPermission)
 	if err == nil {
 		t.Errorf("expected error but got nil")
 		return
@@ -143,11 +178,11 @@ func TestCheckIsAdminNoAccessToken(t *testing.T) {
 	}
 }
 
-func TestCheckIsAdminInvalidAccessTokenFormat(t *testing.T) {
+func TestCheckPermissionInvalidAccessTokenFormat(t *testing.T) {
 	request := test.GenerateBasicRequest()
 	request.Request.Header.Set("Authorization", "token")
 
-	err := CheckIsAdmin(request)
+	err := CheckPermission(request, types.ManageChallengesPermission)
 	if err == nil {
 		t.Errorf("expected error but got nil")
 		return
@@ -161,3 +196,36 @@ func TestCheckIsAdminInvalidAccessTokenFormat(t *testing.T) {
 		t.Errorf("expected invalid access token format but got %s", err.Error())
 	}
 }
+
+func TestGetCurrentUserExpiredAccessToken(t *testing.T) {
+	SetupMockDb()
+	createTestAccessToken(models.AccessToken{Token: "test_token", UserID: 1, ExpiresOn: time.Now().Add(-time.Minute).Unix()})
+	createTestUser(testUser)
+
+	request := test.GenerateBasicRequest()
+	request.Request.Header.Set("Authorization", "Bearer test_token")
+	_, err := GetCurrentUser(request)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsAccessTokenExpiredError(err) {
+		t.Errorf("expected access token expired error but got %s", err.Error())
+	}
+}
+
+func TestGetAccessToken(t *testing.T) {
+	request := test.GenerateBasicRequest()
+	request.Request.Header.Set("Authorization", "Bearer test_token")
+
+	accessToken, err := GetAccessToken(request)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if accessToken != "test_token" {
+		t.Errorf("expected test_token but got %s", accessToken)
+	}
+}
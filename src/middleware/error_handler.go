@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	apperrors "the-wedding-game-api/errors"
 )
@@ -29,6 +32,28 @@ func handleError(c *gin.Context, err error) {
 		return
 	}
 
+	if apperrors.IsAccessTokenExpiredError(err) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+			"code":    "ACCESS_TOKEN_EXPIRED",
+		})
+		c.Abort()
+		return
+	}
+
+	var tooManyRequestsError apperrors.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsError) {
+		retryAfter := int(math.Ceil(tooManyRequestsError.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		c.Abort()
+		return
+	}
+
 	if apperrors.IsAuthenticationError(err) {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"status":  "error",
@@ -6,6 +6,7 @@ import (
 	"testing"
 	test "the-wedding-game-api/_tests"
 	apperrors "the-wedding-game-api/errors"
+	"time"
 )
 
 func TestErrorHandlerWithNoError(t *testing.T) {
@@ -29,6 +30,42 @@ func TestErrorHandlerWithAccessTokenNotFoundError(t *testing.T) {
 	}
 }
 
+func TestErrorHandlerWithAccessTokenExpiredError(t *testing.T) {
+	request := test.GenerateBasicRequest()
+	blw := test.AttachBodyLogWriter(request)
+	_ = request.Error(apperrors.NewAccessTokenExpiredError())
+	ErrorHandler(request)
+
+	if request.Writer.Status() != http.StatusUnauthorized {
+		t.Errorf("expected 401 but got %d", request.Writer.Status())
+	}
+
+	expectedBody := "{\"code\":\"ACCESS_TOKEN_EXPIRED\",\"message\":\"access token expired\",\"status\":\"error\"}"
+	if blw.GetBody() != expectedBody {
+		t.Errorf("expected %s but got %s", expectedBody, blw.GetBody())
+	}
+}
+
+func TestErrorHandlerWithTooManyRequestsError(t *testing.T) {
+	request := test.GenerateBasicRequest()
+	blw := test.AttachBodyLogWriter(request)
+	_ = request.Error(apperrors.NewTooManyRequestsError("too many login attempts", 1500*time.Millisecond))
+	ErrorHandler(request)
+
+	if request.Writer.Status() != http.StatusTooManyRequests {
+		t.Errorf("expected 429 but got %d", request.Writer.Status())
+	}
+
+	if request.Writer.Header().Get("Retry-After") != "2" {
+		t.Errorf("expected Retry-After 2 but got %s", request.Writer.Header().Get("Retry-After"))
+	}
+
+	expectedBody := "{\"message\":\"too many login attempts\",\"status\":\"error\"}"
+	if blw.GetBody() != expectedBody {
+		t.Errorf("expected %s but got %s", expectedBody, blw.GetBody())
+	}
+}
+
 func TestErrorHandlerWithAuthenticationError(t *testing.T) {
 	request := test.GenerateBasicRequest()
 	blw := test.AttachBodyLogWriter(request)
@@ -0,0 +1,73 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateGetAnswersRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	return uint(id), nil
+}
+
+func ValidateCreateAnswerRequest(c *gin.Context) (uint, types.CreateAnswerRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.CreateAnswerRequest{}, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	var createAnswerRequest types.CreateAnswerRequest
+	if err := c.BindJSON(&createAnswerRequest); err != nil {
+		return 0, types.CreateAnswerRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&createAnswerRequest); err != nil {
+		return 0, types.CreateAnswerRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), createAnswerRequest, nil
+}
+
+func ValidateUpdateAnswerRequest(c *gin.Context) (uint, uint, types.UpdateAnswerRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, types.UpdateAnswerRequest{}, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	answerId, err := strconv.Atoi(c.Param("answerId"))
+	if err != nil {
+		return 0, 0, types.UpdateAnswerRequest{}, apperrors.NewValidationError(constants.InvalidAnswerIDError)
+	}
+
+	var updateAnswerRequest types.UpdateAnswerRequest
+	if err := c.BindJSON(&updateAnswerRequest); err != nil {
+		return 0, 0, types.UpdateAnswerRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&updateAnswerRequest); err != nil {
+		return 0, 0, types.UpdateAnswerRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), uint(answerId), updateAnswerRequest, nil
+}
+
+func ValidateDeleteAnswerRequest(c *gin.Context) (uint, uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	answerId, err := strconv.Atoi(c.Param("answerId"))
+	if err != nil {
+		return 0, 0, apperrors.NewValidationError(constants.InvalidAnswerIDError)
+	}
+
+	return uint(id), uint(answerId), nil
+}
@@ -0,0 +1,132 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateGetAnswersRequest(t *testing.T) {
+	c := generateRequestWithParamsOnly(map[string]string{"id": "1"})
+
+	challengeId, err := ValidateGetAnswersRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if challengeId != 1 {
+		t.Error("Expected challenge id to be 1, got", challengeId)
+	}
+}
+
+func TestValidateGetAnswersRequestInvalidId(t *testing.T) {
+	c := generateRequestWithParamsOnly(map[string]string{"id": "abc"})
+
+	_, err := ValidateGetAnswersRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+	if err.Error() != "invalid challenge id" {
+		t.Error("Expected error message to be 'invalid challenge id', got", err.Error())
+	}
+}
+
+func TestValidateCreateAnswerRequest(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"value": "Chomolungma"}, map[string]string{"id": "1"})
+
+	challengeId, request, err := ValidateCreateAnswerRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if challengeId != 1 {
+		t.Error("Expected challenge id to be 1, got", challengeId)
+	}
+	if request.Value != "Chomolungma" {
+		t.Error("Expected value to be Chomolungma, got", request.Value)
+	}
+}
+
+func TestValidateCreateAnswerRequestMissingValue(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{}, map[string]string{"id": "1"})
+
+	_, _, err := ValidateCreateAnswerRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateUpdateAnswerRequest(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"value": "Chomolungma"},
+		map[string]string{"id": "1", "answerId": "2"})
+
+	challengeId, answerId, request, err := ValidateUpdateAnswerRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if challengeId != 1 {
+		t.Error("Expected challenge id to be 1, got", challengeId)
+	}
+	if answerId != 2 {
+		t.Error("Expected answer id to be 2, got", answerId)
+	}
+	if request.Value != "Chomolungma" {
+		t.Error("Expected value to be Chomolungma, got", request.Value)
+	}
+}
+
+func TestValidateUpdateAnswerRequestInvalidAnswerId(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"value": "Chomolungma"},
+		map[string]string{"id": "1", "answerId": "abc"})
+
+	_, _, _, err := ValidateUpdateAnswerRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid answer id" {
+		t.Error("Expected error message to be 'invalid answer id', got", err.Error())
+	}
+}
+
+func TestValidateDeleteAnswerRequest(t *testing.T) {
+	c := generateRequestWithParamsOnly(map[string]string{"id": "1", "answerId": "2"})
+
+	challengeId, answerId, err := ValidateDeleteAnswerRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if challengeId != 1 || answerId != 2 {
+		t.Error("Expected ids to be 1 and 2, got", challengeId, answerId)
+	}
+}
+
+func TestValidateDeleteAnswerRequestInvalidChallengeId(t *testing.T) {
+	c := generateRequestWithParamsOnly(map[string]string{"id": "abc", "answerId": "2"})
+
+	_, _, err := ValidateDeleteAnswerRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid challenge id" {
+		t.Error("Expected error message to be 'invalid challenge id', got", err.Error())
+	}
+}
@@ -0,0 +1,20 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateChangePasswordRequest(c *gin.Context) (types.ChangePasswordRequest, error) {
+	var changePasswordRequest types.ChangePasswordRequest
+	if err := c.BindJSON(&changePasswordRequest); err != nil {
+		return types.ChangePasswordRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&changePasswordRequest); err != nil {
+		return types.ChangePasswordRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return changePasswordRequest, nil
+}
@@ -0,0 +1,34 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateChangePasswordRequest(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"current_password": "current password", "new_password": "new password"})
+
+	changePasswordRequest, err := ValidateChangePasswordRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if changePasswordRequest.CurrentPassword != "current password" || changePasswordRequest.NewPassword != "new password" {
+		t.Error("Expected the passwords of the request, got", changePasswordRequest)
+	}
+}
+
+func TestValidateChangePasswordRequestShortPassword(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"current_password": "current password", "new_password": "short"})
+
+	_, err := ValidateChangePasswordRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidatePublishBingoBoardRequest(c *gin.Context) (types.PublishBingoBoardRequest, error) {
+	var publishBingoBoardRequest types.PublishBingoBoardRequest
+	if err := c.BindJSON(&publishBingoBoardRequest); err != nil {
+		return types.PublishBingoBoardRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&publishBingoBoardRequest); err != nil {
+		return types.PublishBingoBoardRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	// Every cell holds a different challenge, so a single completion never counts twice
+	challengeIds := publishBingoBoardRequest.Challenges
+	size := publishBingoBoardRequest.Size
+	seen := make(map[uint]bool, len(challengeIds))
+	for _, challengeId := range challengeIds {
+		seen[challengeId] = true
+	}
+	if uint(len(challengeIds)) != size*size || len(seen) != len(challengeIds) {
+		return types.PublishBingoBoardRequest{}, apperrors.NewValidationError(constants.InvalidBingoBoardError)
+	}
+
+	return publishBingoBoardRequest, nil
+}
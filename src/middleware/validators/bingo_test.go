@@ -0,0 +1,81 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidatePublishBingoBoardRequest(t *testing.T) {
+	requestData := map[string]interface{}{
+		"size":            2,
+		"challenges":      []uint{4, 3, 2, 1},
+		"line_bonus":      10,
+		"full_card_bonus": 50,
+		"shuffle":         true,
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidatePublishBingoBoardRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Size != 2 || len(request.Challenges) != 4 || request.LineBonus != 10 || request.FullCardBonus != 50 || !request.Shuffle {
+		t.Error("Expected a shuffled 2 × 2 board, got", request)
+	}
+}
+
+func TestValidatePublishBingoBoardRequestWithMissingCells(t *testing.T) {
+	requestData := map[string]interface{}{
+		"size":       3,
+		"challenges": []uint{1, 2, 3, 4},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidatePublishBingoBoardRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) || err.Error() != "challenges must fill every cell of the board with a different challenge" {
+		t.Error("Expected invalid bingo board error, got", err)
+	}
+}
+
+func TestValidatePublishBingoBoardRequestWithDuplicateChallenge(t *testing.T) {
+	requestData := map[string]interface{}{
+		"size":       2,
+		"challenges": []uint{1, 2, 3, 3},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidatePublishBingoBoardRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidatePublishBingoBoardRequestTooLarge(t *testing.T) {
+	requestData := map[string]interface{}{
+		"size":       8,
+		"challenges": []uint{1},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidatePublishBingoBoardRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
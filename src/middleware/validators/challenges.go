@@ -3,7 +3,9 @@ package validators
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"regexp"
 	"strconv"
+	"the-wedding-game-api/config"
 	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
@@ -27,18 +29,60 @@ func ValidateCreateChallengeRequest(c *gin.Context) (types.CreateChallengeReques
 		return types.CreateChallengeRequest{}, apperrors.NewValidationError(err.Error())
 	}
 
-	if createChallengeRequest.Type != types.UploadPhotoChallenge && createChallengeRequest.Type != types.AnswerQuestionChallenge {
+	if createChallengeRequest.Type != types.UploadPhotoChallenge && createChallengeRequest.Type != types.AnswerQuestionChallenge &&
+		createChallengeRequest.Type != types.MultipleChoiceChallenge && createChallengeRequest.Type != types.ScanCodeChallenge &&
+		createChallengeRequest.Type != types.UploadVideoChallenge && createChallengeRequest.Type != types.UploadAudioChallenge &&
+		createChallengeRequest.Type != types.PredictionChallenge && createChallengeRequest.Type != types.OrderingChallenge &&
+		createChallengeRequest.Type != types.CheckInChallenge {
 		return types.CreateChallengeRequest{}, apperrors.NewValidationError(constants.InvalidChallengeTypeError)
 	}
 
-	if createChallengeRequest.Type == types.AnswerQuestionChallenge && createChallengeRequest.Answer == "" {
+	if createChallengeRequest.Type == types.AnswerQuestionChallenge && createChallengeRequest.Answer == "" &&
+		len(createChallengeRequest.Answers) == 0 {
 		return types.CreateChallengeRequest{}, apperrors.NewValidationError(constants.AnswerRequiredError)
 	}
 
+	if createChallengeRequest.Type == types.AnswerQuestionChallenge {
+		if err := validateAnswersMatching(createChallengeRequest.Answer, createChallengeRequest.Answers,
+			createChallengeRequest.AnswerMatching); err != nil {
+			return types.CreateChallengeRequest{}, err
+		}
+	}
+
+	if createChallengeRequest.Type == types.MultipleChoiceChallenge {
+		if err := validateChallengeOptions(createChallengeRequest.Options); err != nil {
+			return types.CreateChallengeRequest{}, err
+		}
+	}
+
+	if createChallengeRequest.Type == types.OrderingChallenge {
+		if err := validateChallengeItems(createChallengeRequest.Items); err != nil {
+			return types.CreateChallengeRequest{}, err
+		}
+	}
+
+	if createChallengeRequest.Type == types.CheckInChallenge {
+		if err := validateCheckInLocation(createChallengeRequest.Location); err != nil {
+			return types.CreateChallengeRequest{}, err
+		}
+	}
+
 	if !utils.IsURLStrict(createChallengeRequest.Image) {
 		return types.CreateChallengeRequest{}, apperrors.NewValidationError(constants.InvalidImageURLError)
 	}
 
+	if err := validateAvailabilityWindow(createChallengeRequest.AvailableFrom, createChallengeRequest.AvailableUntil); err != nil {
+		return types.CreateChallengeRequest{}, err
+	}
+
+	if err := validateHints(createChallengeRequest.Points, createChallengeRequest.Hints); err != nil {
+		return types.CreateChallengeRequest{}, err
+	}
+
+	if err := validateScoringRules(createChallengeRequest.Points, createChallengeRequest.Scoring); err != nil {
+		return types.CreateChallengeRequest{}, err
+	}
+
 	return createChallengeRequest, nil
 }
 
@@ -71,6 +115,57 @@ func ValidateUpdateChallengeRequest(c *gin.Context) (uint, types.UpdateChallenge
 		return 0, types.UpdateChallengeRequest{}, apperrors.NewValidationError(constants.InvalidImageURLError)
 	}
 
+	// The window is optional on update, an omitted end is kept and checked against the other one by the model
+	var availableFrom, availableUntil string
+	if updateChallengeRequest.AvailableFrom != nil {
+		availableFrom = *updateChallengeRequest.AvailableFrom
+	}
+	if updateChallengeRequest.AvailableUntil != nil {
+		availableUntil = *updateChallengeRequest.AvailableUntil
+	}
+	if err := validateAvailabilityWindow(availableFrom, availableUntil); err != nil {
+		return 0, types.UpdateChallengeRequest{}, err
+	}
+
+	if err := validateHints(updateChallengeRequest.Points, updateChallengeRequest.Hints); err != nil {
+		return 0, types.UpdateChallengeRequest{}, err
+	}
+
+	// Scoring rules are optional on update, existing rules are kept if none are provided
+	if updateChallengeRequest.Scoring != nil {
+		if err := validateScoringRules(updateChallengeRequest.Points, *updateChallengeRequest.Scoring); err != nil {
+			return 0, types.UpdateChallengeRequest{}, err
+		}
+	}
+
+	if updateChallengeRequest.Type == types.AnswerQuestionChallenge {
+		if err := validateAnswersMatching(updateChallengeRequest.Answer, updateChallengeRequest.Answers,
+			updateChallengeRequest.AnswerMatching); err != nil {
+			return 0, types.UpdateChallengeRequest{}, err
+		}
+	}
+
+	// Options are optional on update, existing options are kept if none are provided
+	if updateChallengeRequest.Type == types.MultipleChoiceChallenge && len(updateChallengeRequest.Options) > 0 {
+		if err := validateChallengeOptions(updateChallengeRequest.Options); err != nil {
+			return 0, types.UpdateChallengeRequest{}, err
+		}
+	}
+
+	// Items are optional on update, existing items are kept if none are provided
+	if updateChallengeRequest.Type == types.OrderingChallenge && len(updateChallengeRequest.Items) > 0 {
+		if err := validateChallengeItems(updateChallengeRequest.Items); err != nil {
+			return 0, types.UpdateChallengeRequest{}, err
+		}
+	}
+
+	// The location is optional on update, the existing location is kept if none is provided
+	if updateChallengeRequest.Type == types.CheckInChallenge && updateChallengeRequest.Location != nil {
+		if err := validateCheckInLocation(updateChallengeRequest.Location); err != nil {
+			return 0, types.UpdateChallengeRequest{}, err
+		}
+	}
+
 	return uint(id), updateChallengeRequest, nil
 }
 
@@ -101,6 +196,24 @@ func ValidateGetSubmissionsRequest(c *gin.Context) (uint, error) {
 	return uint(id), nil
 }
 
+func ValidateGetAttemptsRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	return uint(id), nil
+}
+
+func ValidateGetQRCodeRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	return uint(id), nil
+}
+
 func ValidateGetAnswerRequest(c *gin.Context) (uint, error) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -110,6 +223,15 @@ func ValidateGetAnswerRequest(c *gin.Context) (uint, error) {
 	return uint(id), nil
 }
 
+func ValidateRevealHintRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	return uint(id), nil
+}
+
 func ValidateDeleteChallengeRequest(c *gin.Context) (uint, error) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -118,3 +240,122 @@ func ValidateDeleteChallengeRequest(c *gin.Context) (uint, error) {
 
 	return uint(id), nil
 }
+
+// validateAnswersMatching validates every accepted answer of a request against the matching mode
+func validateAnswersMatching(answer string, answers []string, answerMatching types.AnswerMatching) error {
+	if len(answers) == 0 {
+		return validateAnswerMatching(answer, answerMatching)
+	}
+
+	for _, value := range append([]string{answer}, answers...) {
+		if value == "" {
+			continue
+		}
+		if err := validateAnswerMatching(value, answerMatching); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateAnswerMatching ensures the answer can be interpreted by the requested matching mode
+func validateAnswerMatching(answer string, answerMatching types.AnswerMatching) error {
+	if answerMatching.Mode != types.RegexAnswerMatch && answerMatching.Mode != types.NumericAnswerMatch {
+		return nil
+	}
+
+	if answer == "" {
+		return apperrors.NewValidationError(constants.AnswerRequiredForMatchModeError)
+	}
+
+	if answerMatching.Mode == types.RegexAnswerMatch {
+		if _, err := regexp.Compile(answer); err != nil {
+			return apperrors.NewValidationError(constants.InvalidAnswerPatternError)
+		}
+	}
+
+	if answerMatching.Mode == types.NumericAnswerMatch {
+		if _, err := utils.ParseNumber(answer); err != nil {
+			return apperrors.NewValidationError(constants.InvalidNumericAnswerError)
+		}
+	}
+
+	return nil
+}
+
+func validateAvailabilityWindow(availableFrom string, availableUntil string) error {
+	from, err := utils.ParseEventTime(availableFrom, config.GetEventLocation())
+	if err != nil {
+		return apperrors.NewValidationError(constants.InvalidAvailableFromError)
+	}
+
+	until, err := utils.ParseEventTime(availableUntil, config.GetEventLocation())
+	if err != nil {
+		return apperrors.NewValidationError(constants.InvalidAvailableUntilError)
+	}
+
+	if from != nil && until != nil && !from.Before(*until) {
+		return apperrors.NewValidationError(constants.InvalidAvailabilityWindowError)
+	}
+
+	return nil
+}
+
+func validateHints(points uint, hints []types.ChallengeHintInput) error {
+	var totalCost uint
+	for _, hint := range hints {
+		totalCost += hint.Cost
+	}
+
+	if totalCost > points {
+		return apperrors.NewValidationError(constants.InvalidHintCostError)
+	}
+
+	return nil
+}
+
+func validateChallengeOptions(options []types.ChallengeOptionInput) error {
+	if len(options) < 2 {
+		return apperrors.NewValidationError(constants.OptionsRequiredError)
+	}
+
+	for _, option := range options {
+		if option.Correct {
+			return nil
+		}
+	}
+
+	return apperrors.NewValidationError(constants.CorrectOptionRequiredError)
+}
+
+func validateChallengeItems(items []types.ChallengeItemInput) error {
+	if len(items) < 2 {
+		return apperrors.NewValidationError(constants.ItemsRequiredError)
+	}
+	return nil
+}
+
+func validateCheckInLocation(location *types.CheckInLocation) error {
+	if location == nil || location.Radius <= 0 {
+		return apperrors.NewValidationError(constants.LocationRequiredError)
+	}
+	return nil
+}
+
+func validateScoringRules(points uint, scoringRules types.ScoringRules) error {
+	if (scoringRules.FirstSolverBonus > 0) != (scoringRules.FirstSolverCount > 0) {
+		return apperrors.NewValidationError(constants.InvalidFirstSolverBonusError)
+	}
+
+	decays := scoringRules.Decay == types.LinearPointsDecay || scoringRules.Decay == types.StepPointsDecay
+	if decays && (scoringRules.DecayPoints == 0 || scoringRules.DecayIntervalMinutes == 0) {
+		return apperrors.NewValidationError(constants.InvalidPointsDecayError)
+	}
+
+	if scoringRules.MinimumPoints > points {
+		return apperrors.NewValidationError(constants.InvalidMinimumPointsError)
+	}
+
+	return nil
+}
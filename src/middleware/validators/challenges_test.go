@@ -319,6 +319,226 @@ func TestValidateCreateChallengeRequestInvalidChallengeType(t *testing.T) {
 	}
 }
 
+func TestValidateCreateChallengeRequestScanCodeWithoutAnswer(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "SCAN_CODE",
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Type != types.ScanCodeChallenge {
+		t.Error("Expected type to be SCAN_CODE, got", request.Type)
+	}
+}
+
+func TestValidateCreateChallengeRequestUploadVideo(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":                 validCreateChallengeRequestUpload.Name,
+		"description":          validCreateChallengeRequestUpload.Description,
+		"points":               validCreateChallengeRequestUpload.Points,
+		"Image":                validCreateChallengeRequestUpload.Image,
+		"type":                 "UPLOAD_VIDEO",
+		"max_duration_seconds": 30,
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Type != types.UploadVideoChallenge || request.MaxDuration != 30 {
+		t.Error("Expected an UPLOAD_VIDEO challenge of at most 30 seconds, got", request)
+	}
+}
+
+func TestValidateCreateChallengeRequestUploadAudio(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":                 validCreateChallengeRequestUpload.Name,
+		"description":          validCreateChallengeRequestUpload.Description,
+		"points":               validCreateChallengeRequestUpload.Points,
+		"Image":                validCreateChallengeRequestUpload.Image,
+		"type":                 "UPLOAD_AUDIO",
+		"max_duration_seconds": 60,
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Type != types.UploadAudioChallenge || request.MaxDuration != 60 {
+		t.Error("Expected an UPLOAD_AUDIO challenge of at most 60 seconds, got", request)
+	}
+}
+
+func TestValidateCreateChallengeRequestPrediction(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "PREDICTION",
+		"prediction":  map[string]interface{}{"mode": "CLOSEST", "closest": 3},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Type != types.PredictionChallenge || request.Prediction.Mode != types.ClosestPrediction || request.Prediction.Closest != 3 {
+		t.Error("Expected a PREDICTION challenge won by the 3 closest guesses, got", request)
+	}
+}
+
+func TestValidateCreateChallengeRequestPredictionInvalidMode(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "PREDICTION",
+		"prediction":  map[string]interface{}{"mode": "FURTHEST"},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateCreateChallengeRequestOrdering(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":           validCreateChallengeRequestUpload.Name,
+		"description":    validCreateChallengeRequestUpload.Description,
+		"points":         validCreateChallengeRequestUpload.Points,
+		"Image":          validCreateChallengeRequestUpload.Image,
+		"type":           "ORDERING",
+		"items":          []map[string]interface{}{{"value": "first date"}, {"value": "engagement"}, {"value": "wedding"}},
+		"partial_credit": true,
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Type != types.OrderingChallenge || len(request.Items) != 3 || !request.PartialCredit {
+		t.Error("Expected an ORDERING challenge with 3 items and partial credit, got", request)
+	}
+}
+
+func TestValidateCreateChallengeRequestOrderingWithOneItem(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "ORDERING",
+		"items":       []map[string]interface{}{{"value": "wedding"}},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) || err.Error() != "at least two items are required" {
+		t.Error("Expected items required error, got", err)
+	}
+}
+
+func TestValidateCreateChallengeRequestCheckIn(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "CHECK_IN",
+		"location":    map[string]interface{}{"latitude": 52.3791, "longitude": 4.9003, "radius_meters": 100},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Type != types.CheckInChallenge || request.Location == nil || request.Location.Radius != 100 {
+		t.Error("Expected a CHECK_IN challenge with a radius of 100 meters, got", request)
+	}
+}
+
+func TestValidateCreateChallengeRequestCheckInWithoutLocation(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "CHECK_IN",
+		"location":    map[string]interface{}{"latitude": 52.3791, "longitude": 4.9003},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) || err.Error() != "location with a radius is required" {
+		t.Error("Expected location required error, got", err)
+	}
+}
+
+func TestValidateCreateChallengeRequestCheckInInvalidLatitude(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        "CHECK_IN",
+		"location":    map[string]interface{}{"latitude": 152.3791, "longitude": 4.9003, "radius_meters": 100},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
 func TestValidateCreateChallengeRequestWithStringPoints(t *testing.T) {
 	requestData := map[string]interface{}{
 		"name":        validCreateChallengeRequestUpload.Name,
@@ -1108,3 +1328,584 @@ func TestValidateDeleteChallengeRequestWithInvalidId(t *testing.T) {
 		t.Error("Expected error message to be", expectedError, "got", err.Error())
 	}
 }
+
+func TestValidateCreateChallengeRequestValidMultipleChoice(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testMultipleChoice",
+		"description": "testMultipleChoiceDescription",
+		"points":      10,
+		"Image":       "https://fake.com/choice.jpg",
+		"type":        "MULTIPLE_CHOICE",
+		"options": []map[string]interface{}{
+			{"value": "Paris", "correct": true},
+			{"value": "London", "correct": false},
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	createChallengeRequest, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if createChallengeRequest.Type != types.MultipleChoiceChallenge {
+		t.Error("Expected type to be MULTIPLE_CHOICE, got", createChallengeRequest.Type)
+	}
+	if len(createChallengeRequest.Options) != 2 {
+		t.Error("Expected 2 options, got", len(createChallengeRequest.Options))
+		return
+	}
+	if createChallengeRequest.Options[0].Value != "Paris" || !createChallengeRequest.Options[0].Correct {
+		t.Error("Expected first option to be Paris and correct, got", createChallengeRequest.Options[0])
+	}
+}
+
+func TestValidateCreateChallengeRequestMultipleChoiceWithoutOptions(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testMultipleChoice",
+		"description": "testMultipleChoiceDescription",
+		"points":      10,
+		"Image":       "https://fake.com/choice.jpg",
+		"type":        "MULTIPLE_CHOICE",
+		"options": []map[string]interface{}{
+			{"value": "Paris", "correct": true},
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+
+	if err.Error() != "at least two options are required for multiple choice challenges" {
+		t.Error("Expected error message to be 'at least two options are required for multiple choice challenges', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestMultipleChoiceWithoutCorrectOption(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testMultipleChoice",
+		"description": "testMultipleChoiceDescription",
+		"points":      10,
+		"Image":       "https://fake.com/choice.jpg",
+		"type":        "MULTIPLE_CHOICE",
+		"options": []map[string]interface{}{
+			{"value": "Paris"},
+			{"value": "London"},
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "at least one option must be marked as correct" {
+		t.Error("Expected error message to be 'at least one option must be marked as correct', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestMultipleChoiceWithEmptyOption(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testMultipleChoice",
+		"description": "testMultipleChoiceDescription",
+		"points":      10,
+		"Image":       "https://fake.com/choice.jpg",
+		"type":        "MULTIPLE_CHOICE",
+		"options": []map[string]interface{}{
+			{"value": "Paris", "correct": true},
+			{"value": ""},
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	expectedError := "Key: 'CreateChallengeRequest.Options[1].Value' Error:Field validation for 'Value' failed on the 'required' tag"
+	if err.Error() != expectedError {
+		t.Error("Expected error message to be", expectedError, "got", err.Error())
+	}
+}
+
+func TestValidateUpdateChallengeRequestMultipleChoiceWithoutCorrectOption(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testMultipleChoice",
+		"description": "testMultipleChoiceDescription",
+		"points":      10,
+		"image":       "https://fake.com/choice.jpg",
+		"status":      "ACTIVE",
+		"type":        "MULTIPLE_CHOICE",
+		"options": []map[string]interface{}{
+			{"value": "Paris"},
+			{"value": "London"},
+		},
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "1"})
+
+	_, _, err := ValidateUpdateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "at least one option must be marked as correct" {
+		t.Error("Expected error message to be 'at least one option must be marked as correct', got", err.Error())
+	}
+}
+
+func TestValidateUpdateChallengeRequestMultipleChoiceKeepsOptions(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testMultipleChoice",
+		"description": "testMultipleChoiceDescription",
+		"points":      10,
+		"image":       "https://fake.com/choice.jpg",
+		"status":      "ACTIVE",
+		"type":        "MULTIPLE_CHOICE",
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "1"})
+
+	_, updateChallengeRequest, err := ValidateUpdateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if len(updateChallengeRequest.Options) != 0 {
+		t.Error("Expected no options, got", updateChallengeRequest.Options)
+	}
+}
+
+func TestValidateCreateChallengeRequestWithAnswerMatching(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answer":      "nyc|new york",
+		"answer_matching": map[string]interface{}{
+			"mode": "REGEX",
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	createChallengeRequest, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if createChallengeRequest.AnswerMatching.Mode != types.RegexAnswerMatch {
+		t.Error("Expected answer matching mode to be REGEX, got", createChallengeRequest.AnswerMatching.Mode)
+	}
+}
+
+func TestValidateCreateChallengeRequestWithInvalidAnswerMatchingMode(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answer":      validCreateChallengeRequestAnswer.Answer,
+		"answer_matching": map[string]interface{}{
+			"mode": "FUZZY",
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	expectedError := "Key: 'CreateChallengeRequest.AnswerMatching.Mode' Error:Field validation for 'Mode' failed on the 'oneof' tag"
+	if err.Error() != expectedError {
+		t.Error("Expected error message to be", expectedError, "got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithInvalidAnswerPattern(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answer":      "new york(",
+		"answer_matching": map[string]interface{}{
+			"mode": "REGEX",
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "answer must be a valid regular expression for regex answer matching" {
+		t.Error("Expected error message to be 'answer must be a valid regular expression for regex answer matching', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithInvalidNumericAnswer(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answer":      "forty two",
+		"answer_matching": map[string]interface{}{
+			"mode":              "NUMERIC",
+			"numeric_tolerance": 1,
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "answer must be a number for numeric answer matching" {
+		t.Error("Expected error message to be 'answer must be a number for numeric answer matching', got", err.Error())
+	}
+}
+
+func TestValidateUpdateChallengeRequestMatchModeWithoutAnswer(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        "testAnswer",
+		"description": "testAnswerDescription",
+		"points":      10,
+		"image":       "https://fake.com/answer.jpg",
+		"status":      "ACTIVE",
+		"type":        "ANSWER_QUESTION",
+		"answer_matching": map[string]interface{}{
+			"mode": "NUMERIC",
+		},
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "1"})
+
+	_, _, err := ValidateUpdateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "answer is required when using regex or numeric answer matching" {
+		t.Error("Expected error message to be 'answer is required when using regex or numeric answer matching', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithAnswersOnly(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answers":     []string{"Mount Everest", "Chomolungma"},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	createChallengeRequest, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if len(createChallengeRequest.Answers) != 2 {
+		t.Error("Expected 2 answers, got", len(createChallengeRequest.Answers))
+	}
+}
+
+func TestValidateCreateChallengeRequestWithEmptyAnswerInAnswers(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answers":     []string{"Mount Everest", ""},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	expectedError := "Key: 'CreateChallengeRequest.Answers[1]' Error:Field validation for 'Answers[1]' failed on the 'required' tag"
+	if err.Error() != expectedError {
+		t.Error("Expected error message to be", expectedError, "got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithInvalidPatternInAnswers(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestAnswer.Name,
+		"description": validCreateChallengeRequestAnswer.Description,
+		"points":      validCreateChallengeRequestAnswer.Points,
+		"Image":       validCreateChallengeRequestAnswer.Image,
+		"type":        validCreateChallengeRequestAnswer.Type,
+		"answer":      "nyc",
+		"answers":     []string{"new york("},
+		"answer_matching": map[string]interface{}{
+			"mode": "REGEX",
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "answer must be a valid regular expression for regex answer matching" {
+		t.Error("Expected error message to be 'answer must be a valid regular expression for regex answer matching', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithAvailabilityWindow(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":            validCreateChallengeRequestUpload.Name,
+		"description":     validCreateChallengeRequestUpload.Description,
+		"points":          validCreateChallengeRequestUpload.Points,
+		"Image":           validCreateChallengeRequestUpload.Image,
+		"type":            validCreateChallengeRequestUpload.Type,
+		"available_from":  "2025-06-21T18:00",
+		"available_until": "2025-06-21T20:00",
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	createChallengeRequest, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if createChallengeRequest.AvailableFrom != "2025-06-21T18:00" {
+		t.Error("Expected available_from to be 2025-06-21T18:00, got", createChallengeRequest.AvailableFrom)
+	}
+}
+
+func TestValidateCreateChallengeRequestWithInvalidAvailableUntil(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":            validCreateChallengeRequestUpload.Name,
+		"description":     validCreateChallengeRequestUpload.Description,
+		"points":          validCreateChallengeRequestUpload.Points,
+		"Image":           validCreateChallengeRequestUpload.Image,
+		"type":            validCreateChallengeRequestUpload.Type,
+		"available_until": "after the first dance",
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "available_until must be a valid timestamp" {
+		t.Error("Expected error message to be 'available_until must be a valid timestamp', got", err.Error())
+	}
+}
+
+func TestValidateUpdateChallengeRequestWithReversedAvailabilityWindow(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":            "testUpload",
+		"description":     "testUploadDescription",
+		"points":          10,
+		"image":           "https://fake.com/upload.jpg",
+		"status":          "ACTIVE",
+		"type":            "UPLOAD_PHOTO",
+		"available_from":  "2025-06-21T20:00",
+		"available_until": "2025-06-21T18:00",
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "1"})
+
+	_, _, err := ValidateUpdateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "available_from must be before available_until" {
+		t.Error("Expected error message to be 'available_from must be before available_until', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithHints(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        validCreateChallengeRequestUpload.Type,
+		"hints": []map[string]interface{}{
+			{"text": "look up", "cost": 2},
+			{"text": "look left", "cost": 3},
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	createChallengeRequest, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if len(createChallengeRequest.Hints) != 2 {
+		t.Error("Expected 2 hints, got", len(createChallengeRequest.Hints))
+	}
+}
+
+func TestValidateCreateChallengeRequestWithHintsCostingTooMuch(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        validCreateChallengeRequestUpload.Type,
+		"hints": []map[string]interface{}{
+			{"text": "look up", "cost": 6},
+			{"text": "look left", "cost": 6},
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "total hint cost cannot exceed the points of the challenge" {
+		t.Error("Expected error message to be 'total hint cost cannot exceed the points of the challenge', got", err.Error())
+	}
+}
+
+func TestValidateCreateChallengeRequestWithEmptyHint(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        validCreateChallengeRequestUpload.Type,
+		"hints":       []map[string]interface{}{{"cost": 1}},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateCreateChallengeRequestWithScoringRules(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        validCreateChallengeRequestUpload.Type,
+		"scoring": map[string]interface{}{
+			"first_solver_bonus":     5,
+			"first_solver_count":     3,
+			"decay":                  "STEP",
+			"decay_points":           1,
+			"decay_interval_minutes": 30,
+		},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	createChallengeRequest, err := ValidateCreateChallengeRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if createChallengeRequest.Scoring.Decay != types.StepPointsDecay || createChallengeRequest.Scoring.FirstSolverCount != 3 {
+		t.Error("Expected step decay with 3 first solvers, got", createChallengeRequest.Scoring)
+	}
+}
+
+func TestValidateCreateChallengeRequestWithInvalidScoringRules(t *testing.T) {
+	tests := []struct {
+		scoring  map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"first_solver_bonus": 5}, "first_solver_bonus and first_solver_count must be set together"},
+		{map[string]interface{}{"decay": "LINEAR", "decay_points": 1}, "decay_points and decay_interval_minutes are required when points decay"},
+		{map[string]interface{}{"minimum_points": 1000}, "minimum_points cannot exceed the points of the challenge"},
+	}
+
+	for _, test := range tests {
+		requestData := map[string]interface{}{
+			"name":        validCreateChallengeRequestUpload.Name,
+			"description": validCreateChallengeRequestUpload.Description,
+			"points":      validCreateChallengeRequestUpload.Points,
+			"Image":       validCreateChallengeRequestUpload.Image,
+			"type":        validCreateChallengeRequestUpload.Type,
+			"scoring":     test.scoring,
+		}
+		c := generateRequestWithBodyOnly(requestData)
+
+		_, err := ValidateCreateChallengeRequest(c)
+		if err == nil {
+			t.Error("Expected error, got nil")
+			continue
+		}
+
+		if err.Error() != test.expected {
+			t.Errorf("Expected error message to be '%s', got %s", test.expected, err.Error())
+		}
+	}
+}
+
+func TestValidateCreateChallengeRequestWithUnknownDecay(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":        validCreateChallengeRequestUpload.Name,
+		"description": validCreateChallengeRequestUpload.Description,
+		"points":      validCreateChallengeRequestUpload.Points,
+		"Image":       validCreateChallengeRequestUpload.Image,
+		"type":        validCreateChallengeRequestUpload.Type,
+		"scoring":     map[string]interface{}{"decay": "EXPONENTIAL"},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateChallengeRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
@@ -0,0 +1,47 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+)
+
+func ValidateImportGuestsRequest(c *gin.Context) (types.ImportGuestsRequest, error) {
+	var importGuestsRequest types.ImportGuestsRequest
+	if err := c.BindJSON(&importGuestsRequest); err != nil {
+		return types.ImportGuestsRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&importGuestsRequest); err != nil {
+		return types.ImportGuestsRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	seen := make(map[string]bool, len(importGuestsRequest.Guests))
+	for _, guest := range importGuestsRequest.Guests {
+		inviteCode := utils.NormalizeCode(guest.InviteCode)
+		if inviteCode == "" {
+			continue
+		}
+		if seen[inviteCode] {
+			return types.ImportGuestsRequest{}, apperrors.NewValidationError(constants.InviteCodeTakenError)
+		}
+		seen[inviteCode] = true
+	}
+
+	return importGuestsRequest, nil
+}
+
+func ValidateRegistrationSettingsRequest(c *gin.Context) (types.RegistrationSettings, error) {
+	var registrationSettings types.RegistrationSettings
+	if err := c.BindJSON(&registrationSettings); err != nil {
+		return types.RegistrationSettings{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&registrationSettings); err != nil {
+		return types.RegistrationSettings{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return registrationSettings, nil
+}
@@ -0,0 +1,113 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateImportGuestsRequest(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"guests": []map[string]interface{}{
+		{"name": "Aunt May", "invite_code": "may123"},
+		{"name": "Uncle Ben"},
+	}})
+
+	importGuestsRequest, err := ValidateImportGuestsRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if len(importGuestsRequest.Guests) != 2 || importGuestsRequest.Guests[0].InviteCode != "may123" {
+		t.Error("Expected two guests with invite code may123 for the first, got", importGuestsRequest)
+	}
+}
+
+func TestValidateImportGuestsRequestWithoutGuests(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"guests": []map[string]interface{}{}})
+
+	_, err := ValidateImportGuestsRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateImportGuestsRequestWithoutName(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"guests": []map[string]interface{}{{"invite_code": "may123"}}})
+
+	_, err := ValidateImportGuestsRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateImportGuestsRequestDuplicateInviteCode(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"guests": []map[string]interface{}{
+		{"name": "Aunt May", "invite_code": "may123"},
+		{"name": "Uncle Ben", "invite_code": "MAY123"},
+	}})
+
+	_, err := ValidateImportGuestsRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invite code is already taken" {
+		t.Error("Expected error message to be 'invite code is already taken', got", err.Error())
+	}
+}
+
+func TestValidateImportGuestsRequestDuplicateInviteCodeWithSpaces(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"guests": []map[string]interface{}{
+		{"name": "Aunt May", "invite_code": "may123"},
+		{"name": "Uncle Ben", "invite_code": " MAY123 "},
+	}})
+
+	_, err := ValidateImportGuestsRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateRegistrationSettingsRequestMissingValue(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{})
+
+	_, err := ValidateRegistrationSettingsRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateRegistrationSettingsRequestFalse(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"open_registration": false})
+
+	registrationSettings, err := ValidateRegistrationSettingsRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if registrationSettings.OpenRegistration == nil || *registrationSettings.OpenRegistration {
+		t.Error("Expected closed registration, got", registrationSettings)
+	}
+}
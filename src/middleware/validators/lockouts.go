@@ -0,0 +1,16 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func ValidateClearLockoutRequest(c *gin.Context) (string, error) {
+	key := c.Query("key")
+	if key == "" {
+		return "", apperrors.NewValidationError(constants.LockoutKeyRequiredError)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,34 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateClearLockoutRequest(t *testing.T) {
+	c := generateRequestWithQuery("/admin/lockouts?key=username%3Aadmin")
+
+	key, err := ValidateClearLockoutRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if key != "username:admin" {
+		t.Error("Expected key username:admin, got", key)
+	}
+}
+
+func TestValidateClearLockoutRequestMissingKey(t *testing.T) {
+	c := generateRequestWithQuery("/admin/lockouts")
+
+	_, err := ValidateClearLockoutRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) || err.Error() != "lockout key is required" {
+		t.Error("Expected validation error 'lockout key is required', got", err)
+	}
+}
@@ -0,0 +1,36 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateGetPointsLedgerRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidUserIDError)
+	}
+
+	return uint(id), nil
+}
+
+func ValidateCreatePointsAdjustmentRequest(c *gin.Context) (uint, types.CreatePointsAdjustmentRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.CreatePointsAdjustmentRequest{}, apperrors.NewValidationError(constants.InvalidUserIDError)
+	}
+
+	var createPointsAdjustmentRequest types.CreatePointsAdjustmentRequest
+	if err := c.BindJSON(&createPointsAdjustmentRequest); err != nil {
+		return 0, types.CreatePointsAdjustmentRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&createPointsAdjustmentRequest); err != nil {
+		return 0, types.CreatePointsAdjustmentRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), createPointsAdjustmentRequest, nil
+}
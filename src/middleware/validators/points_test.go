@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func TestValidateCreatePointsAdjustmentRequest(t *testing.T) {
+	requestData := map[string]interface{}{
+		"type":   "BONUS",
+		"points": 50,
+		"reason": "best dancer",
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "2"})
+
+	userId, request, err := ValidateCreatePointsAdjustmentRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if userId != 2 || request.Type != types.BonusPointsEntry || request.Points != 50 || request.Reason != "best dancer" {
+		t.Error("Expected a bonus of 50 points, got", request)
+	}
+}
+
+func TestValidateCreatePointsAdjustmentRequestWithInvalidType(t *testing.T) {
+	requestData := map[string]interface{}{
+		"type":   "REVERSAL",
+		"points": 50,
+		"reason": "best dancer",
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "2"})
+
+	_, _, err := ValidateCreatePointsAdjustmentRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateCreatePointsAdjustmentRequestWithoutReason(t *testing.T) {
+	requestData := map[string]interface{}{
+		"type":   "PENALTY",
+		"points": 10,
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "2"})
+
+	_, _, err := ValidateCreatePointsAdjustmentRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateCreatePointsAdjustmentRequestWithInvalidUserId(t *testing.T) {
+	requestData := map[string]interface{}{
+		"type":   "BONUS",
+		"points": 50,
+		"reason": "best dancer",
+	}
+	c := generateRequestWithBodyAndParams(requestData, map[string]string{"id": "abc"})
+
+	_, _, err := ValidateCreatePointsAdjustmentRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) || err.Error() != "invalid user id" {
+		t.Error("Expected invalid user id error, got", err)
+	}
+}
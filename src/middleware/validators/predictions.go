@@ -0,0 +1,27 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateResolvePredictionRequest(c *gin.Context) (uint, types.ResolvePredictionRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.ResolvePredictionRequest{}, apperrors.NewValidationError(constants.InvalidChallengeIDError)
+	}
+
+	var resolvePredictionRequest types.ResolvePredictionRequest
+	if err := c.BindJSON(&resolvePredictionRequest); err != nil {
+		return 0, types.ResolvePredictionRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&resolvePredictionRequest); err != nil {
+		return 0, types.ResolvePredictionRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), resolvePredictionRequest, nil
+}
@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateResolvePredictionRequest(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"outcome": "12.5"}, map[string]string{"id": "3"})
+
+	id, request, err := ValidateResolvePredictionRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if id != 3 || request.Outcome != "12.5" {
+		t.Error("Expected challenge 3 with outcome 12.5, got", id, request)
+	}
+}
+
+func TestValidateResolvePredictionRequestWithoutOutcome(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{}, map[string]string{"id": "3"})
+
+	_, _, err := ValidateResolvePredictionRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateResolvePredictionRequestInvalidId(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"outcome": "12.5"}, map[string]string{"id": "speech"})
+
+	_, _, err := ValidateResolvePredictionRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid challenge id" {
+		t.Error("Expected error message to be 'invalid challenge id', got", err.Error())
+	}
+}
@@ -0,0 +1,52 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateCreateQuizRoundRequest(c *gin.Context) (types.CreateQuizRoundRequest, error) {
+	var createQuizRoundRequest types.CreateQuizRoundRequest
+	if err := c.BindJSON(&createQuizRoundRequest); err != nil {
+		return types.CreateQuizRoundRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&createQuizRoundRequest); err != nil {
+		return types.CreateQuizRoundRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	seen := make(map[uint]bool, len(createQuizRoundRequest.Challenges))
+	for _, challengeId := range createQuizRoundRequest.Challenges {
+		if seen[challengeId] {
+			return types.CreateQuizRoundRequest{}, apperrors.NewValidationError(constants.InvalidQuizRoundError)
+		}
+		seen[challengeId] = true
+	}
+
+	return createQuizRoundRequest, nil
+}
+
+func ValidateQuizRoundIdRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidQuizRoundIDError)
+	}
+
+	return uint(id), nil
+}
+
+func ValidateAnswerQuizQuestionRequest(c *gin.Context) (types.AnswerQuizQuestionRequest, error) {
+	var answerQuizQuestionRequest types.AnswerQuizQuestionRequest
+	if err := c.BindJSON(&answerQuizQuestionRequest); err != nil {
+		return types.AnswerQuizQuestionRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&answerQuizQuestionRequest); err != nil {
+		return types.AnswerQuizQuestionRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return answerQuizQuestionRequest, nil
+}
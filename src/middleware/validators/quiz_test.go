@@ -0,0 +1,80 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateCreateQuizRoundRequest(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":             "Round 1",
+		"question_seconds": 30,
+		"challenges":       []uint{3, 1, 2},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	request, err := ValidateCreateQuizRoundRequest(c)
+	if err != nil {
+		t.Error("Expected nil, got", err)
+		return
+	}
+
+	if request.Name != "Round 1" || request.QuestionSeconds != 30 || len(request.Challenges) != 3 || request.Challenges[0] != 3 {
+		t.Error("Expected a round of 3 questions with 30 seconds each, got", request)
+	}
+}
+
+func TestValidateCreateQuizRoundRequestWithTooShortQuestions(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":             "Round 1",
+		"question_seconds": 2,
+		"challenges":       []uint{1},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateQuizRoundRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateCreateQuizRoundRequestWithDuplicateChallenge(t *testing.T) {
+	requestData := map[string]interface{}{
+		"name":             "Round 1",
+		"question_seconds": 30,
+		"challenges":       []uint{1, 2, 1},
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateCreateQuizRoundRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) || err.Error() != "challenges must exist, be different and not be part of another quiz round" {
+		t.Error("Expected invalid quiz round error, got", err)
+	}
+}
+
+func TestValidateAnswerQuizQuestionRequestWithoutChallenge(t *testing.T) {
+	requestData := map[string]interface{}{
+		"answer": "answer",
+	}
+	c := generateRequestWithBodyOnly(requestData)
+
+	_, err := ValidateAnswerQuizQuestionRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
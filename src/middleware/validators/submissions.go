@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"strings"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+// ValidateGetModerationQueueRequest returns the requested state, the queue shows pending submissions by default
+func ValidateGetModerationQueueRequest(c *gin.Context) (types.SubmissionState, error) {
+	state := types.SubmissionState(strings.ToUpper(c.DefaultQuery("state", string(types.PendingSubmission))))
+	switch state {
+	case types.PendingSubmission, types.ApprovedSubmission, types.RejectedSubmission:
+		return state, nil
+	default:
+		return "", apperrors.NewValidationError(constants.InvalidSubmissionStateError)
+	}
+}
+
+func ValidateApproveSubmissionRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidSubmissionIDError)
+	}
+
+	return uint(id), nil
+}
+
+func ValidateRejectSubmissionRequest(c *gin.Context) (uint, types.RejectSubmissionRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.RejectSubmissionRequest{}, apperrors.NewValidationError(constants.InvalidSubmissionIDError)
+	}
+
+	var rejectSubmissionRequest types.RejectSubmissionRequest
+	if err := c.BindJSON(&rejectSubmissionRequest); err != nil {
+		return 0, types.RejectSubmissionRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&rejectSubmissionRequest); err != nil {
+		return 0, types.RejectSubmissionRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), rejectSubmissionRequest, nil
+}
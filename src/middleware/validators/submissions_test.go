@@ -0,0 +1,102 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func generateRequestWithQuery(path string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Request = httptest.NewRequest("GET", path, nil)
+	return c
+}
+
+func TestValidateGetModerationQueueRequestDefaultsToPending(t *testing.T) {
+	c := generateRequestWithQuery("/admin/submissions")
+
+	state, err := ValidateGetModerationQueueRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if state != types.PendingSubmission {
+		t.Error("Expected state PENDING, got", state)
+	}
+}
+
+func TestValidateGetModerationQueueRequestLowerCaseState(t *testing.T) {
+	c := generateRequestWithQuery("/admin/submissions?state=rejected")
+
+	state, err := ValidateGetModerationQueueRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if state != types.RejectedSubmission {
+		t.Error("Expected state REJECTED, got", state)
+	}
+}
+
+func TestValidateGetModerationQueueRequestInvalidState(t *testing.T) {
+	c := generateRequestWithQuery("/admin/submissions?state=deleted")
+
+	_, err := ValidateGetModerationQueueRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "state must be one of pending, approved or rejected" {
+		t.Error("Expected error message to be 'state must be one of pending, approved or rejected', got", err.Error())
+	}
+}
+
+func TestValidateApproveSubmissionRequestInvalidId(t *testing.T) {
+	c := generateRequestWithParamsOnly(map[string]string{"id": "abc"})
+
+	_, err := ValidateApproveSubmissionRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid submission id" {
+		t.Error("Expected error message to be 'invalid submission id', got", err.Error())
+	}
+}
+
+func TestValidateRejectSubmissionRequest(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"reason": "blurry"}, map[string]string{"id": "12"})
+
+	id, request, err := ValidateRejectSubmissionRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if id != 12 || request.Reason != "blurry" {
+		t.Error("Expected submission 12 rejected as blurry, got", id, request)
+	}
+}
+
+func TestValidateRejectSubmissionRequestMissingReason(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{}, map[string]string{"id": "12"})
+
+	_, _, err := ValidateRejectSubmissionRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateCreateTeamRequest(c *gin.Context) (types.CreateTeamRequest, error) {
+	var createTeamRequest types.CreateTeamRequest
+	if err := c.BindJSON(&createTeamRequest); err != nil {
+		return types.CreateTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&createTeamRequest); err != nil {
+		return types.CreateTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return createTeamRequest, nil
+}
+
+func ValidateUpdateTeamRequest(c *gin.Context) (uint, types.UpdateTeamRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.UpdateTeamRequest{}, apperrors.NewValidationError(constants.InvalidTeamIDError)
+	}
+
+	var updateTeamRequest types.UpdateTeamRequest
+	if err := c.BindJSON(&updateTeamRequest); err != nil {
+		return 0, types.UpdateTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&updateTeamRequest); err != nil {
+		return 0, types.UpdateTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), updateTeamRequest, nil
+}
+
+func ValidateDeleteTeamRequest(c *gin.Context) (uint, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidTeamIDError)
+	}
+
+	return uint(id), nil
+}
+
+func ValidateJoinTeamRequest(c *gin.Context) (types.JoinTeamRequest, error) {
+	var joinTeamRequest types.JoinTeamRequest
+	if err := c.BindJSON(&joinTeamRequest); err != nil {
+		return types.JoinTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&joinTeamRequest); err != nil {
+		return types.JoinTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return joinTeamRequest, nil
+}
+
+func ValidateAssignTeamRequest(c *gin.Context) (uint, types.AssignTeamRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.AssignTeamRequest{}, apperrors.NewValidationError(constants.InvalidUserIDError)
+	}
+
+	var assignTeamRequest types.AssignTeamRequest
+	if err := c.BindJSON(&assignTeamRequest); err != nil {
+		return 0, types.AssignTeamRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), assignTeamRequest, nil
+}
@@ -0,0 +1,90 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func TestValidateCreateTeamRequest(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"name": "Table 1", "join_code": "table1"})
+
+	createTeamRequest, err := ValidateCreateTeamRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if createTeamRequest.Name != "Table 1" || createTeamRequest.JoinCode != "table1" {
+		t.Error("Expected team Table 1 with join code table1, got", createTeamRequest)
+	}
+}
+
+func TestValidateCreateTeamRequestInvalidJoinCode(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{"name": "Table 1", "join_code": "t 1"})
+
+	_, err := ValidateCreateTeamRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateUpdateTeamRequestInvalidId(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"name": "Table 1"}, map[string]string{"id": "abc"})
+
+	_, _, err := ValidateUpdateTeamRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid team id" {
+		t.Error("Expected error message to be 'invalid team id', got", err.Error())
+	}
+}
+
+func TestValidateJoinTeamRequestMissingJoinCode(t *testing.T) {
+	c := generateRequestWithBodyOnly(map[string]interface{}{})
+
+	_, err := ValidateJoinTeamRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateAssignTeamRequest(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"team_id": nil}, map[string]string{"id": "3"})
+
+	userId, assignTeamRequest, err := ValidateAssignTeamRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if userId != 3 || assignTeamRequest.TeamId != nil {
+		t.Error("Expected user 3 without team, got", userId, assignTeamRequest.TeamId)
+	}
+}
+
+func TestValidateAssignTeamRequestInvalidId(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"team_id": 1}, map[string]string{"id": "abc"})
+
+	_, _, err := ValidateAssignTeamRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid user id" {
+		t.Error("Expected error message to be 'invalid user id', got", err.Error())
+	}
+}
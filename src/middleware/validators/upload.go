@@ -5,9 +5,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"mime/multipart"
 	"path/filepath"
+	"slices"
+	"strings"
 	"the-wedding-game-api/config"
 	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/utils"
+	"time"
 )
 
 func ValidateUploadImageRequest(c *gin.Context) (*multipart.FileHeader, error) {
@@ -51,3 +55,87 @@ func isAllowedExtension(file *multipart.FileHeader) bool {
 
 	return false
 }
+
+// ValidateUploadVideoRequest returns the video and its duration, read from the container header
+func ValidateUploadVideoRequest(c *gin.Context) (*multipart.FileHeader, time.Duration, error) {
+	file, err := c.FormFile("video")
+	if err != nil {
+		if err.Error() == "missing form body" || err.Error() == "http: no such file" {
+			return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.VideoIsRequiredError)
+		}
+
+		return &multipart.FileHeader{}, 0, fmt.Errorf("error getting file from form: %v", err)
+	}
+
+	extension := strings.ToLower(filepath.Ext(file.Filename))
+	if !slices.Contains([]string{".mp4", ".mov", ".webm"}, extension) {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.FileMustBeAVideoError)
+	}
+
+	if file.Size == 0 {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.FileIsEmptyError)
+	}
+
+	maxSize := config.GetMaxVideoUploadSize()
+	if file.Size > maxSize {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(fmt.Sprintf(constants.MaxVideoFileSizeError, maxSize))
+	}
+
+	video, err := file.Open()
+	if err != nil {
+		return &multipart.FileHeader{}, 0, fmt.Errorf("error opening video: %v", err)
+	}
+	defer func() { _ = video.Close() }()
+
+	duration, err := utils.GetVideoDuration(video, extension)
+	if err != nil {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.InvalidVideoError)
+	}
+
+	return file, duration, nil
+}
+
+// ValidateUploadAudioRequest returns the audio message and its duration. The format is sniffed from the content
+// and must match the extension, so a renamed file of another type is rejected.
+func ValidateUploadAudioRequest(c *gin.Context) (*multipart.FileHeader, time.Duration, error) {
+	file, err := c.FormFile("audio")
+	if err != nil {
+		if err.Error() == "missing form body" || err.Error() == "http: no such file" {
+			return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.AudioIsRequiredError)
+		}
+
+		return &multipart.FileHeader{}, 0, fmt.Errorf("error getting file from form: %v", err)
+	}
+
+	extension := strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	if !slices.Contains([]string{utils.M4AAudio, utils.MP3Audio, utils.OggAudio, utils.WebMAudio}, extension) {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.FileMustBeAnAudioError)
+	}
+
+	if file.Size == 0 {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.FileIsEmptyError)
+	}
+
+	maxSize := config.GetMaxAudioUploadSize()
+	if file.Size > maxSize {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(fmt.Sprintf(constants.MaxAudioFileSizeError, maxSize))
+	}
+
+	audio, err := file.Open()
+	if err != nil {
+		return &multipart.FileHeader{}, 0, fmt.Errorf("error opening audio: %v", err)
+	}
+	defer func() { _ = audio.Close() }()
+
+	format, err := utils.DetectAudioFormat(audio)
+	if err != nil || format != extension {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.FileMustBeAnAudioError)
+	}
+
+	duration, err := utils.GetAudioDuration(audio, format)
+	if err != nil {
+		return &multipart.FileHeader{}, 0, apperrors.NewValidationError(constants.InvalidAudioError)
+	}
+
+	return file, duration, nil
+}
@@ -285,3 +285,167 @@ func TestValidateUploadImageRequestWithLargeFile(t *testing.T) {
 		t.Errorf("Expected error to be %v, got %v", expectedError, err)
 	}
 }
+
+func generateUploadVideoRequest(fileName string, content []byte) *gin.Context {
+	return generateUploadRequest("video", fileName, content)
+}
+
+func generateUploadRequest(fileKey string, fileName string, content []byte) *gin.Context {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(fileKey, fileName)
+	if err != nil {
+		panic(err)
+	}
+	if _, err = part.Write(content); err != nil {
+		panic(err)
+	}
+	if err = writer.Close(); err != nil {
+		panic(err)
+	}
+
+	req, err := http.NewRequest("POST", "/upload/"+fileKey, body)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(nil)
+	c.Request = req
+	return c
+}
+
+func TestValidateUploadVideoRequest(t *testing.T) {
+	content, err := os.ReadFile("../../_tests/assets/test_upload_video.mp4")
+	if err != nil {
+		panic(err)
+	}
+
+	fileHeader, duration, err := ValidateUploadVideoRequest(generateUploadVideoRequest("test_upload_video.MP4", content))
+	if err != nil {
+		t.Errorf("Expected error to be nil, got %v", err)
+		return
+	}
+
+	if fileHeader.Filename != "test_upload_video.MP4" {
+		t.Errorf("Expected filename to be test_upload_video.MP4, got %v", fileHeader.Filename)
+	}
+	if duration.Seconds() != 10 {
+		t.Errorf("Expected duration to be 10 seconds, got %v", duration)
+	}
+}
+
+func TestValidateUploadVideoRequestWithoutFile(t *testing.T) {
+	req, err := http.NewRequest("POST", "/upload/video", nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Content-Type", "multipart/form-data")
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(nil)
+	c.Request = req
+
+	_, _, err = ValidateUploadVideoRequest(c)
+	if err == nil || err.Error() != "video is required" {
+		t.Errorf("Expected error to be video is required, got %v", err)
+	}
+}
+
+func TestValidateUploadVideoRequestWithWrongExtension(t *testing.T) {
+	_, _, err := ValidateUploadVideoRequest(generateUploadVideoRequest("clip.avi", []byte("video")))
+	if err == nil || err.Error() != "file must be an mp4, mov or webm video" {
+		t.Errorf("Expected error to be file must be an mp4, mov or webm video, got %v", err)
+	}
+}
+
+func TestValidateUploadVideoRequestWithEmptyFile(t *testing.T) {
+	_, _, err := ValidateUploadVideoRequest(generateUploadVideoRequest("clip.mp4", []byte{}))
+	if err == nil || err.Error() != "file is empty" {
+		t.Errorf("Expected error to be file is empty, got %v", err)
+	}
+}
+
+func TestValidateUploadVideoRequestWithInvalidVideo(t *testing.T) {
+	_, _, err := ValidateUploadVideoRequest(generateUploadVideoRequest("clip.webm", []byte("not a video")))
+	if err == nil || err.Error() != "could not read the duration of the video" {
+		t.Errorf("Expected error to be could not read the duration of the video, got %v", err)
+	}
+}
+
+func TestValidateUploadAudioRequest(t *testing.T) {
+	content, err := os.ReadFile("../../_tests/assets/test_upload_audio.m4a")
+	if err != nil {
+		panic(err)
+	}
+
+	fileHeader, duration, err := ValidateUploadAudioRequest(generateUploadRequest("audio", "test_upload_audio.m4a", content))
+	if err != nil {
+		t.Errorf("Expected error to be nil, got %v", err)
+		return
+	}
+
+	if fileHeader.Filename != "test_upload_audio.m4a" {
+		t.Errorf("Expected filename to be test_upload_audio.m4a, got %v", fileHeader.Filename)
+	}
+	if duration.Seconds() != 5 {
+		t.Errorf("Expected duration to be 5 seconds, got %v", duration)
+	}
+}
+
+func TestValidateUploadAudioRequestWithoutFile(t *testing.T) {
+	req, err := http.NewRequest("POST", "/upload/audio", nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Content-Type", "multipart/form-data")
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(nil)
+	c.Request = req
+
+	_, _, err = ValidateUploadAudioRequest(c)
+	if err == nil || err.Error() != "audio is required" {
+		t.Errorf("Expected error to be audio is required, got %v", err)
+	}
+}
+
+func TestValidateUploadAudioRequestWithWrongExtension(t *testing.T) {
+	_, _, err := ValidateUploadAudioRequest(generateUploadRequest("audio", "message.wav", []byte("RIFF")))
+	if err == nil || err.Error() != "file must be an m4a, mp3, ogg or webm audio message" {
+		t.Errorf("Expected error to be file must be an m4a, mp3, ogg or webm audio message, got %v", err)
+	}
+}
+
+func TestValidateUploadAudioRequestWithRenamedFile(t *testing.T) {
+	content, err := os.ReadFile("../../_tests/assets/test_upload_image.jpg")
+	if err != nil {
+		panic(err)
+	}
+
+	_, _, err = ValidateUploadAudioRequest(generateUploadRequest("audio", "message.mp3", content))
+	if err == nil || err.Error() != "file must be an m4a, mp3, ogg or webm audio message" {
+		t.Errorf("Expected error to be file must be an m4a, mp3, ogg or webm audio message, got %v", err)
+	}
+}
+
+func TestValidateUploadAudioRequestWithMismatchedFormat(t *testing.T) {
+	content, err := os.ReadFile("../../_tests/assets/test_upload_audio.m4a")
+	if err != nil {
+		panic(err)
+	}
+
+	_, _, err = ValidateUploadAudioRequest(generateUploadRequest("audio", "message.ogg", content))
+	if err == nil || err.Error() != "file must be an m4a, mp3, ogg or webm audio message" {
+		t.Errorf("Expected error to be file must be an m4a, mp3, ogg or webm audio message, got %v", err)
+	}
+}
+
+func TestValidateUploadAudioRequestWithInvalidAudio(t *testing.T) {
+	_, _, err := ValidateUploadAudioRequest(generateUploadRequest("audio", "message.ogg", []byte("OggS broken")))
+	if err == nil || err.Error() != "could not read the duration of the audio message" {
+		t.Errorf("Expected error to be could not read the duration of the audio message, got %v", err)
+	}
+}
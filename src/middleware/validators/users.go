@@ -0,0 +1,27 @@
+package validators
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func ValidateUpdateUserRoleRequest(c *gin.Context) (uint, types.UpdateUserRoleRequest, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, types.UpdateUserRoleRequest{}, apperrors.NewValidationError(constants.InvalidUserIDError)
+	}
+
+	var updateUserRoleRequest types.UpdateUserRoleRequest
+	if err := c.BindJSON(&updateUserRoleRequest); err != nil {
+		return 0, types.UpdateUserRoleRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	if err := validate.Struct(&updateUserRoleRequest); err != nil {
+		return 0, types.UpdateUserRoleRequest{}, apperrors.NewValidationError(err.Error())
+	}
+
+	return uint(id), updateUserRoleRequest, nil
+}
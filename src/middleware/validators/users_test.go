@@ -0,0 +1,50 @@
+package validators
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func TestValidateUpdateUserRoleRequest(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"role": "MODERATOR", "password": "bridesmaid password"},
+		map[string]string{"id": "3"})
+
+	userId, updateUserRoleRequest, err := ValidateUpdateUserRoleRequest(c)
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if userId != 3 || updateUserRoleRequest.Role != types.Moderator || updateUserRoleRequest.Password != "bridesmaid password" {
+		t.Error("Expected user 3 to become a moderator, got", userId, updateUserRoleRequest)
+	}
+}
+
+func TestValidateUpdateUserRoleRequestUnknownRole(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"role": "BRIDE"}, map[string]string{"id": "3"})
+
+	_, _, err := ValidateUpdateUserRoleRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Error("Expected validation error, got", err)
+	}
+}
+
+func TestValidateUpdateUserRoleRequestInvalidId(t *testing.T) {
+	c := generateRequestWithBodyAndParams(map[string]interface{}{"role": "PLAYER"}, map[string]string{"id": "abc"})
+
+	_, _, err := ValidateUpdateUserRoleRequest(c)
+	if err == nil {
+		t.Error("Expected error, got nil")
+		return
+	}
+
+	if err.Error() != "invalid user id" {
+		t.Error("Expected error message to be 'invalid user id', got", err.Error())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
 )
 
 func migrate() {
@@ -26,9 +27,54 @@ func migrate() {
 		log.Fatal("Could not connect database")
 	}
 
+	_ = db.AutoMigrate(&models.Team{})
 	_ = db.AutoMigrate(&models.User{})
 	_ = db.AutoMigrate(&models.Challenge{})
 	_ = db.AutoMigrate(&models.AccessToken{})
 	_ = db.AutoMigrate(&models.Answer{})
+	// Submissions created before points were persisted are awarded the points of the challenge minus revealed hints
+	backfillSubmissionPoints := db.Migrator().HasTable(&models.Submission{}) && !db.Migrator().HasColumn(&models.Submission{}, "Points")
 	_ = db.AutoMigrate(&models.Submission{})
+	_ = db.AutoMigrate(&models.ChallengeOption{})
+	_ = db.AutoMigrate(&models.ChallengeItem{})
+	_ = db.AutoMigrate(&models.ChallengePrerequisite{})
+	_ = db.AutoMigrate(&models.ChallengeHint{})
+	_ = db.AutoMigrate(&models.HintReveal{})
+	_ = db.AutoMigrate(&models.Attempt{})
+	_ = db.AutoMigrate(&models.Upload{})
+	_ = db.AutoMigrate(&models.BingoBoard{})
+	_ = db.AutoMigrate(&models.BingoCell{})
+	_ = db.AutoMigrate(&models.QuizRound{})
+	_ = db.AutoMigrate(&models.QuizQuestion{})
+	// Submissions from before the points ledger get an entry for the points they count for
+	backfillPointsLedger := !db.Migrator().HasTable(&models.PointsEntry{})
+	_ = db.AutoMigrate(&models.PointsEntry{})
+	_ = db.AutoMigrate(&models.Guest{})
+	_ = db.AutoMigrate(&models.Setting{})
+	_ = db.AutoMigrate(&models.LoginLockout{})
+
+	if backfillSubmissionPoints {
+		log.Println("Backfilling points of existing submissions")
+		db.Exec(`
+			UPDATE submissions
+			SET points = GREATEST(challenges.points - COALESCE((
+				SELECT SUM(challenge_hints.cost)
+				FROM hint_reveals
+				INNER JOIN challenge_hints ON hint_reveals.hint_id = challenge_hints.id
+				WHERE hint_reveals.user_id = submissions.user_id AND hint_reveals.challenge_id = submissions.challenge_id
+			), 0), 0)
+			FROM challenges
+			WHERE submissions.challenge_id = challenges.id
+		`)
+	}
+
+	if backfillPointsLedger {
+		log.Println("Backfilling the points ledger with existing submissions")
+		db.Exec(`
+			INSERT INTO points_entries (created_at, updated_at, user_id, type, points, reason, challenge_id, submission_id)
+			SELECT submissions.created_at, NOW(), submissions.user_id, ?, submissions.points, ?, submissions.challenge_id, submissions.id
+			FROM submissions
+			WHERE submissions.state = ? AND submissions.points > 0 AND submissions.deleted_at IS NULL
+		`, types.SubmissionPointsEntry, models.SubmissionPointsReason, types.ApprovedSubmission)
+	}
 }
@@ -4,27 +4,45 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"strconv"
+	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"time"
 )
 
+// AccessToken authenticates a user until ExpiresOn, the refresh token trades it for a new pair until RefreshExpiresOn.
+// Tokens from before refresh tokens existed have neither.
 type AccessToken struct {
 	gorm.Model
-	Token     string `gorm:"unique"`
-	UserID    uint   `gorm:"not null"`
-	ExpiresOn int64  `gorm:"not null"`
-	User      User
+	Token            string  `gorm:"unique"`
+	RefreshToken     *string `gorm:"unique"`
+	UserID           uint    `gorm:"not null"`
+	ExpiresOn        int64   `gorm:"not null"`
+	RefreshExpiresOn int64   `gorm:"not null;default:0"`
+	User             User
 }
 
+var accessTokenLifetime = 24 * time.Hour
+var refreshTokenLifetime = 30 * 24 * time.Hour
+
 func generateAccessToken() string {
 	return uuid.New().String()
 }
 
+func newAccessToken(userId uint) AccessToken {
+	refreshToken := generateAccessToken()
+	now := time.Now()
+	return AccessToken{
+		Token:            generateAccessToken(),
+		RefreshToken:     &refreshToken,
+		UserID:           userId,
+		ExpiresOn:        now.Add(accessTokenLifetime).Unix(),
+		RefreshExpiresOn: now.Add(refreshTokenLifetime).Unix(),
+	}
+}
+
 func LinkAccessTokenToUser(userId uint) (AccessToken, error) {
 	conn := GetConnection()
-	token := generateAccessToken()
-	expiresOn := time.Now().Add(24 * time.Hour).Unix()
-	accessToken := AccessToken{Token: token, UserID: userId, ExpiresOn: expiresOn}
+	accessToken := newAccessToken(userId)
 	if err := conn.Create(&accessToken).GetError(); err != nil {
 		return AccessToken{}, err
 	}
@@ -41,6 +59,10 @@ func GetUserByAccessToken(token string) (User, error) {
 		return User{}, err
 	}
 
+	if accessToken.ExpiresOn <= time.Now().Unix() {
+		return User{}, apperrors.NewAccessTokenExpiredError()
+	}
+
 	var user User
 	if err := conn.Where("id = ?", accessToken.UserID).First(&user).GetError(); err != nil {
 		if apperrors.IsRecordNotFoundError(err) {
@@ -51,3 +73,36 @@ func GetUserByAccessToken(token string) (User, error) {
 
 	return user, nil
 }
+
+// RefreshAccessToken replaces the tokens the refresh token belongs to with a new pair, so every refresh token works once
+func RefreshAccessToken(refreshToken string) (AccessToken, error) {
+	conn := GetConnection()
+	existing, err := conn.GetAccessTokenByRefreshToken(refreshToken)
+	if err != nil {
+		return AccessToken{}, err
+	}
+	if existing == nil || existing.RefreshExpiresOn <= time.Now().Unix() {
+		return AccessToken{}, apperrors.NewAuthenticationError(constants.InvalidRefreshTokenError)
+	}
+
+	accessToken, err := conn.ReplaceAccessToken(existing.ID, newAccessToken(existing.UserID))
+	if err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return AccessToken{}, apperrors.NewAuthenticationError(constants.InvalidRefreshTokenError)
+		}
+		return AccessToken{}, err
+	}
+	return accessToken, nil
+}
+
+// RevokeAccessToken logs the token out, its refresh token stops working as well
+func RevokeAccessToken(token string) error {
+	conn := GetConnection()
+	return conn.DeleteAccessToken(token)
+}
+
+// DeleteExpiredAccessTokens deletes the tokens that can neither be used nor refreshed anymore
+func DeleteExpiredAccessTokens() (int64, error) {
+	conn := GetConnection()
+	return conn.DeleteExpiredAccessTokens(time.Now().Unix())
+}
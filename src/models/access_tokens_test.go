@@ -2,13 +2,14 @@ package models
 
 import (
 	"errors"
+	"gorm.io/gorm"
 	"testing"
 	apperrors "the-wedding-game-api/errors"
 	"time"
 )
 
 var (
-	testAccessToken = AccessToken{Token: "test_token", UserID: 1, ExpiresOn: 1}
+	testAccessToken = AccessToken{Token: "test_token", UserID: 1, ExpiresOn: time.Now().Add(time.Hour).Unix()}
 )
 
 func createTestAccessToken(accessToken AccessToken) {
@@ -133,3 +134,119 @@ func TestGetUserByAccessTokenError(t *testing.T) {
 		t.Errorf("expected error but got %s", err.Error())
 	}
 }
+
+func TestGetUserByAccessTokenExpired(t *testing.T) {
+	SetupMockDb()
+	createTestAccessToken(AccessToken{Token: "test_token", UserID: 1, ExpiresOn: time.Now().Unix()})
+	createTestUser(testUser)
+
+	_, err := GetUserByAccessToken("test_token")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsAccessTokenExpiredError(err) {
+		t.Errorf("expected access token expired error but got %s", err.Error())
+	}
+}
+
+func TestLinkAccessTokenToUserWithRefreshToken(t *testing.T) {
+	SetupMockDb()
+	accessToken, err := LinkAccessTokenToUser(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if accessToken.RefreshToken == nil || len(*accessToken.RefreshToken) != 36 || *accessToken.RefreshToken == accessToken.Token {
+		t.Errorf("expected a refresh token different from the access token but got %v", accessToken.RefreshToken)
+	}
+
+	in30Days := time.Now().Add(30 * 24 * time.Hour).Unix()
+	if in30Days-accessToken.RefreshExpiresOn > 1 {
+		t.Errorf("Refresh token expiry time invalid")
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	mockDB := SetupMockDb()
+	refreshToken := "test_refresh_token"
+	mockDB.Create(&AccessToken{
+		Model:            gorm.Model{ID: 1},
+		Token:            "test_token",
+		RefreshToken:     &refreshToken,
+		UserID:           2,
+		ExpiresOn:        time.Now().Add(-time.Hour).Unix(),
+		RefreshExpiresOn: time.Now().Add(time.Hour).Unix(),
+	})
+
+	accessToken, err := RefreshAccessToken(refreshToken)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if accessToken.UserID != 2 || accessToken.Token == "test_token" || *accessToken.RefreshToken == refreshToken {
+		t.Errorf("expected new tokens for user 2 but got %v", accessToken)
+	}
+	if accessToken.ExpiresOn <= time.Now().Unix() {
+		t.Errorf("expected the new access token to be valid but it expires on %d", accessToken.ExpiresOn)
+	}
+
+	_, err = RefreshAccessToken(refreshToken)
+	if err == nil || !apperrors.IsAuthenticationError(err) || err.Error() != "invalid refresh token" {
+		t.Errorf("expected the refresh token to work once but got %v", err)
+	}
+}
+
+func TestRefreshAccessTokenExpired(t *testing.T) {
+	mockDB := SetupMockDb()
+	refreshToken := "test_refresh_token"
+	mockDB.Create(&AccessToken{
+		Model:            gorm.Model{ID: 1},
+		Token:            "test_token",
+		RefreshToken:     &refreshToken,
+		UserID:           2,
+		ExpiresOn:        time.Now().Add(-time.Hour).Unix(),
+		RefreshExpiresOn: time.Now().Add(-time.Minute).Unix(),
+	})
+
+	_, err := RefreshAccessToken(refreshToken)
+	if err == nil || !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected authentication error but got %v", err)
+	}
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.Create(&AccessToken{Token: "test_token", UserID: 1, ExpiresOn: time.Now().Add(time.Hour).Unix()})
+
+	if err := RevokeAccessToken("test_token"); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	_, err := GetUserByAccessToken("test_token")
+	if err == nil || !apperrors.IsAccessTokenNotFoundError(err) {
+		t.Errorf("expected access token not found error but got %v", err)
+	}
+}
+
+func TestDeleteExpiredAccessTokens(t *testing.T) {
+	mockDB := SetupMockDb()
+	now := time.Now()
+	mockDB.Create(&AccessToken{Token: "expired", ExpiresOn: now.Add(-time.Hour).Unix()})
+	mockDB.Create(&AccessToken{Token: "refreshable", ExpiresOn: now.Add(-time.Hour).Unix(), RefreshExpiresOn: now.Add(time.Hour).Unix()})
+	mockDB.Create(&AccessToken{Token: "valid", ExpiresOn: now.Add(time.Hour).Unix(), RefreshExpiresOn: now.Add(time.Hour).Unix()})
+
+	deleted, err := DeleteExpiredAccessTokens()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if deleted != 1 || len(mockDB.items) != 2 {
+		t.Errorf("expected only the expired token to be deleted but got %d deleted, %v left", deleted, mockDB.items)
+	}
+}
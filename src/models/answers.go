@@ -1,8 +1,13 @@
 package models
 
 import (
+	"fmt"
 	"gorm.io/gorm"
+	"math"
+	"regexp"
 	"strconv"
+	"strings"
+	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
 	"the-wedding-game-api/utils"
@@ -10,8 +15,8 @@ import (
 
 type Answer struct {
 	gorm.Model
-	ChallengeID uint   `gorm:"not null;unique"`
-	Value       string `gorm:"not null"`
+	ChallengeID uint   `gorm:"not null;uniqueIndex:idx_challenge_answer"`
+	Value       string `gorm:"not null;uniqueIndex:idx_challenge_answer"`
 	Challenge   Challenge
 }
 
@@ -43,30 +48,160 @@ func VerifyAnswer(challengeId uint, answer string) (bool, error) {
 		return false, err
 	}
 
+	if err := challengeModel.CheckAvailability(); err != nil {
+		return false, err
+	}
+
 	if challengeModel.Type == types.AnswerQuestionChallenge {
-		return verifyAnswerForQuestion(challengeId, answer)
+		return verifyAnswerForQuestion(challengeModel, answer)
 	}
 
-	if challengeModel.Type == types.UploadPhotoChallenge {
+	if _, isMedia := challengeModel.GetMediaType(); isMedia {
 		return verifyAnswerForPhoto(answer)
 	}
 
+	if challengeModel.Type == types.MultipleChoiceChallenge {
+		return verifyAnswerForMultipleChoice(challengeId, answer)
+	}
+
+	if challengeModel.Type == types.ScanCodeChallenge {
+		return verifyAnswerForScanCode(challengeModel, answer), nil
+	}
+
+	if challengeModel.Type == types.PredictionChallenge {
+		return verifyAnswerForPrediction(challengeModel, answer)
+	}
+
+	if challengeModel.Type == types.OrderingChallenge {
+		return verifyAnswerForOrdering(challengeModel, answer)
+	}
+
+	if challengeModel.Type == types.CheckInChallenge {
+		return verifyAnswerForCheckIn(challengeModel, answer)
+	}
+
 	return false, nil
 }
 
-func verifyAnswerForQuestion(challengeId uint, answer string) (bool, error) {
+func getAnswersForQuestion(challengeId uint) ([]Answer, error) {
 	conn := GetConnection()
+	answers, err := conn.GetAnswersForChallenge(challengeId)
+	if err != nil {
+		return nil, err
+	}
 
-	var answerModel Answer
-	err := conn.Where("challenge_id = ?", challengeId).First(&answerModel).GetError()
+	if len(answers) == 0 {
+		return nil, apperrors.NewNotFoundError("Answer with Challenge", strconv.Itoa(int(challengeId)))
+	}
+
+	return answers, nil
+}
+
+func verifyAnswerForQuestion(challenge Challenge, answer string) (bool, error) {
+	answers, err := getAnswersForQuestion(challenge.ID)
 	if err != nil {
-		if apperrors.IsRecordNotFoundError(err) {
-			return false, apperrors.NewNotFoundError("Answer with Challenge", strconv.Itoa(int(challengeId)))
+		return false, err
+	}
+
+	values := make([]string, len(answers))
+	for i := range answers {
+		values[i] = answers[i].Value
+	}
+	return matchAnyAnswer(challenge.GetAnswerMatching(), values, answer)
+}
+
+func matchAnyAnswer(answerMatching types.AnswerMatching, expected []string, answer string) (bool, error) {
+	for _, value := range expected {
+		isCorrect, err := matchAnswer(answerMatching, value, answer)
+		if err != nil {
+			return false, err
+		}
+		if isCorrect {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// acceptsExistingSubmissions checks whether every existing submission for the challenge would still be
+// accepted by the given set of answers, using the matching policy the submissions were judged with.
+func acceptsExistingSubmissions(challenge Challenge, answers []string) (bool, error) {
+	submissions, err := GetSubmissionsForChallenge(challenge.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, submission := range submissions {
+		isAccepted, err := matchAnyAnswer(challenge.GetAnswerMatching(), answers, submission.Answer)
+		if err != nil {
+			return false, err
+		}
+		if !isAccepted {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func matchAnswer(answerMatching types.AnswerMatching, expected string, answer string) (bool, error) {
+	switch answerMatching.Mode {
+	case types.ExactAnswerMatch:
+		return expected == answer, nil
+	case types.RegexAnswerMatch:
+		pattern, err := regexp.Compile("(?i)^(?:" + expected + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid answer pattern: %w", err)
 		}
+		return pattern.MatchString(strings.TrimSpace(answer)), nil
+	case types.NumericAnswerMatch:
+		expectedNumber, err := utils.ParseNumber(expected)
+		if err != nil {
+			return false, fmt.Errorf("invalid numeric answer: %w", err)
+		}
+		number, err := utils.ParseNumber(answer)
+		if err != nil {
+			return false, nil
+		}
+		return math.Abs(expectedNumber-number) <= answerMatching.NumericTolerance, nil
+	default:
+		distance := utils.LevenshteinDistance(utils.NormalizeAnswer(expected), utils.NormalizeAnswer(answer))
+		return distance <= int(answerMatching.MaxDistance), nil
+	}
+}
+
+// verifyAnswerForMultipleChoice expects the answer to be a comma separated list of the selected option ids.
+// The answer is correct only if exactly the options marked as correct are selected.
+func verifyAnswerForMultipleChoice(challengeId uint, answer string) (bool, error) {
+	selectedIds, err := utils.ParseIdList(answer)
+	if err != nil {
+		return false, apperrors.NewValidationError(constants.InvalidOptionSelectionError)
+	}
+
+	options, err := GetOptionsForChallenge(challengeId)
+	if err != nil {
 		return false, err
 	}
+	if len(options) == 0 {
+		return false, apperrors.NewNotFoundError("Options with Challenge", strconv.Itoa(int(challengeId)))
+	}
+
+	selected := make(map[uint]bool, len(selectedIds))
+	for _, id := range selectedIds {
+		selected[id] = true
+	}
 
-	return answerModel.Value == answer, nil
+	correctCount := 0
+	for _, option := range options {
+		if option.Correct != selected[option.ID] {
+			return false, nil
+		}
+		if option.Correct {
+			correctCount++
+		}
+	}
+
+	return len(selected) == correctCount, nil
 }
 
 func verifyAnswerForPhoto(answer string) (bool, error) {
@@ -77,9 +212,29 @@ func verifyAnswerForPhoto(answer string) (bool, error) {
 	return true, nil
 }
 
+// AcceptedAnswers merges the single answer and the list of answers of a challenge request into one set,
+// ignoring empty and duplicate values.
+func AcceptedAnswers(answer string, answers []string) []string {
+	accepted := make([]string, 0, len(answers)+1)
+	seen := make(map[string]bool)
+	for _, value := range append([]string{answer}, answers...) {
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		accepted = append(accepted, value)
+	}
+	return accepted
+}
+
 func (answer Answer) Update() (Answer, error) {
 	conn := GetConnection()
-	return conn.UpdateAnswer(answer.ChallengeID, answer.Value)
+	return conn.UpdateAnswerById(answer)
+}
+
+func UpdateAnswers(challengeId uint, answers []string) ([]Answer, error) {
+	conn := GetConnection()
+	return conn.UpdateAnswer(challengeId, answers)
 }
 
 func DeleteAnswer(challengeId uint) error {
@@ -87,15 +242,125 @@ func DeleteAnswer(challengeId uint) error {
 	return conn.DeleteAnswer(challengeId)
 }
 
-func GetAnswer(challengeId uint) (string, error) {
+func GetAnswers(challengeId uint) ([]Answer, error) {
 	conn := GetConnection()
-	answer, err := conn.GetAnswerForChallenge(challengeId)
+	return conn.GetAnswersForChallenge(challengeId)
+}
+
+func GetAnswer(challengeId uint) ([]string, error) {
+	answers, err := getAnswersForQuestion(challengeId)
 	if err != nil {
-		if apperrors.IsRecordNotFoundError(err) {
-			return "", apperrors.NewNotFoundError("Answer with Challenge", strconv.Itoa(int(challengeId)))
+		return nil, err
+	}
+
+	values := make([]string, len(answers))
+	for i := range answers {
+		values[i] = answers[i].Value
+	}
+	return values, nil
+}
+
+func (challenge Challenge) AddAnswer(value string) (Answer, error) {
+	if challenge.Type != types.AnswerQuestionChallenge {
+		return Answer{}, apperrors.NewValidationError(constants.AnswersNotSupportedError)
+	}
+
+	if err := challenge.checkAnswerValue(value); err != nil {
+		return Answer{}, err
+	}
+
+	answer := NewAnswer(challenge.ID, value)
+	return answer.Save()
+}
+
+func (challenge Challenge) UpdateAnswer(answerId uint, value string) (Answer, error) {
+	answers, err := challenge.getAnswersForUpdate(answerId)
+	if err != nil {
+		return Answer{}, err
+	}
+
+	if err := challenge.checkAnswerValue(value); err != nil {
+		return Answer{}, err
+	}
+
+	remaining := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		if answer.ID == answerId {
+			remaining = append(remaining, value)
+			continue
 		}
-		return "", err
+		remaining = append(remaining, answer.Value)
 	}
 
-	return answer, nil
+	if err := challenge.checkAnswerChangeAllowed(remaining); err != nil {
+		return Answer{}, err
+	}
+
+	answer := NewAnswer(challenge.ID, value)
+	answer.ID = answerId
+	return answer.Update()
+}
+
+func (challenge Challenge) DeleteAnswer(answerId uint) error {
+	answers, err := challenge.getAnswersForUpdate(answerId)
+	if err != nil {
+		return err
+	}
+
+	if len(answers) == 1 {
+		return apperrors.NewValidationError(constants.LastAnswerDeletionError)
+	}
+
+	remaining := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		if answer.ID != answerId {
+			remaining = append(remaining, answer.Value)
+		}
+	}
+
+	if err := challenge.checkAnswerChangeAllowed(remaining); err != nil {
+		return err
+	}
+
+	conn := GetConnection()
+	return conn.DeleteAnswerById(challenge.ID, answerId)
+}
+
+// getAnswersForUpdate returns the current answers of the challenge, ensuring the given answer is one of them
+func (challenge Challenge) getAnswersForUpdate(answerId uint) ([]Answer, error) {
+	if challenge.Type != types.AnswerQuestionChallenge {
+		return nil, apperrors.NewValidationError(constants.AnswersNotSupportedError)
+	}
+
+	answers, err := GetAnswers(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, answer := range answers {
+		if answer.ID == answerId {
+			return answers, nil
+		}
+	}
+
+	return nil, apperrors.NewNotFoundError("Answer", strconv.Itoa(int(answerId)))
+}
+
+func (challenge Challenge) checkAnswerChangeAllowed(answers []string) error {
+	accepted, err := acceptsExistingSubmissions(challenge, answers)
+	if err != nil {
+		return fmt.Errorf("error verifying answer: %w", err)
+	}
+	if !accepted {
+		return apperrors.NewValidationError("Cannot update answer if submissions exist")
+	}
+	return nil
+}
+
+// checkAnswerValue ensures the answer can be interpreted by the matching mode of the challenge
+func (challenge Challenge) checkAnswerValue(value string) error {
+	if _, err := matchAnswer(challenge.GetAnswerMatching(), value, value); err != nil {
+		return apperrors.NewValidationError(err.Error())
+	}
+	return nil
 }
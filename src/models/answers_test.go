@@ -2,9 +2,11 @@ package models
 
 import (
 	"errors"
+	"gorm.io/gorm"
 	"testing"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 var (
@@ -305,14 +307,14 @@ func TestGetAnswer(t *testing.T) {
 	createTestChallenge(testChallenge123)
 	createTestAnswer(testAnswer123)
 
-	answer, err := GetAnswer(testAnswer123.ChallengeID)
+	answers, err := GetAnswer(testAnswer123.ChallengeID)
 	if err != nil {
 		t.Errorf("expected nil but got %s", err.Error())
 		return
 	}
 
-	if answer != "mock_answer" {
-		t.Errorf("expected %s but got %s", testAnswer123.Value, answer)
+	if len(answers) != 1 || answers[0] != testAnswer123.Value {
+		t.Errorf("expected [%s] but got %v", testAnswer123.Value, answers)
 	}
 }
 
@@ -333,3 +335,341 @@ func TestGetAnswerError(t *testing.T) {
 		t.Errorf("expected test_error but got %s", err.Error())
 	}
 }
+
+func TestMatchAnswerNormalized(t *testing.T) {
+	answerMatching := types.AnswerMatching{Mode: types.NormalizedAnswerMatch}
+
+	matchingAnswers := []string{"Eiffel Tower", "The Eiffel Tower ", "eiffel tower", "EIFFEL-TOWER!", "Eiffel  Tówer"}
+	for _, answer := range matchingAnswers {
+		isCorrect, err := matchAnswer(answerMatching, "Eiffel Tower", answer)
+		if err != nil {
+			t.Errorf("expected nil but got %s", err.Error())
+			return
+		}
+		if !isCorrect {
+			t.Errorf("expected %s to match but it did not", answer)
+		}
+	}
+
+	isCorrect, err := matchAnswer(answerMatching, "Eiffel Tower", "Eifel Tower")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if isCorrect {
+		t.Errorf("expected misspelled answer not to match without a max distance")
+	}
+}
+
+func TestMatchAnswerWithMaxDistance(t *testing.T) {
+	answerMatching := types.AnswerMatching{Mode: types.NormalizedAnswerMatch, MaxDistance: 2}
+
+	isCorrect, err := matchAnswer(answerMatching, "Eiffel Tower", "Eifel Towr")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if !isCorrect {
+		t.Errorf("expected answer within distance to match")
+	}
+
+	isCorrect, err = matchAnswer(answerMatching, "Eiffel Tower", "Efel Towr")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if isCorrect {
+		t.Errorf("expected answer outside distance not to match")
+	}
+}
+
+func TestMatchAnswerExact(t *testing.T) {
+	answerMatching := types.AnswerMatching{Mode: types.ExactAnswerMatch}
+
+	isCorrect, _ := matchAnswer(answerMatching, "Eiffel Tower", "Eiffel Tower")
+	if !isCorrect {
+		t.Errorf("expected identical answer to match")
+	}
+
+	isCorrect, _ = matchAnswer(answerMatching, "Eiffel Tower", "eiffel tower")
+	if isCorrect {
+		t.Errorf("expected answer with different case not to match")
+	}
+}
+
+func TestMatchAnswerRegex(t *testing.T) {
+	answerMatching := types.AnswerMatching{Mode: types.RegexAnswerMatch}
+
+	matchingAnswers := []string{"NYC", "new york", " New York City "}
+	for _, answer := range matchingAnswers {
+		isCorrect, err := matchAnswer(answerMatching, "nyc|new york( city)?", answer)
+		if err != nil {
+			t.Errorf("expected nil but got %s", err.Error())
+			return
+		}
+		if !isCorrect {
+			t.Errorf("expected %s to match but it did not", answer)
+		}
+	}
+
+	isCorrect, _ := matchAnswer(answerMatching, "nyc|new york( city)?", "new york state")
+	if isCorrect {
+		t.Errorf("expected pattern to match the whole answer only")
+	}
+
+	_, err := matchAnswer(answerMatching, "(", "anything")
+	if err == nil {
+		t.Errorf("expected error for an invalid pattern but got nil")
+	}
+}
+
+func TestMatchAnswerNumeric(t *testing.T) {
+	answerMatching := types.AnswerMatching{Mode: types.NumericAnswerMatch, NumericTolerance: 0.5}
+
+	matchingAnswers := []string{"42", "42.5", "41,5", " 42 "}
+	for _, answer := range matchingAnswers {
+		isCorrect, err := matchAnswer(answerMatching, "42", answer)
+		if err != nil {
+			t.Errorf("expected nil but got %s", err.Error())
+			return
+		}
+		if !isCorrect {
+			t.Errorf("expected %s to match but it did not", answer)
+		}
+	}
+
+	nonMatchingAnswers := []string{"43", "forty two", ""}
+	for _, answer := range nonMatchingAnswers {
+		isCorrect, err := matchAnswer(answerMatching, "42", answer)
+		if err != nil {
+			t.Errorf("expected nil but got %s", err.Error())
+			return
+		}
+		if isCorrect {
+			t.Errorf("expected %s not to match but it did", answer)
+		}
+	}
+}
+
+func TestVerifyAnswerNormalizedByDefault(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(testChallenge123)
+	createTestAnswer(testAnswer123)
+
+	isCorrect, err := VerifyAnswer(testAnswer123.ChallengeID, " The ANSWER ")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if !isCorrect {
+		t.Errorf("expected true but got false")
+	}
+}
+
+func TestVerifyAnswerMultipleAnswers(t *testing.T) {
+	for _, answer := range []string{"mount everest", "Chomolungma"} {
+		SetupMockDb()
+		createTestChallenge(testChallenge123)
+		createTestAnswer(Answer{ChallengeID: 123, Value: "Mount Everest"})
+		createTestAnswer(Answer{ChallengeID: 123, Value: "Chomolungma"})
+
+		isCorrect, err := VerifyAnswer(testChallenge123.ID, answer)
+		if err != nil {
+			t.Errorf("expected nil but got %s", err.Error())
+			return
+		}
+		if !isCorrect {
+			t.Errorf("expected %s to be accepted", answer)
+		}
+	}
+}
+
+func TestAcceptedAnswers(t *testing.T) {
+	accepted := AcceptedAnswers("a", []string{"b", "a", "", "c"})
+	if len(accepted) != 3 || accepted[0] != "a" || accepted[1] != "b" || accepted[2] != "c" {
+		t.Errorf("expected [a b c] but got %v", accepted)
+	}
+
+	accepted = AcceptedAnswers("", nil)
+	if len(accepted) != 0 {
+		t.Errorf("expected no answers but got %v", accepted)
+	}
+}
+
+func TestChallengeAddAnswer(t *testing.T) {
+	SetupMockDb()
+
+	answer, err := testChallenge123.AddAnswer("another answer")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if answer.ChallengeID != testChallenge123.ID || answer.Value != "another answer" {
+		t.Errorf("expected answer for challenge %d but got %v", testChallenge123.ID, answer)
+	}
+}
+
+func TestChallengeAddAnswerNotSupported(t *testing.T) {
+	SetupMockDb()
+
+	_, err := testChallenge321.AddAnswer("another answer")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "answers can only be managed for answer question challenges" {
+		t.Errorf("expected answers can only be managed for answer question challenges but got %s", err.Error())
+	}
+}
+
+func TestChallengeAddAnswerInvalidPattern(t *testing.T) {
+	SetupMockDb()
+
+	challenge := testChallenge123
+	challenge.AnswerMatchMode = types.RegexAnswerMatch
+	_, err := challenge.AddAnswer("new york(")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+}
+
+func TestChallengeUpdateAnswer(t *testing.T) {
+	SetupMockDb()
+	createTestAnswer(Answer{Model: gorm.Model{ID: 1}, ChallengeID: 123, Value: "answer"})
+
+	answer, err := testChallenge123.UpdateAnswer(1, "new answer")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if answer.ID != 1 || answer.Value != "new answer" {
+		t.Errorf("expected updated answer 1 but got %v", answer)
+	}
+
+	answers, _ := GetAnswer(testChallenge123.ID)
+	if len(answers) != 1 || answers[0] != "new answer" {
+		t.Errorf("expected [new answer] but got %v", answers)
+	}
+}
+
+func TestChallengeUpdateAnswerNotFound(t *testing.T) {
+	SetupMockDb()
+	createTestAnswer(Answer{Model: gorm.Model{ID: 1}, ChallengeID: 123, Value: "answer"})
+
+	_, err := testChallenge123.UpdateAnswer(2, "new answer")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsNotFoundError(err) {
+		t.Errorf("expected not found error but got %s", err.Error())
+	}
+	if err.Error() != "Answer with key 2 not found." {
+		t.Errorf("expected Answer with key 2 not found. but got %s", err.Error())
+	}
+}
+
+func TestChallengeUpdateAnswerWithSubmissions(t *testing.T) {
+	mockDb := SetupMockDb()
+	createTestAnswer(Answer{Model: gorm.Model{ID: 1}, ChallengeID: 123, Value: "answer"})
+	_, _ = mockDb.AddSubmission(NewSubmission(1, testChallenge123.ID, "answer"))
+
+	_, err := testChallenge123.UpdateAnswer(1, "new answer")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "Cannot update answer if submissions exist" {
+		t.Errorf("expected Cannot update answer if submissions exist but got %s", err.Error())
+	}
+}
+
+func TestChallengeDeleteAnswer(t *testing.T) {
+	mockDb := SetupMockDb()
+	createTestAnswer(Answer{Model: gorm.Model{ID: 1}, ChallengeID: 123, Value: "answer"})
+	createTestAnswer(Answer{Model: gorm.Model{ID: 2}, ChallengeID: 123, Value: "other answer"})
+	_, _ = mockDb.AddSubmission(NewSubmission(1, testChallenge123.ID, "answer"))
+
+	err := testChallenge123.DeleteAnswer(2)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	answers, _ := GetAnswer(testChallenge123.ID)
+	if len(answers) != 1 || answers[0] != "answer" {
+		t.Errorf("expected [answer] but got %v", answers)
+	}
+}
+
+func TestChallengeDeleteLastAnswer(t *testing.T) {
+	SetupMockDb()
+	createTestAnswer(Answer{Model: gorm.Model{ID: 1}, ChallengeID: 123, Value: "answer"})
+
+	err := testChallenge123.DeleteAnswer(1)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "cannot delete the last answer of a challenge" {
+		t.Errorf("expected cannot delete the last answer of a challenge but got %s", err.Error())
+	}
+}
+
+func TestChallengeDeleteAnswerWithSubmissions(t *testing.T) {
+	mockDb := SetupMockDb()
+	createTestAnswer(Answer{Model: gorm.Model{ID: 1}, ChallengeID: 123, Value: "answer"})
+	createTestAnswer(Answer{Model: gorm.Model{ID: 2}, ChallengeID: 123, Value: "other answer"})
+	_, _ = mockDb.AddSubmission(NewSubmission(1, testChallenge123.ID, "other answer"))
+
+	err := testChallenge123.DeleteAnswer(2)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "Cannot update answer if submissions exist" {
+		t.Errorf("expected Cannot update answer if submissions exist but got %s", err.Error())
+	}
+}
+
+func TestVerifyAnswerOutsideAvailabilityWindow(t *testing.T) {
+	SetupMockDb()
+
+	past := time.Now().Add(-time.Hour)
+	challenge := testChallenge123
+	challenge.AvailableUntil = &past
+	createTestChallenge(challenge)
+	createTestAnswer(testAnswer123)
+
+	_, err := VerifyAnswer(testAnswer123.ChallengeID, testAnswer123.Value)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "challenge is no longer available" {
+		t.Errorf("expected challenge is no longer available but got %s", err.Error())
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+// Attempt records every answer a user submitted for a challenge, whether it was correct or not
+type Attempt struct {
+	gorm.Model
+	UserID      uint   `gorm:"not null;index"`
+	ChallengeID uint   `gorm:"not null;index"`
+	Value       string `gorm:"not null"`
+	Correct     bool   `gorm:"not null;default:false"`
+	User        User
+	Challenge   Challenge
+}
+
+func NewAttempt(userId uint, challengeId uint, value string, correct bool) Attempt {
+	return Attempt{
+		UserID:      userId,
+		ChallengeID: challengeId,
+		Value:       value,
+		Correct:     correct,
+	}
+}
+
+func (attempt Attempt) Save() (Attempt, error) {
+	conn := GetConnection()
+	if err := conn.Create(&attempt).GetError(); err != nil {
+		return Attempt{}, err
+	}
+	return attempt, nil
+}
+
+// SaveAttempt records the answer of the user unless all attempts of the challenge are used up, the attempts are
+// counted and saved in one transaction so concurrent answers cannot go past the limit
+func (challenge Challenge) SaveAttempt(attempt Attempt) (Attempt, error) {
+	conn := GetConnection()
+	return conn.SaveAttempt(attempt, challenge.MaxAttempts)
+}
+
+func GetAttemptsForChallenge(challengeId uint) ([]types.AttemptForChallenge, error) {
+	conn := GetConnection()
+	return conn.GetAttemptsForChallenge(challengeId)
+}
+
+// GetRemainingAttempts returns the number of wrong answers the user can still submit, or nil if the challenge has no limit
+func (challenge Challenge) GetRemainingAttempts(userId uint) (*uint, error) {
+	if challenge.MaxAttempts == 0 {
+		return nil, nil
+	}
+
+	conn := GetConnection()
+	failed, err := conn.CountFailedAttempts(userId, challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := uint(0)
+	if failed < challenge.MaxAttempts {
+		remaining = challenge.MaxAttempts - failed
+	}
+	return &remaining, nil
+}
+
+// CheckAttemptsLeft returns a validation error once the user used up all attempts for the challenge
+func (challenge Challenge) CheckAttemptsLeft(userId uint) error {
+	remaining, err := challenge.GetRemainingAttempts(userId)
+	if err != nil {
+		return err
+	}
+	if remaining != nil && *remaining == 0 {
+		return apperrors.NewValidationError(constants.NoAttemptsLeftError)
+	}
+	return nil
+}
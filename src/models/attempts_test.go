@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+)
+
+func createTestAttempt(attempt Attempt) {
+	database := GetConnection()
+	database.Create(&attempt)
+}
+
+func TestNewAttempt(t *testing.T) {
+	attempt := NewAttempt(1, 123, "answer", true)
+	if attempt.UserID != 1 || attempt.ChallengeID != 123 || attempt.Value != "answer" || !attempt.Correct {
+		t.Errorf("expected correct attempt of user 1 for challenge 123 but got %v", attempt)
+	}
+}
+
+func TestGetRemainingAttemptsWithoutLimit(t *testing.T) {
+	SetupMockDb()
+	createTestAttempt(NewAttempt(1, 123, "wrong", false))
+
+	remaining, err := testChallenge123.GetRemainingAttempts(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if remaining != nil {
+		t.Errorf("expected no limit but got %d remaining", *remaining)
+	}
+}
+
+func TestGetRemainingAttempts(t *testing.T) {
+	SetupMockDb()
+	challenge := testChallenge123
+	challenge.MaxAttempts = 3
+
+	createTestAttempt(NewAttempt(1, 123, "wrong", false))
+	createTestAttempt(NewAttempt(1, 123, "also wrong", false))
+	createTestAttempt(NewAttempt(2, 123, "wrong", false))
+	createTestAttempt(NewAttempt(1, 456, "wrong", false))
+
+	remaining, err := challenge.GetRemainingAttempts(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if remaining == nil || *remaining != 1 {
+		t.Errorf("expected 1 remaining attempt but got %v", remaining)
+	}
+}
+
+func TestCheckAttemptsLeft(t *testing.T) {
+	SetupMockDb()
+	challenge := testChallenge123
+	challenge.MaxAttempts = 2
+
+	createTestAttempt(NewAttempt(1, 123, "wrong", false))
+	if err := challenge.CheckAttemptsLeft(1); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	createTestAttempt(NewAttempt(1, 123, "also wrong", false))
+	err := challenge.CheckAttemptsLeft(1)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+		return
+	}
+	if err.Error() != "no attempts left for this challenge" {
+		t.Errorf("expected 'no attempts left for this challenge' but got %s", err.Error())
+	}
+}
+
+func TestSaveAttempt(t *testing.T) {
+	SetupMockDb()
+	challenge := testChallenge123
+	challenge.MaxAttempts = 1
+
+	if _, err := challenge.SaveAttempt(NewAttempt(1, 123, "wrong", false)); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	_, err := challenge.SaveAttempt(NewAttempt(1, 123, "also wrong", false))
+	if err == nil || !apperrors.IsValidationError(err) || err.Error() != "no attempts left for this challenge" {
+		t.Errorf("expected no attempts left error but got %v", err)
+		return
+	}
+
+	remaining, err := challenge.GetRemainingAttempts(1)
+	if err != nil || remaining == nil || *remaining != 0 {
+		t.Errorf("expected the refused attempt not to be saved but got %v %v", remaining, err)
+	}
+}
+
+func TestGetAttemptsForChallenge(t *testing.T) {
+	SetupMockDb()
+	createTestAttempt(NewAttempt(1, 123, "wrong", false))
+	createTestAttempt(NewAttempt(2, 123, "answer", true))
+	createTestAttempt(NewAttempt(1, 456, "other", false))
+
+	attempts, err := GetAttemptsForChallenge(123)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(attempts) != 2 {
+		t.Errorf("expected 2 attempts but got %d", len(attempts))
+		return
+	}
+	if attempts[0].Value != "wrong" || attempts[0].Correct || attempts[1].Value != "answer" || !attempts[1].Correct {
+		t.Errorf("expected the wrong and the correct attempt but got %v", attempts)
+	}
+}
@@ -1,18 +1,31 @@
 package models
 
 import (
+	"crypto/subtle"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"os"
+	"slices"
+	"strconv"
+	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
 )
 
 type User struct {
 	gorm.Model
-	Username string         `gorm:"unique;not null"`
-	Role     types.UserRole `gorm:"default:'PLAYER'"`
+	Username     string         `gorm:"unique;not null"`
+	Role         types.UserRole `gorm:"default:'PLAYER'"`
+	TeamID       *uint          `gorm:"index"`
+	PasswordHash *string        // bcrypt hash, only admins have a password
 }
 
+// bcrypt ignores everything after 72 bytes
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
 func NewUser(username string) User {
 	return User{
 		Username: username,
@@ -32,12 +45,114 @@ func DoesUserExist(username string) (bool, User, error) {
 	return true, user, nil
 }
 
-func ValidatePassword(password string) error {
-	expectedPassword := os.Getenv("ADMIN_PASSWORD")
-	if password != expectedPassword {
-		return apperrors.NewAuthenticationError("invalid password")
+// HasPermission returns true if the role of the user grants the permission
+func (user User) HasPermission(permission types.Permission) bool {
+	return slices.Contains(types.RolePermissions[user.Role], permission)
+}
+
+// IsStaff returns true for admins and moderators, they log in with a password instead of an invite code
+func (user User) IsStaff() bool {
+	return user.Role != types.Player
+}
+
+// ValidatePassword checks the password of the user against their password hash. Admins without a password yet
+// can log in once with ADMIN_PASSWORD, which then becomes their password.
+func (user User) ValidatePassword(password string) error {
+	if user.PasswordHash != nil {
+		if bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(password)) != nil {
+			return apperrors.NewAuthenticationError(constants.InvalidPasswordError)
+		}
+		return nil
+	}
+
+	bootstrapPassword := os.Getenv("ADMIN_PASSWORD")
+	if user.Role != types.Admin || bootstrapPassword == "" || subtle.ConstantTimeCompare([]byte(password), []byte(bootstrapPassword)) != 1 {
+		return apperrors.NewAuthenticationError(constants.InvalidPasswordError)
+	}
+	_, err := user.SetPassword(password)
+	return err
+}
+
+// SetPassword stores the hash of the new password of the user
+func (user User) SetPassword(password string) (User, error) {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return User{}, apperrors.NewValidationError(constants.PasswordLengthError)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return User{}, err
+	}
+	passwordHash := string(hash)
+
+	conn := GetConnection()
+	if err := conn.SetUserPassword(user.ID, passwordHash); err != nil {
+		return User{}, err
+	}
+	user.PasswordHash = &passwordHash
+	return user, nil
+}
+
+// ChangePassword sets the new password of the user if the current one is right
+func (user User) ChangePassword(currentPassword string, newPassword string) (User, error) {
+	if err := user.ValidatePassword(currentPassword); err != nil {
+		return User{}, err
 	}
-	return nil
+	return user.SetPassword(newPassword)
+}
+
+// SetRole changes the role of the user, admins and moderators need a password so the password is set as well if given.
+// Players log in without one, the password of a demoted user is removed so it cannot be used anymore.
+func (user User) SetRole(role types.UserRole, password string) (User, error) {
+	if role != types.Player && user.PasswordHash == nil && password == "" {
+		return User{}, apperrors.NewValidationError(constants.PasswordRequiredForRoleError)
+	}
+
+	if role != types.Player && password != "" {
+		var err error
+		if user, err = user.SetPassword(password); err != nil {
+			return User{}, err
+		}
+	}
+
+	conn := GetConnection()
+	if err := conn.SetUserRole(user.ID, role); err != nil {
+		return User{}, err
+	}
+	user.Role = role
+	if role == types.Player {
+		user.PasswordHash = nil
+	}
+	return user, nil
+}
+
+func GetAllUsers() ([]User, error) {
+	conn := GetConnection()
+	return conn.GetAllUsers()
+}
+
+// SetAdminPassword makes the user with the username an admin with the password, the user is created if needed
+func SetAdminPassword(username string, password string) (User, error) {
+	exists, user, err := DoesUserExist(username)
+	if err != nil {
+		return User{}, err
+	}
+
+	if !exists {
+		user = NewUser(username)
+		user.Role = types.Admin
+		if user, err = user.Save(); err != nil {
+			return User{}, err
+		}
+	} else if user.Role != types.Admin {
+		conn := GetConnection()
+		if err := conn.SetUserRole(user.ID, types.Admin); err != nil {
+			return User{}, err
+		}
+		user.Role = types.Admin
+	}
+
+	return user.SetPassword(password)
 }
 
 func (user User) Save() (User, error) {
@@ -50,9 +165,17 @@ func (user User) Save() (User, error) {
 
 func (user User) GetPoints() (uint, error) {
 	conn := GetConnection()
-	points, err := conn.GetPointsForUser(user.ID)
-	if err != nil {
-		return 0, err
+	return conn.GetPointsForUser(user.ID)
+}
+
+func GetUserByID(id uint) (User, error) {
+	conn := GetConnection()
+	var user User
+	if err := conn.First(&user, id).GetError(); err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return User{}, apperrors.NewNotFoundError("User", strconv.Itoa(int(id)))
+		}
+		return User{}, err
 	}
-	return points, nil
+	return user, nil
 }
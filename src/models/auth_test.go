@@ -2,7 +2,7 @@ package models
 
 import (
 	"errors"
-	"os"
+	"gorm.io/gorm"
 	"testing"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
@@ -139,39 +139,51 @@ func TestUserSaveError(t *testing.T) {
 	}
 }
 
-func TestValidatePassword1(t *testing.T) {
-	err := os.Setenv("ADMIN_PASSWORD", "test_password")
-	if err != nil {
-		t.Errorf("Failed to set environment variable: %s", err.Error())
-	}
+func createTestAdmin() *User {
+	database := GetConnection()
+	admin := &User{Model: gorm.Model{ID: 1}, Username: testUserAdmin.Username, Role: types.Admin}
+	database.Create(admin)
+	return admin
+}
 
-	err = ValidatePassword("test_password")
+func TestValidatePasswordWithBootstrapPassword(t *testing.T) {
+	SetupMockDb()
+	admin := createTestAdmin()
+	t.Setenv("ADMIN_PASSWORD", "test_password")
+
+	err := admin.ValidatePassword("test_password")
 	if err != nil {
 		t.Errorf("expected nil but got %s", err.Error())
 		return
 	}
-}
 
-func TestValidatePassword2(t *testing.T) {
-	err := os.Setenv("ADMIN_PASSWORD", "another password")
-	if err != nil {
-		t.Errorf("Failed to set environment variable: %s", err.Error())
+	if admin.PasswordHash == nil || *admin.PasswordHash == "test_password" {
+		t.Errorf("expected the bootstrap password to be stored hashed")
 	}
+}
 
-	err = ValidatePassword("another password")
-	if err != nil {
-		t.Errorf("expected nil but got %s", err.Error())
+func TestValidatePasswordWithoutBootstrapPassword(t *testing.T) {
+	SetupMockDb()
+	admin := createTestAdmin()
+	t.Setenv("ADMIN_PASSWORD", "")
+
+	err := admin.ValidatePassword("")
+	if err == nil {
+		t.Errorf("expected error but got nil")
 		return
 	}
+
+	if !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected true but got false")
+	}
 }
 
 func TestValidatePasswordError(t *testing.T) {
-	err := os.Setenv("ADMIN_PASSWORD", "test_password")
-	if err != nil {
-		t.Errorf("Failed to set environment variable: %s", err.Error())
-	}
+	SetupMockDb()
+	admin := createTestAdmin()
+	t.Setenv("ADMIN_PASSWORD", "test_password")
 
-	err = ValidatePassword("wrong_password")
+	err := admin.ValidatePassword("wrong_password")
 	if err == nil {
 		t.Errorf("expected error but got nil")
 		return
@@ -185,6 +197,59 @@ func TestValidatePasswordError(t *testing.T) {
 	}
 }
 
+func TestValidatePasswordWithHash(t *testing.T) {
+	SetupMockDb()
+	admin := createTestAdmin()
+	t.Setenv("ADMIN_PASSWORD", "test_password")
+
+	updated, err := admin.SetPassword("another password")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if err := updated.ValidatePassword("another password"); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	err = updated.ValidatePassword("test_password")
+	if err == nil || !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected the bootstrap password to be rejected once a password is set but got %v", err)
+	}
+}
+
+func TestSetPasswordTooShort(t *testing.T) {
+	SetupMockDb()
+	admin := createTestAdmin()
+
+	_, err := admin.SetPassword("short")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+}
+
+func TestChangePasswordWrongCurrentPassword(t *testing.T) {
+	SetupMockDb()
+	admin := createTestAdmin()
+	updated, _ := admin.SetPassword("test_password")
+
+	_, err := updated.ChangePassword("wrong_password", "another password")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected authentication error but got %s", err.Error())
+	}
+}
+
 func TestGetPoints(t *testing.T) {
 	SetupMockDb()
 	createTestUser(testUser)
@@ -220,3 +285,88 @@ func TestGetPointsError(t *testing.T) {
 		t.Errorf("expected test_error but got %s", err.Error())
 	}
 }
+
+func TestHasPermission(t *testing.T) {
+	admin := User{Role: types.Admin}
+	moderator := User{Role: types.Moderator}
+	player := User{Role: types.Player}
+
+	if !admin.HasPermission(types.ManageChallengesPermission) || !admin.HasPermission(types.ModerateSubmissionsPermission) {
+		t.Errorf("expected admins to have every permission")
+	}
+	if !moderator.HasPermission(types.ModerateSubmissionsPermission) || !moderator.HasPermission(types.ViewSubmissionsPermission) {
+		t.Errorf("expected moderators to view and moderate submissions")
+	}
+	if moderator.HasPermission(types.ViewAnswersPermission) || moderator.HasPermission(types.ManageScoresPermission) {
+		t.Errorf("expected moderators not to see answers or change scores")
+	}
+	if player.HasPermission(types.ViewSubmissionsPermission) {
+		t.Errorf("expected players to have no permissions")
+	}
+}
+
+func TestValidatePasswordBootstrapOnlyForAdmins(t *testing.T) {
+	SetupMockDb()
+	t.Setenv("ADMIN_PASSWORD", "test_password")
+
+	moderator := User{Model: gorm.Model{ID: 2}, Username: "moderator", Role: types.Moderator}
+	err := moderator.ValidatePassword("test_password")
+	if err == nil || !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected authentication error but got %v", err)
+	}
+}
+
+func TestSetRoleRequiresPassword(t *testing.T) {
+	SetupMockDb()
+	createTestUser(User{Model: gorm.Model{ID: 2}, Username: "bridesmaid", Role: types.Player})
+
+	_, err := User{Model: gorm.Model{ID: 2}, Role: types.Player}.SetRole(types.Moderator, "")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "a password is required for admins and moderators" {
+		t.Errorf("expected validation error 'a password is required for admins and moderators' but got %s", err.Error())
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	SetupMockDb()
+	createTestUser(User{Model: gorm.Model{ID: 2}, Username: "bridesmaid", Role: types.Player})
+
+	user, err := User{Model: gorm.Model{ID: 2}, Role: types.Player}.SetRole(types.Moderator, "bridesmaid password")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if user.Role != types.Moderator || user.PasswordHash == nil {
+		t.Errorf("expected a moderator with a password but got %v", user)
+		return
+	}
+
+	if err := user.ValidatePassword("bridesmaid password"); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestSetRoleToPlayerRemovesPassword(t *testing.T) {
+	mockDB := SetupMockDb()
+	passwordHash := "hash"
+	createTestUser(User{Model: gorm.Model{ID: 2}, Username: "bridesmaid", Role: types.Moderator, PasswordHash: &passwordHash})
+
+	user, err := User{Model: gorm.Model{ID: 2}, Role: types.Moderator, PasswordHash: &passwordHash}.SetRole(types.Player, "")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if user.Role != types.Player || user.PasswordHash != nil {
+		t.Errorf("expected a player without a password but got %v", user)
+		return
+	}
+
+	for _, item := range mockDB.items {
+		if stored, ok := item.(*User); ok && stored.ID == 2 && stored.PasswordHash != nil {
+			t.Errorf("expected the stored password to be removed but got %v", *stored.PasswordHash)
+		}
+	}
+}
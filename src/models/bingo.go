@@ -0,0 +1,275 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"log"
+	"slices"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+// BingoBoard is the published bingo card, a grid of size × size challenges. Publishing a new board replaces it.
+type BingoBoard struct {
+	gorm.Model
+	Size          uint `gorm:"not null"`
+	LineBonus     uint `gorm:"not null;default:0"`
+	FullCardBonus uint `gorm:"not null;default:0"`
+	Shuffle       bool `gorm:"not null;default:false"`
+}
+
+// BingoCell places a challenge on the board, positions go row by row starting at 0
+type BingoCell struct {
+	gorm.Model
+	BoardID     uint `gorm:"not null;index"`
+	ChallengeID uint `gorm:"not null;index"`
+	Position    uint `gorm:"not null"`
+	Board       BingoBoard
+	Challenge   Challenge
+}
+
+// BingoCard is the board as one player sees it, with the cells in the order of the player
+type BingoCard struct {
+	Board          BingoBoard
+	Cells          []BingoCell
+	Completed      []bool
+	CompletedLines uint
+	FullCard       bool
+	Bonus          uint
+	Challenges     map[uint]Challenge
+}
+
+func NewBingoBoard(size uint, lineBonus uint, fullCardBonus uint, shuffle bool) BingoBoard {
+	return BingoBoard{
+		Size:          size,
+		LineBonus:     lineBonus,
+		FullCardBonus: fullCardBonus,
+		Shuffle:       shuffle,
+	}
+}
+
+// PublishBingoBoard replaces the current board, the challenges fill the cells row by row.
+// The request is expected to hold size × size different challenges.
+func PublishBingoBoard(publishBingoBoardRequest types.PublishBingoBoardRequest) (BingoBoard, []BingoCell, error) {
+	challengeIds := publishBingoBoardRequest.Challenges
+	conn := GetConnection()
+	challenges, err := conn.GetChallengesByIds(challengeIds)
+	if err != nil {
+		return BingoBoard{}, nil, err
+	}
+	if len(challenges) != len(challengeIds) {
+		return BingoBoard{}, nil, apperrors.NewValidationError(constants.InvalidBingoBoardError)
+	}
+
+	board := NewBingoBoard(publishBingoBoardRequest.Size, publishBingoBoardRequest.LineBonus, publishBingoBoardRequest.FullCardBonus,
+		publishBingoBoardRequest.Shuffle)
+	cells := make([]BingoCell, len(challengeIds))
+	for i, challengeId := range challengeIds {
+		cells[i] = BingoCell{ChallengeID: challengeId, Position: uint(i)}
+	}
+
+	board, cells, err = conn.ReplaceBingoBoard(board, cells)
+	if err != nil {
+		return BingoBoard{}, nil, err
+	}
+	refreshBingoBonuses()
+	return board, cells, nil
+}
+
+// GetBingoBoard returns the current board and its cells in the order they were published
+func GetBingoBoard() (BingoBoard, []BingoCell, error) {
+	board, cells, err := getCurrentBingoBoard()
+	if err != nil {
+		return BingoBoard{}, nil, err
+	}
+	if board == nil {
+		return BingoBoard{}, nil, apperrors.NewNotFoundError("Bingo board", "current")
+	}
+	return *board, cells, nil
+}
+
+// getCurrentBingoBoard returns the current board and its cells, or nil if no board is published
+func getCurrentBingoBoard() (*BingoBoard, []BingoCell, error) {
+	conn := GetConnection()
+	board, err := conn.GetBingoBoard()
+	if err != nil || board == nil {
+		return nil, nil, err
+	}
+
+	cells, err := conn.GetBingoCells(board.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return board, cells, nil
+}
+
+// DeleteBingoBoard removes the board, the bonuses earned on it are withdrawn
+func DeleteBingoBoard() error {
+	conn := GetConnection()
+	if err := conn.DeleteBingoBoard(); err != nil {
+		return err
+	}
+	refreshBingoBonuses()
+	return nil
+}
+
+// CheckNotOnBingoBoard returns a validation error if the challenge is a cell of the current board,
+// deleting it would leave a cell nobody can complete
+func (challenge Challenge) CheckNotOnBingoBoard() error {
+	_, cells, err := getCurrentBingoBoard()
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(cells, func(cell BingoCell) bool { return cell.ChallengeID == challenge.ID }) {
+		return apperrors.NewValidationError(constants.ChallengeOnBingoBoardError)
+	}
+	return nil
+}
+
+// GetCellsForUser returns the cells in the order the user sees them, shuffled per user if the board asks for it
+func (board BingoBoard) GetCellsForUser(cells []BingoCell, userId uint) []BingoCell {
+	if !board.Shuffle {
+		return cells
+	}
+	return shuffleForUser(cells, userId, board.ID)
+}
+
+// countBingoLines counts the completed rows, columns and diagonals of a card given row by row
+func countBingoLines(size uint, completed []bool) (uint, bool) {
+	n := int(size)
+	isCompleted := func(row int, column int) bool { return completed[row*n+column] }
+
+	var lines uint
+	fullCard := true
+	diagonal, antiDiagonal := true, true
+	for i := 0; i < n; i++ {
+		row, column := true, true
+		for j := 0; j < n; j++ {
+			row = row && isCompleted(i, j)
+			column = column && isCompleted(j, i)
+		}
+		if row {
+			lines++
+		} else {
+			fullCard = false
+		}
+		if column {
+			lines++
+		}
+		diagonal = diagonal && isCompleted(i, i)
+		antiDiagonal = antiDiagonal && isCompleted(i, n-1-i)
+	}
+	if diagonal {
+		lines++
+	}
+	if antiDiagonal {
+		lines++
+	}
+
+	return lines, fullCard
+}
+
+// getBingoCard works out which cells the user completed and the bonus they earned
+func (board BingoBoard) getBingoCard(cells []BingoCell, userId uint, completedChallenges map[uint]bool) BingoCard {
+	card := BingoCard{
+		Board: board,
+		Cells: board.GetCellsForUser(cells, userId),
+	}
+
+	card.Completed = make([]bool, len(card.Cells))
+	for i, cell := range card.Cells {
+		card.Completed[i] = completedChallenges[cell.ChallengeID]
+	}
+
+	card.CompletedLines, card.FullCard = countBingoLines(board.Size, card.Completed)
+	card.Bonus = card.CompletedLines * board.LineBonus
+	if card.FullCard {
+		card.Bonus += board.FullCardBonus
+	}
+	return card
+}
+
+// getBingoCompletions returns the challenges of the board completed by every user, team challenges completed
+// by a team member count for the whole team
+func getBingoCompletions(cells []BingoCell) (map[uint]map[uint]bool, error) {
+	conn := GetConnection()
+	completions, err := conn.GetBingoCompletions(getBingoChallengeIds(cells))
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[uint]map[uint]bool)
+	for _, completion := range completions {
+		if completed[completion.UserId] == nil {
+			completed[completion.UserId] = make(map[uint]bool)
+		}
+		completed[completion.UserId][completion.ChallengeId] = true
+	}
+	return completed, nil
+}
+
+func getBingoChallengeIds(cells []BingoCell) []uint {
+	challengeIds := make([]uint, len(cells))
+	for i, cell := range cells {
+		challengeIds[i] = cell.ChallengeID
+	}
+	return challengeIds
+}
+
+// GetBingoCard returns the current board as the user sees it
+func GetBingoCard(userId uint) (BingoCard, error) {
+	board, cells, err := GetBingoBoard()
+	if err != nil {
+		return BingoCard{}, err
+	}
+
+	completed, err := getBingoCompletions(cells)
+	if err != nil {
+		return BingoCard{}, err
+	}
+	card := board.getBingoCard(cells, userId, completed[userId])
+
+	conn := GetConnection()
+	challenges, err := conn.GetChallengesByIds(getBingoChallengeIds(cells))
+	if err != nil {
+		return BingoCard{}, err
+	}
+	card.Challenges = make(map[uint]Challenge, len(challenges))
+	for _, challenge := range challenges {
+		card.Challenges[challenge.ID] = challenge
+	}
+	return card, nil
+}
+
+// refreshBingoBonuses syncs the bingo bonuses after a change that is already saved. A failed sync is only logged,
+// reporting the saved change as failed would make a retry fail as a duplicate; the next sync records the missed bonuses.
+func refreshBingoBonuses() {
+	if err := syncBingoBonuses(); err != nil {
+		log.Println("Error syncing bingo bonuses: ", err)
+	}
+}
+
+// syncBingoBonuses records in the ledger the change of the bingo bonus of every user since the last sync,
+// it runs after anything that can change a card: a completion, a challenge, a team or the board itself
+func syncBingoBonuses() error {
+	bonuses := make(map[uint]uint)
+	board, cells, err := getCurrentBingoBoard()
+	if err != nil {
+		return err
+	}
+
+	if board != nil {
+		completed, err := getBingoCompletions(cells)
+		if err != nil {
+			return err
+		}
+		for userId, completedChallenges := range completed {
+			if card := board.getBingoCard(cells, userId, completedChallenges); card.Bonus > 0 {
+				bonuses[userId] = card.Bonus
+			}
+		}
+	}
+
+	conn := GetConnection()
+	return conn.RecordBingoBonuses(bonuses)
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"slices"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+// setupBingoBoard publishes a 2 × 2 board of the challenges 1 to 4, worth 10 points a line and 50 for a full card
+func setupBingoBoard(t *testing.T, shuffle bool) *MockDB {
+	mockDB := SetupMockDb()
+	for id := uint(1); id <= 4; id++ {
+		mockDB.Create(&Challenge{ID: id, Name: "challenge", Type: types.AnswerQuestionChallenge, Status: types.ActiveChallenge})
+	}
+
+	_, _, err := PublishBingoBoard(types.PublishBingoBoardRequest{
+		Size: 2, Challenges: []uint{1, 2, 3, 4}, LineBonus: 10, FullCardBonus: 50, Shuffle: shuffle,
+	})
+	if err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+	return mockDB
+}
+
+func completeBingoCells(mockDB *MockDB, userId uint, challengeIds ...uint) {
+	for _, challengeId := range challengeIds {
+		submission := NewSubmission(userId, challengeId, "answer")
+		submission.Model = gorm.Model{ID: userId*100 + challengeId}
+		_, _ = mockDB.AddSubmission(submission)
+	}
+}
+
+func TestCountBingoLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed []bool
+		lines     uint
+		fullCard  bool
+	}{
+		{"empty", []bool{false, false, false, false, false, false, false, false, false}, 0, false},
+		{"row", []bool{false, false, false, true, true, true, false, false, false}, 1, false},
+		{"column", []bool{false, true, false, false, true, false, false, true, false}, 1, false},
+		{"diagonal", []bool{true, false, false, false, true, false, false, false, true}, 1, false},
+		{"anti diagonal", []bool{false, false, true, false, true, false, true, false, false}, 1, false},
+		{"row and column", []bool{true, true, true, true, false, false, true, false, false}, 2, false},
+		{"full card", []bool{true, true, true, true, true, true, true, true, true}, 8, true},
+	}
+
+	for _, test := range tests {
+		lines, fullCard := countBingoLines(3, test.completed)
+		if lines != test.lines || fullCard != test.fullCard {
+			t.Errorf("%s: expected %d lines and full card %v but got %d and %v", test.name, test.lines, test.fullCard, lines, fullCard)
+		}
+	}
+}
+
+func TestPublishBingoBoardWithUnknownChallenge(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.Create(&Challenge{ID: 1, Name: "challenge", Type: types.AnswerQuestionChallenge, Status: types.ActiveChallenge})
+
+	_, _, err := PublishBingoBoard(types.PublishBingoBoardRequest{Size: 2, Challenges: []uint{1, 2, 3, 4}})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "challenges must fill every cell of the board with a different challenge" {
+		t.Errorf("expected invalid bingo board error but got %s", err.Error())
+	}
+}
+
+func TestGetBingoCard(t *testing.T) {
+	mockDB := setupBingoBoard(t, false)
+	completeBingoCells(mockDB, 1, 1, 2, 3)
+
+	card, err := GetBingoCard(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if !slices.Equal(card.Completed, []bool{true, true, true, false}) {
+		t.Errorf("expected the first three cells to be completed but got %v", card.Completed)
+	}
+	// the first row, the first column and the anti diagonal
+	if card.CompletedLines != 3 || card.FullCard || card.Bonus != 30 {
+		t.Errorf("expected 3 lines worth 30 points but got %d lines worth %d", card.CompletedLines, card.Bonus)
+	}
+	if card.Challenges[4].Name != "challenge" {
+		t.Errorf("expected the challenges of the cells but got %v", card.Challenges)
+	}
+}
+
+func TestGetBingoCardFullCard(t *testing.T) {
+	mockDB := setupBingoBoard(t, false)
+	completeBingoCells(mockDB, 1, 1, 2, 3, 4)
+
+	card, err := GetBingoCard(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if card.CompletedLines != 6 || !card.FullCard || card.Bonus != 110 {
+		t.Errorf("expected 6 lines and a full card worth 110 points but got %d lines worth %d", card.CompletedLines, card.Bonus)
+	}
+}
+
+func TestGetBingoCardIgnoresInactiveChallenges(t *testing.T) {
+	mockDB := setupBingoBoard(t, false)
+	completeBingoCells(mockDB, 1, 1, 2)
+	for _, item := range mockDB.items {
+		if challenge, ok := item.(*Challenge); ok && challenge.ID == 2 {
+			challenge.Status = types.InactiveChallenge
+		}
+	}
+
+	card, err := GetBingoCard(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if card.Completed[1] || card.CompletedLines != 0 || card.Bonus != 0 {
+		t.Errorf("expected the inactive challenge not to count but got %v", card)
+	}
+}
+
+func TestGetBingoCardWithoutBoard(t *testing.T) {
+	SetupMockDb()
+
+	_, err := GetBingoCard(1)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsNotFoundError(err) {
+		t.Errorf("expected not found error but got %s", err.Error())
+	}
+}
+
+func TestGetBingoCardShuffledPerUser(t *testing.T) {
+	setupBingoBoard(t, true)
+
+	board, cells, err := GetBingoBoard()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	first := board.GetCellsForUser(cells, 1)
+	if !slices.Equal(first, board.GetCellsForUser(cells, 1)) {
+		t.Errorf("expected the same order for the same user")
+	}
+
+	sorted := slices.Clone(first)
+	slices.SortFunc(sorted, func(a, b BingoCell) int { return int(a.Position) - int(b.Position) })
+	if !slices.Equal(sorted, cells) {
+		t.Errorf("expected the shuffled card to contain every cell once")
+	}
+}
+
+// getBingoPointsEntries returns the points of the bingo entries in the ledger, in the order they were recorded
+func getBingoPointsEntries(mockDB *MockDB) []PointsEntry {
+	var entries []PointsEntry
+	for _, item := range mockDB.items {
+		if entry, ok := item.(*PointsEntry); ok && entry.Type == types.BingoPointsEntry {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries
+}
+
+func TestSyncBingoBonuses(t *testing.T) {
+	mockDB := setupBingoBoard(t, false)
+	completeBingoCells(mockDB, 1, 1, 2)
+	completeBingoCells(mockDB, 4, 1, 3)
+
+	if err := syncBingoBonuses(); err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+	entries := getBingoPointsEntries(mockDB)
+	if len(entries) != 2 || entries[0].UserID != 1 || entries[0].Points != 10 || entries[1].UserID != 4 || entries[1].Points != 10 {
+		t.Errorf("expected a row for user 1 and a column for user 4 but got %v", entries)
+		return
+	}
+
+	// Syncing again without any change records nothing
+	if err := syncBingoBonuses(); err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+	if entries = getBingoPointsEntries(mockDB); len(entries) != 2 {
+		t.Errorf("expected no new entries but got %v", entries)
+		return
+	}
+
+	// user1 completes the card, 6 lines and the full card are worth 110 points
+	completeBingoCells(mockDB, 1, 3, 4)
+	if err := syncBingoBonuses(); err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+	entries = getBingoPointsEntries(mockDB)
+	if len(entries) != 3 || entries[2].UserID != 1 || entries[2].Points != 100 || entries[2].Reason != BingoPointsReason {
+		t.Errorf("expected 100 more points for user 1 but got %v", entries)
+	}
+}
+
+func TestDeleteBingoBoardWithdrawsBonuses(t *testing.T) {
+	mockDB := setupBingoBoard(t, false)
+	completeBingoCells(mockDB, 1, 1, 2, 3, 4)
+	if err := syncBingoBonuses(); err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	if err := DeleteBingoBoard(); err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	entries := getBingoPointsEntries(mockDB)
+	if len(entries) != 2 || entries[1].Points != -110 || entries[1].Reason != BingoReversalPointsReason {
+		t.Errorf("expected the full card bonus to be withdrawn but got %v", entries)
+	}
+}
+
+func TestCompleteChallengeWhenBingoSyncFails(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.BingoError = errors.New("test_error")
+	challenge := createScoringChallenge(types.ScoringRules{})
+
+	submission, err := CompleteChallenge(1, challenge, "answer")
+	if err != nil {
+		t.Errorf("expected the saved submission to be returned but got %s", err.Error())
+		return
+	}
+	if submission.Points != 100 || !submission.IsApproved() {
+		t.Errorf("expected an approved submission worth 100 but got %v", submission)
+	}
+}
+
+func TestCheckNotOnBingoBoard(t *testing.T) {
+	setupBingoBoard(t, false)
+
+	err := Challenge{ID: 2}.CheckNotOnBingoBoard()
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+
+	if err := (Challenge{ID: 5}).CheckNotOnBingoBoard(); err != nil {
+		t.Errorf("expected nil for a challenge not on the board but got %s", err.Error())
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+type ChallengeHint struct {
+	gorm.Model
+	ChallengeID uint   `gorm:"not null;index"`
+	Position    uint   `gorm:"not null"`
+	Text        string `gorm:"not null"`
+	Cost        uint   `gorm:"not null;default:0"`
+	Challenge   Challenge
+}
+
+// HintReveal records that a user revealed a hint, its cost is deducted from the points awarded for the challenge
+type HintReveal struct {
+	gorm.Model
+	UserID      uint `gorm:"not null;uniqueIndex:idx_user_hint"`
+	HintID      uint `gorm:"not null;uniqueIndex:idx_user_hint"`
+	ChallengeID uint `gorm:"not null;index"`
+	User        User
+	Hint        ChallengeHint
+}
+
+func NewChallengeHint(challengeId uint, position uint, text string, cost uint) ChallengeHint {
+	return ChallengeHint{
+		ChallengeID: challengeId,
+		Position:    position,
+		Text:        text,
+		Cost:        cost,
+	}
+}
+
+func NewHintReveal(userId uint, hint ChallengeHint) HintReveal {
+	return HintReveal{
+		UserID:      userId,
+		HintID:      hint.ID,
+		ChallengeID: hint.ChallengeID,
+	}
+}
+
+func (reveal HintReveal) Save() (HintReveal, error) {
+	conn := GetConnection()
+	if err := conn.Create(&reveal).GetError(); err != nil {
+		return HintReveal{}, err
+	}
+	return reveal, nil
+}
+
+// GetHintsForChallenge returns the hints of the challenge in the order they are revealed
+func GetHintsForChallenge(challengeId uint) ([]ChallengeHint, error) {
+	conn := GetConnection()
+	return conn.GetHintsForChallenge(challengeId)
+}
+
+// GetRevealedHintIds returns the ids of all hints the user has revealed
+func GetRevealedHintIds(userId uint) (map[uint]bool, error) {
+	conn := GetConnection()
+	reveals, err := conn.GetHintRevealsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	revealed := make(map[uint]bool, len(reveals))
+	for _, reveal := range reveals {
+		revealed[reveal.HintID] = true
+	}
+	return revealed, nil
+}
+
+// SplitHints separates the hints already revealed by the user from the number of hints still hidden
+func SplitHints(hints []ChallengeHint, revealed map[uint]bool) ([]ChallengeHint, int) {
+	revealedHints := make([]ChallengeHint, 0, len(hints))
+	for _, hint := range hints {
+		if revealed[hint.ID] {
+			revealedHints = append(revealedHints, hint)
+		}
+	}
+	return revealedHints, len(hints) - len(revealedHints)
+}
+
+// RevealNextHint reveals the first hint the user has not seen yet and returns it with the number of hints left
+func (challenge Challenge) RevealNextHint(userId uint) (ChallengeHint, int, error) {
+	hints, err := GetHintsForChallenge(challenge.ID)
+	if err != nil {
+		return ChallengeHint{}, 0, err
+	}
+
+	revealed, err := GetRevealedHintIds(userId)
+	if err != nil {
+		return ChallengeHint{}, 0, err
+	}
+
+	for i, hint := range hints {
+		if revealed[hint.ID] {
+			continue
+		}
+
+		if _, err := NewHintReveal(userId, hint).Save(); err != nil {
+			return ChallengeHint{}, 0, err
+		}
+
+		_, remaining := SplitHints(hints[i+1:], revealed)
+		return hint, remaining, nil
+	}
+
+	return ChallengeHint{}, 0, apperrors.NewValidationError(constants.NoHintsLeftError)
+}
+
+// CheckHintsRevealable returns a validation error once the user completed the challenge, as hints no longer cost anything
+func CheckHintsRevealable(userId uint, challengeId uint) error {
+	completed, err := IsChallengeCompleted(userId, challengeId)
+	if err != nil {
+		return err
+	}
+	if completed {
+		return apperrors.NewValidationError(constants.HintsForCompletedChallengeError)
+	}
+	return nil
+}
+
+// getHintCostForUser returns the total cost of the hints the user revealed for the challenge
+func getHintCostForUser(userId uint, challengeId uint) (uint, error) {
+	hints, err := GetHintsForChallenge(challengeId)
+	if err != nil {
+		return 0, err
+	}
+
+	revealed, err := GetRevealedHintIds(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	revealedHints, _ := SplitHints(hints, revealed)
+	var cost uint
+	for _, hint := range revealedHints {
+		cost += hint.Cost
+	}
+	return cost, nil
+}
+
+func saveHintsForChallenge(challengeId uint, hints []types.ChallengeHintInput) error {
+	conn := GetConnection()
+	challengeHints := make([]ChallengeHint, len(hints))
+	for i, hint := range hints {
+		challengeHints[i] = NewChallengeHint(challengeId, uint(i+1), hint.Text, hint.Cost)
+	}
+
+	if err := conn.ReplaceHintsForChallenge(challengeId, challengeHints); err != nil {
+		return fmt.Errorf("error while saving hints for challenge: %w", err)
+	}
+	return nil
+}
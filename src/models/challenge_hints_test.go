@@ -0,0 +1,167 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+var (
+	testHint1 = ChallengeHint{Model: gorm.Model{ID: 1}, ChallengeID: 123, Position: 1, Text: "it is tall", Cost: 2}
+	testHint2 = ChallengeHint{Model: gorm.Model{ID: 2}, ChallengeID: 123, Position: 2, Text: "it is in Paris", Cost: 5}
+)
+
+func createTestHint(hint ChallengeHint) {
+	database := GetConnection()
+	database.Create(&hint)
+}
+
+func TestNewChallengeHint(t *testing.T) {
+	hint := NewChallengeHint(123, 1, "it is tall", 2)
+	if hint.ChallengeID != 123 || hint.Position != 1 || hint.Text != "it is tall" || hint.Cost != 2 {
+		t.Errorf("expected hint for challenge 123 but got %v", hint)
+	}
+}
+
+func TestRevealNextHint(t *testing.T) {
+	SetupMockDb()
+	createTestHint(testHint1)
+	createTestHint(testHint2)
+
+	hint, remaining, err := testChallenge123.RevealNextHint(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if hint.ID != testHint1.ID || remaining != 1 {
+		t.Errorf("expected hint 1 with 1 remaining but got hint %d with %d remaining", hint.ID, remaining)
+	}
+
+	hint, remaining, err = testChallenge123.RevealNextHint(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if hint.ID != testHint2.ID || remaining != 0 {
+		t.Errorf("expected hint 2 with 0 remaining but got hint %d with %d remaining", hint.ID, remaining)
+	}
+}
+
+func TestRevealNextHintPerUser(t *testing.T) {
+	SetupMockDb()
+	createTestHint(testHint1)
+	createTestHint(testHint2)
+
+	_, _, _ = testChallenge123.RevealNextHint(1)
+
+	hint, _, err := testChallenge123.RevealNextHint(2)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if hint.ID != testHint1.ID {
+		t.Errorf("expected another user to start with hint 1 but got %d", hint.ID)
+	}
+}
+
+func TestRevealNextHintNoHintsLeft(t *testing.T) {
+	SetupMockDb()
+	createTestHint(testHint1)
+	_, _, _ = testChallenge123.RevealNextHint(1)
+
+	_, _, err := testChallenge123.RevealNextHint(1)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "no hints left for this challenge" {
+		t.Errorf("expected no hints left for this challenge but got %s", err.Error())
+	}
+}
+
+func TestRevealNextHintError(t *testing.T) {
+	mockDb := SetupMockDb()
+	mockDb.Error = errors.New("test_error")
+
+	_, _, err := testChallenge123.RevealNextHint(1)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsDatabaseError(err) {
+		t.Errorf("expected database error but got %s", err.Error())
+	}
+}
+
+func TestSplitHints(t *testing.T) {
+	revealed, remaining := SplitHints([]ChallengeHint{testHint1, testHint2}, map[uint]bool{testHint1.ID: true})
+	if len(revealed) != 1 || revealed[0].ID != testHint1.ID {
+		t.Errorf("expected hint 1 to be revealed but got %v", revealed)
+	}
+	if remaining != 1 {
+		t.Errorf("expected 1 remaining but got %d", remaining)
+	}
+}
+
+func TestCreateNewChallengeWithHints(t *testing.T) {
+	SetupMockDb()
+
+	createChallengeRequest := types.CreateChallengeRequest{
+		Name:        testChallenge1.Name,
+		Description: testChallenge1.Description,
+		Points:      testChallenge1.Points,
+		Image:       testChallenge1.Image,
+		Type:        testChallenge1.Type,
+		Hints: []types.ChallengeHintInput{
+			{Text: "look up", Cost: 1},
+			{Text: "look left", Cost: 3},
+		},
+	}
+
+	challenge, err := CreateNewChallenge(createChallengeRequest)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	hints, err := GetHintsForChallenge(challenge.ID)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(hints) != 2 {
+		t.Errorf("expected 2 but got %d", len(hints))
+		return
+	}
+	if hints[0].Position != 1 || hints[0].Text != "look up" || hints[1].Position != 2 || hints[1].Cost != 3 {
+		t.Errorf("expected hints in request order but got %v", hints)
+	}
+}
+
+func TestUpdateChallengeHintsAfterReveal(t *testing.T) {
+	SetupMockDb()
+	createTestHint(testHint1)
+	_, _, _ = testChallenge123.RevealNextHint(1)
+
+	_, err := testChallenge123.Update(types.UpdateChallengeRequest{
+		Name:  testChallenge123.Name,
+		Type:  testChallenge123.Type,
+		Hints: []types.ChallengeHintInput{{Text: "new hint"}},
+	})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "Cannot update hints once they have been revealed" {
+		t.Errorf("expected Cannot update hints once they have been revealed but got %s", err.Error())
+	}
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+)
+
+// ChallengeItem is one of the items of an ordering challenge, the position is its place in the correct order
+type ChallengeItem struct {
+	gorm.Model
+	ChallengeID uint   `gorm:"not null;index"`
+	Value       string `gorm:"not null"`
+	Position    uint   `gorm:"not null"`
+	Challenge   Challenge
+}
+
+func NewChallengeItem(challengeId uint, value string, position uint) ChallengeItem {
+	return ChallengeItem{
+		ChallengeID: challengeId,
+		Value:       value,
+		Position:    position,
+	}
+}
+
+func (item ChallengeItem) Save() (ChallengeItem, error) {
+	conn := GetConnection()
+	if err := conn.Create(&item).GetError(); err != nil {
+		return ChallengeItem{}, err
+	}
+	return item, nil
+}
+
+// GetItemsForChallenge returns the items of an ordering challenge in the correct order
+func GetItemsForChallenge(challengeId uint) ([]ChallengeItem, error) {
+	conn := GetConnection()
+	return conn.GetItemsForChallenge(challengeId)
+}
+
+func DeleteItemsForChallenge(challengeId uint) error {
+	conn := GetConnection()
+	return conn.DeleteItemsForChallenge(challengeId)
+}
+
+// saveItemsForChallenge saves the items in the given order, which is the correct order
+func saveItemsForChallenge(challengeId uint, items []types.ChallengeItemInput) error {
+	for i, item := range items {
+		challengeItem := NewChallengeItem(challengeId, item.Value, uint(i+1))
+		if _, err := challengeItem.Save(); err != nil {
+			return fmt.Errorf("error while creating item for challenge: %w", err)
+		}
+	}
+	return nil
+}
+
+func replaceItemsForChallenge(challengeId uint, items []types.ChallengeItemInput) error {
+	if err := DeleteItemsForChallenge(challengeId); err != nil {
+		return fmt.Errorf("error while deleting items for challenge: %w", err)
+	}
+	return saveItemsForChallenge(challengeId, items)
+}
+
+// ShuffleItems returns the items in an order that is random but always the same for the user,
+// so the list does not jump around when the player reloads the challenge
+func ShuffleItems(items []ChallengeItem, userId uint, challengeId uint) []ChallengeItem {
+	return shuffleForUser(items, userId, challengeId)
+}
+
+// getOrderingCredit returns how many items the answer, a comma separated list of item ids, puts in their correct place.
+// The answer must list every item of the challenge exactly once.
+func getOrderingCredit(challengeId uint, answer string) (uint, uint, error) {
+	orderedIds, err := utils.ParseIdList(answer)
+	if err != nil {
+		return 0, 0, apperrors.NewValidationError(constants.InvalidItemOrderError)
+	}
+
+	items, err := GetItemsForChallenge(challengeId)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(items) != len(orderedIds) {
+		return 0, 0, apperrors.NewValidationError(constants.InvalidItemOrderError)
+	}
+
+	positions := make(map[uint]uint, len(items))
+	for _, item := range items {
+		positions[item.ID] = item.Position
+	}
+
+	var correct uint
+	seen := make(map[uint]bool, len(orderedIds))
+	for i, id := range orderedIds {
+		position, exists := positions[id]
+		if !exists || seen[id] {
+			return 0, 0, apperrors.NewValidationError(constants.InvalidItemOrderError)
+		}
+		seen[id] = true
+		if position == uint(i+1) {
+			correct++
+		}
+	}
+
+	return correct, uint(len(items)), nil
+}
+
+// verifyAnswerForOrdering accepts the exact order, or with partial credit any order with at least one item in place
+func verifyAnswerForOrdering(challenge Challenge, answer string) (bool, error) {
+	correct, total, err := getOrderingCredit(challenge.ID, answer)
+	if err != nil {
+		return false, err
+	}
+
+	if challenge.OrderingPartialCredit {
+		return correct > 0, nil
+	}
+	return correct == total, nil
+}
+
+// orderingPoints scales the points of a partial credit ordering challenge by the share of items in their correct place
+func (challenge Challenge) orderingPoints(points uint, answer string) (uint, error) {
+	if challenge.Type != types.OrderingChallenge || !challenge.OrderingPartialCredit {
+		return points, nil
+	}
+
+	correct, total, err := getOrderingCredit(challenge.ID, answer)
+	if err != nil {
+		return 0, err
+	}
+	return points * correct / total, nil
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"slices"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+var testOrderingChallenge = Challenge{ID: 99, Name: "milestones", Description: "Put the milestones in order", Points: 40,
+	Type: types.OrderingChallenge, Status: types.ActiveChallenge}
+
+func setupOrderingItems() {
+	database := GetConnection()
+	for i, value := range []string{"first date", "moved in", "engagement", "wedding"} {
+		item := NewChallengeItem(testOrderingChallenge.ID, value, uint(i+1))
+		item.Model = gorm.Model{ID: uint(10 + i)}
+		database.Create(&item)
+	}
+}
+
+func TestShuffleItemsIsDeterministicPerUser(t *testing.T) {
+	items := make([]ChallengeItem, 8)
+	for i := range items {
+		items[i] = NewChallengeItem(testOrderingChallenge.ID, "item", uint(i+1))
+		items[i].ID = uint(i + 1)
+	}
+
+	first := ShuffleItems(items, 1, testOrderingChallenge.ID)
+	again := ShuffleItems(items, 1, testOrderingChallenge.ID)
+	other := ShuffleItems(items, 2, testOrderingChallenge.ID)
+
+	if !slices.Equal(first, again) {
+		t.Errorf("expected the same order for the same user")
+	}
+	if slices.Equal(first, other) {
+		t.Errorf("expected another order for another user")
+	}
+
+	sorted := slices.Clone(first)
+	slices.SortFunc(sorted, func(a, b ChallengeItem) int { return int(a.ID) - int(b.ID) })
+	if !slices.Equal(sorted, items) {
+		t.Errorf("expected the shuffled items to contain every item once")
+	}
+}
+
+func TestVerifyAnswerForOrderingAllOrNothing(t *testing.T) {
+	SetupMockDb()
+	setupOrderingItems()
+
+	correct, err := verifyAnswerForOrdering(testOrderingChallenge, "10,11,12,13")
+	if err != nil || !correct {
+		t.Errorf("expected the correct order to be accepted but got %v %v", correct, err)
+	}
+
+	correct, err = verifyAnswerForOrdering(testOrderingChallenge, "10,11,13,12")
+	if err != nil || correct {
+		t.Errorf("expected a wrong order to be rejected but got %v %v", correct, err)
+	}
+}
+
+func TestVerifyAnswerForOrderingPartialCredit(t *testing.T) {
+	SetupMockDb()
+	setupOrderingItems()
+
+	challenge := testOrderingChallenge
+	challenge.OrderingPartialCredit = true
+
+	correct, err := verifyAnswerForOrdering(challenge, "10,11,13,12")
+	if err != nil || !correct {
+		t.Errorf("expected a partly correct order to be accepted but got %v %v", correct, err)
+	}
+
+	correct, err = verifyAnswerForOrdering(challenge, "13,12,11,10")
+	if err != nil || correct {
+		t.Errorf("expected an order without any item in place to be rejected but got %v %v", correct, err)
+	}
+}
+
+func TestVerifyAnswerForOrderingInvalidOrder(t *testing.T) {
+	SetupMockDb()
+	setupOrderingItems()
+
+	for _, answer := range []string{"10,11,12", "10,11,12,12", "10,11,12,99", "first date"} {
+		_, err := verifyAnswerForOrdering(testOrderingChallenge, answer)
+		if err == nil {
+			t.Errorf("expected error for %s but got nil", answer)
+			continue
+		}
+		if !apperrors.IsValidationError(err) || err.Error() != "answer must list the ids of all items exactly once" {
+			t.Errorf("expected invalid item order error for %s but got %s", answer, err.Error())
+		}
+	}
+}
+
+func TestCompleteOrderingChallengeWithPartialCredit(t *testing.T) {
+	SetupMockDb()
+	setupOrderingItems()
+
+	challenge := testOrderingChallenge
+	challenge.OrderingPartialCredit = true
+
+	submission, err := CompleteChallenge(1, challenge, "10,11,13,12")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if submission.Points != 20 {
+		t.Errorf("expected half of the points for two of four items in place but got %d", submission.Points)
+	}
+}
+
+func TestCompleteOrderingChallengeAllOrNothing(t *testing.T) {
+	SetupMockDb()
+	setupOrderingItems()
+
+	submission, err := CompleteChallenge(1, testOrderingChallenge, "10,11,12,13")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if submission.Points != 40 {
+		t.Errorf("expected all points but got %d", submission.Points)
+	}
+}
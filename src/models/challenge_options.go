@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"the-wedding-game-api/types"
+)
+
+type ChallengeOption struct {
+	gorm.Model
+	ChallengeID uint   `gorm:"not null;index"`
+	Value       string `gorm:"not null"`
+	Correct     bool   `gorm:"not null;default:false"`
+	Challenge   Challenge
+}
+
+func NewChallengeOption(challengeId uint, value string, correct bool) ChallengeOption {
+	return ChallengeOption{
+		ChallengeID: challengeId,
+		Value:       value,
+		Correct:     correct,
+	}
+}
+
+func (option ChallengeOption) Save() (ChallengeOption, error) {
+	conn := GetConnection()
+	if err := conn.Create(&option).GetError(); err != nil {
+		return ChallengeOption{}, err
+	}
+	return option, nil
+}
+
+func GetOptionsForChallenge(challengeId uint) ([]ChallengeOption, error) {
+	conn := GetConnection()
+	return conn.GetOptionsForChallenge(challengeId)
+}
+
+func DeleteOptionsForChallenge(challengeId uint) error {
+	conn := GetConnection()
+	return conn.DeleteOptionsForChallenge(challengeId)
+}
+
+func saveOptionsForChallenge(challengeId uint, options []types.ChallengeOptionInput) error {
+	for _, option := range options {
+		challengeOption := NewChallengeOption(challengeId, option.Value, option.Correct)
+		if _, err := challengeOption.Save(); err != nil {
+			return fmt.Errorf("error while creating option for challenge: %w", err)
+		}
+	}
+	return nil
+}
+
+func replaceOptionsForChallenge(challengeId uint, options []types.ChallengeOptionInput) error {
+	if err := DeleteOptionsForChallenge(challengeId); err != nil {
+		return fmt.Errorf("error while deleting options for challenge: %w", err)
+	}
+	return saveOptionsForChallenge(challengeId, options)
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+var (
+	testChallenge555 = Challenge{ID: 555, Name: "name", Description: "description", Points: 10, Type: types.MultipleChoiceChallenge, Status: types.ActiveChallenge}
+	testOption1      = ChallengeOption{Model: gorm.Model{ID: 1}, ChallengeID: 555, Value: "Paris", Correct: true}
+	testOption2      = ChallengeOption{Model: gorm.Model{ID: 2}, ChallengeID: 555, Value: "London", Correct: false}
+	testOption3      = ChallengeOption{Model: gorm.Model{ID: 3}, ChallengeID: 555, Value: "Lyon", Correct: true}
+)
+
+func createTestOption(option ChallengeOption) {
+	database := GetConnection()
+	database.Create(&option)
+}
+
+func TestNewChallengeOption(t *testing.T) {
+	option := NewChallengeOption(testOption1.ChallengeID, testOption1.Value, testOption1.Correct)
+	if option.ChallengeID != testOption1.ChallengeID {
+		t.Errorf("expected %d but got %d", testOption1.ChallengeID, option.ChallengeID)
+	}
+	if option.Value != testOption1.Value {
+		t.Errorf("expected %s but got %s", testOption1.Value, option.Value)
+	}
+	if option.Correct != testOption1.Correct {
+		t.Errorf("expected %t but got %t", testOption1.Correct, option.Correct)
+	}
+}
+
+func TestGetOptionsForChallenge(t *testing.T) {
+	SetupMockDb()
+	createTestOption(testOption1)
+	createTestOption(testOption2)
+	createTestOption(ChallengeOption{ChallengeID: 999, Value: "other"})
+
+	options, err := GetOptionsForChallenge(555)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(options) != 2 {
+		t.Errorf("expected 2 but got %d", len(options))
+		return
+	}
+	if options[0].Value != testOption1.Value || options[1].Value != testOption2.Value {
+		t.Errorf("expected options in insertion order but got %v", options)
+	}
+}
+
+func TestGetOptionsForChallengeError(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.Error = errors.New("test_error")
+
+	_, err := GetOptionsForChallenge(555)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsDatabaseError(err) {
+		t.Errorf("expected database error but got %s", err.Error())
+	}
+}
+
+func TestReplaceOptionsForChallenge(t *testing.T) {
+	SetupMockDb()
+	createTestOption(testOption1)
+	createTestOption(testOption2)
+
+	err := replaceOptionsForChallenge(555, []types.ChallengeOptionInput{
+		{Value: "Rome", Correct: true},
+		{Value: "Milan"},
+		{Value: "Turin"},
+	})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	options, err := GetOptionsForChallenge(555)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(options) != 3 {
+		t.Errorf("expected 3 but got %d", len(options))
+		return
+	}
+	if options[0].Value != "Rome" || !options[0].Correct {
+		t.Errorf("expected Rome to be the correct option but got %v", options[0])
+	}
+}
+
+func TestVerifyAnswerMultipleChoice(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(testChallenge555)
+	createTestOption(testOption1)
+	createTestOption(testOption2)
+	createTestOption(testOption3)
+
+	isCorrect, err := VerifyAnswer(testChallenge555.ID, "3,1")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if !isCorrect {
+		t.Errorf("expected true but got false")
+	}
+}
+
+func TestVerifyAnswerMultipleChoiceIncorrect(t *testing.T) {
+	answers := []string{"1", "2", "1,2,3", "1,3,4", "2,3"}
+	for _, answer := range answers {
+		SetupMockDb()
+		createTestChallenge(testChallenge555)
+		createTestOption(testOption1)
+		createTestOption(testOption2)
+		createTestOption(testOption3)
+
+		isCorrect, err := VerifyAnswer(testChallenge555.ID, answer)
+		if err != nil {
+			t.Errorf("expected nil but got %s", err.Error())
+			return
+		}
+
+		if isCorrect {
+			t.Errorf("expected false for %s but got true", answer)
+		}
+	}
+}
+
+func TestVerifyAnswerMultipleChoiceInvalidSelection(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(testChallenge555)
+	createTestOption(testOption1)
+
+	_, err := VerifyAnswer(testChallenge555.ID, "Paris")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "invalid option selection" {
+		t.Errorf("expected invalid option selection but got %s", err.Error())
+	}
+}
+
+func TestVerifyAnswerMultipleChoiceNoOptions(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(testChallenge555)
+
+	_, err := VerifyAnswer(testChallenge555.ID, "1")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsNotFoundError(err) {
+		t.Errorf("expected not found error but got %s", err.Error())
+	}
+}
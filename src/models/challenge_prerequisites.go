@@ -0,0 +1,156 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"slices"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+)
+
+// ChallengePrerequisite states that the challenge is locked until the prerequisite challenge is completed
+type ChallengePrerequisite struct {
+	gorm.Model
+	ChallengeID    uint      `gorm:"not null;uniqueIndex:idx_challenge_prerequisite"`
+	PrerequisiteID uint      `gorm:"not null;uniqueIndex:idx_challenge_prerequisite"`
+	Challenge      Challenge `gorm:"foreignKey:ChallengeID"`
+	Prerequisite   Challenge `gorm:"foreignKey:PrerequisiteID"`
+}
+
+func GetAllPrerequisites() ([]ChallengePrerequisite, error) {
+	conn := GetConnection()
+	return conn.GetAllPrerequisites()
+}
+
+func GetPrerequisitesForChallenge(challengeId uint) ([]uint, error) {
+	prerequisites, err := GetAllPrerequisites()
+	if err != nil {
+		return nil, err
+	}
+	return getPrerequisiteGraph(prerequisites)[challengeId], nil
+}
+
+// GetUnmetPrerequisites returns, for every locked challenge, the prerequisites not covered by the completed submissions
+func GetUnmetPrerequisites(completed []Submission) (map[uint][]uint, error) {
+	prerequisites, err := GetAllPrerequisites()
+	if err != nil {
+		return nil, err
+	}
+
+	unmet := make(map[uint][]uint)
+	for _, prerequisite := range prerequisites {
+		if !IsChallengeInSubmissions(prerequisite.PrerequisiteID, completed) {
+			unmet[prerequisite.ChallengeID] = append(unmet[prerequisite.ChallengeID], prerequisite.PrerequisiteID)
+		}
+	}
+	return unmet, nil
+}
+
+// CheckChallengeUnlocked returns a validation error if the user has not completed all prerequisites of the challenge
+func CheckChallengeUnlocked(challengeId uint, userId uint) error {
+	completed, err := GetCompletedChallenges(userId)
+	if err != nil {
+		return err
+	}
+
+	unmet, err := GetUnmetPrerequisites(completed)
+	if err != nil {
+		return err
+	}
+
+	if len(unmet[challengeId]) > 0 {
+		return apperrors.NewValidationError(constants.ChallengeLockedError)
+	}
+	return nil
+}
+
+// GetChallengeNames returns the names of the given challenges by id
+func GetChallengeNames(challengeIds []uint) (map[uint]string, error) {
+	conn := GetConnection()
+	challenges, err := conn.GetChallengesByIds(challengeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(challenges))
+	for _, challenge := range challenges {
+		names[challenge.ID] = challenge.Name
+	}
+	return names, nil
+}
+
+// validatePrerequisites ensures the prerequisites exist and that making them prerequisites of the challenge
+// would not create a cycle, in which case none of the challenges involved could ever be unlocked
+func validatePrerequisites(challengeId uint, prerequisiteIds []uint) error {
+	if len(prerequisiteIds) == 0 {
+		return nil
+	}
+
+	conn := GetConnection()
+	challenges, err := conn.GetChallengesByIds(prerequisiteIds)
+	if err != nil {
+		return err
+	}
+	if len(challenges) != len(uniqueIds(prerequisiteIds)) {
+		return apperrors.NewValidationError(constants.InvalidPrerequisiteError)
+	}
+
+	if slices.Contains(prerequisiteIds, challengeId) {
+		return apperrors.NewValidationError(constants.PrerequisiteCycleError)
+	}
+
+	prerequisites, err := GetAllPrerequisites()
+	if err != nil {
+		return err
+	}
+
+	graph := getPrerequisiteGraph(prerequisites)
+	graph[challengeId] = prerequisiteIds
+	if dependsOn(graph, prerequisiteIds, challengeId) {
+		return apperrors.NewValidationError(constants.PrerequisiteCycleError)
+	}
+
+	return nil
+}
+
+func replacePrerequisitesForChallenge(challengeId uint, prerequisiteIds []uint) error {
+	conn := GetConnection()
+	return conn.ReplacePrerequisitesForChallenge(challengeId, uniqueIds(prerequisiteIds))
+}
+
+func getPrerequisiteGraph(prerequisites []ChallengePrerequisite) map[uint][]uint {
+	graph := make(map[uint][]uint)
+	for _, prerequisite := range prerequisites {
+		graph[prerequisite.ChallengeID] = append(graph[prerequisite.ChallengeID], prerequisite.PrerequisiteID)
+	}
+	return graph
+}
+
+// dependsOn reports whether the target can be reached from any of the start challenges by following prerequisites
+func dependsOn(graph map[uint][]uint, start []uint, target uint) bool {
+	visited := make(map[uint]bool)
+	stack := slices.Clone(start)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == target {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		stack = append(stack, graph[current]...)
+	}
+	return false
+}
+
+func uniqueIds(ids []uint) []uint {
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
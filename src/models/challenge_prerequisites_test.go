@@ -0,0 +1,227 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func createTestPrerequisite(challengeId uint, prerequisiteId uint) {
+	database := GetConnection()
+	database.Create(&ChallengePrerequisite{ChallengeID: challengeId, PrerequisiteID: prerequisiteId})
+}
+
+func TestGetUnmetPrerequisites(t *testing.T) {
+	SetupMockDb()
+	createTestPrerequisite(2, 1)
+	createTestPrerequisite(3, 1)
+	createTestPrerequisite(3, 2)
+
+	unmet, err := GetUnmetPrerequisites([]Submission{{ChallengeID: 1}})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(unmet[2]) != 0 {
+		t.Errorf("expected challenge 2 to be unlocked but got %v", unmet[2])
+	}
+	if len(unmet[3]) != 1 || unmet[3][0] != 2 {
+		t.Errorf("expected challenge 3 to wait for challenge 2 but got %v", unmet[3])
+	}
+}
+
+func TestGetUnmetPrerequisitesError(t *testing.T) {
+	mockDb := SetupMockDb()
+	mockDb.Error = errors.New("test_error")
+
+	_, err := GetUnmetPrerequisites(nil)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsDatabaseError(err) {
+		t.Errorf("expected database error but got %s", err.Error())
+	}
+}
+
+func TestGetPrerequisitesForChallenge(t *testing.T) {
+	SetupMockDb()
+	createTestPrerequisite(3, 1)
+	createTestPrerequisite(3, 2)
+	createTestPrerequisite(4, 3)
+
+	prerequisites, err := GetPrerequisitesForChallenge(3)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(prerequisites) != 2 || prerequisites[0] != 1 || prerequisites[1] != 2 {
+		t.Errorf("expected [1 2] but got %v", prerequisites)
+	}
+}
+
+func TestGetChallengeNames(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 1, Name: "first dance"})
+	createTestChallenge(Challenge{ID: 2, Name: "cake cutting"})
+
+	names, err := GetChallengeNames([]uint{2})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(names) != 1 || names[2] != "cake cutting" {
+		t.Errorf("expected cake cutting but got %v", names)
+	}
+}
+
+func TestValidatePrerequisites(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 1})
+	createTestChallenge(Challenge{ID: 2})
+	createTestPrerequisite(2, 1)
+
+	if err := validatePrerequisites(3, []uint{1, 2}); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestValidatePrerequisitesUnknownChallenge(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 1})
+
+	err := validatePrerequisites(3, []uint{1, 42})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "prerequisites must reference existing challenges" {
+		t.Errorf("expected prerequisites must reference existing challenges but got %s", err.Error())
+	}
+}
+
+func TestValidatePrerequisitesSelf(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 1})
+
+	err := validatePrerequisites(1, []uint{1})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "prerequisites cannot form a cycle" {
+		t.Errorf("expected prerequisites cannot form a cycle but got %s", err.Error())
+	}
+}
+
+func TestValidatePrerequisitesCycle(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 1})
+	createTestChallenge(Challenge{ID: 2})
+	createTestChallenge(Challenge{ID: 3})
+	createTestPrerequisite(2, 1)
+	createTestPrerequisite(3, 2)
+
+	err := validatePrerequisites(1, []uint{3})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+	if err.Error() != "prerequisites cannot form a cycle" {
+		t.Errorf("expected prerequisites cannot form a cycle but got %s", err.Error())
+	}
+}
+
+func TestValidatePrerequisitesReverseEdge(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 1})
+	createTestChallenge(Challenge{ID: 2})
+	createTestPrerequisite(1, 2)
+
+	// Challenge 1 already requires challenge 2, so challenge 2 cannot require challenge 1
+	if err := validatePrerequisites(2, []uint{1}); err == nil {
+		t.Errorf("expected cycle error but got nil")
+	}
+
+	if err := validatePrerequisites(1, []uint{}); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestCreateNewChallengeWithPrerequisites(t *testing.T) {
+	mockDb := SetupMockDb()
+	createTestChallenge(Challenge{ID: 1})
+
+	createChallengeRequest := types.CreateChallengeRequest{
+		Name:          "second",
+		Description:   "description",
+		Points:        10,
+		Image:         "image",
+		Type:          types.UploadPhotoChallenge,
+		Prerequisites: []uint{1, 1},
+	}
+
+	challenge, err := CreateNewChallenge(createChallengeRequest)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	prerequisites, _ := mockDb.GetAllPrerequisites()
+	if len(prerequisites) != 1 || prerequisites[0].ChallengeID != challenge.ID || prerequisites[0].PrerequisiteID != 1 {
+		t.Errorf("expected a single prerequisite on challenge 1 but got %v", prerequisites)
+	}
+}
+
+func TestUpdateChallengePrerequisitesCycle(t *testing.T) {
+	SetupMockDb()
+	createTestChallenge(Challenge{ID: 2})
+	createTestPrerequisite(2, 1)
+
+	challenge := Challenge{ID: 1, Name: "name", Type: types.UploadPhotoChallenge}
+	createTestChallenge(challenge)
+
+	_, err := challenge.Update(types.UpdateChallengeRequest{
+		Name:          "name",
+		Type:          types.UploadPhotoChallenge,
+		Prerequisites: []uint{2},
+	})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "prerequisites cannot form a cycle" {
+		t.Errorf("expected prerequisites cannot form a cycle but got %s", err.Error())
+	}
+}
+
+func TestDeleteChallengeRemovesPrerequisites(t *testing.T) {
+	mockDb := SetupMockDb()
+	createTestPrerequisite(2, 1)
+	createTestPrerequisite(3, 2)
+
+	if err := (Challenge{ID: 2, Type: types.UploadPhotoChallenge}).Delete(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	prerequisites, _ := mockDb.GetAllPrerequisites()
+	if len(prerequisites) != 0 {
+		t.Errorf("expected no prerequisites but got %v", prerequisites)
+	}
+}
@@ -3,9 +3,14 @@ package models
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"slices"
 	"strconv"
+	"the-wedding-game-api/config"
+	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+	"time"
 )
 
 type Challenge struct {
@@ -17,6 +22,40 @@ type Challenge struct {
 	Image       string                `gorm:"not null"`
 	Type        types.ChallengeType   `gorm:"not null"`
 	Status      types.ChallengeStatus `gorm:"default:'ACTIVE'"`
+
+	AnswerMatchMode        types.AnswerMatchMode `gorm:"default:'NORMALIZED'"`
+	AnswerMaxDistance      uint                  `gorm:"not null;default:0"`
+	AnswerNumericTolerance float64               `gorm:"not null;default:0"`
+
+	AvailableFrom  *time.Time
+	AvailableUntil *time.Time
+
+	MaxAttempts uint `gorm:"not null;default:0"`
+
+	FirstSolverBonus     uint              `gorm:"not null;default:0"`
+	FirstSolverCount     uint              `gorm:"not null;default:0"`
+	PointsDecay          types.PointsDecay `gorm:"default:'NONE'"`
+	DecayPoints          uint              `gorm:"not null;default:0"`
+	DecayIntervalMinutes uint              `gorm:"not null;default:0"`
+	MinimumPoints        uint              `gorm:"not null;default:0"`
+
+	TeamChallenge bool `gorm:"not null;default:false"`
+
+	SecretCode string `gorm:"not null;default:''"`
+
+	MaxDurationSeconds uint `gorm:"not null;default:0"`
+
+	PredictionMode      types.PredictionMode `gorm:"default:'EXACT'"`
+	PredictionClosest   uint                 `gorm:"not null;default:0"`
+	PredictionTolerance float64              `gorm:"not null;default:0"`
+	Outcome             string               `gorm:"not null;default:''"`
+	ResolvedAt          *time.Time
+
+	OrderingPartialCredit bool `gorm:"not null;default:false"`
+
+	CheckInLatitude  float64 `gorm:"not null;default:0"`
+	CheckInLongitude float64 `gorm:"not null;default:0"`
+	CheckInRadius    float64 `gorm:"not null;default:0"`
 }
 
 func NewChallenge(name string, description string, points uint, image string, challengeType types.ChallengeType,
@@ -41,19 +80,62 @@ func CreateNewChallenge(createChallengeRequest types.CreateChallengeRequest) (Ch
 		createChallengeRequest.Type,
 		types.ActiveChallenge,
 	)
+	challenge.setAnswerMatching(createChallengeRequest.AnswerMatching)
+	challenge.MaxAttempts = createChallengeRequest.MaxAttempts
+	challenge.setScoringRules(createChallengeRequest.Scoring)
+	challenge.TeamChallenge = createChallengeRequest.Team
+	challenge.MaxDurationSeconds = createChallengeRequest.MaxDuration
+	challenge.setPredictionRules(createChallengeRequest.Prediction)
+	challenge.OrderingPartialCredit = createChallengeRequest.PartialCredit
+	if createChallengeRequest.Location != nil {
+		challenge.setCheckInLocation(*createChallengeRequest.Location)
+	}
+	if challenge.Type == types.ScanCodeChallenge {
+		challenge.SecretCode = generateSecretCode()
+	}
+	if err := challenge.setAvailability(createChallengeRequest.AvailableFrom, createChallengeRequest.AvailableUntil); err != nil {
+		return Challenge{}, err
+	}
+
+	if err := validatePrerequisites(0, createChallengeRequest.Prerequisites); err != nil {
+		return Challenge{}, err
+	}
 
 	createdChallenge, err := challenge.Save()
 	if err != nil {
 		return Challenge{}, err
 	}
 
+	if len(createChallengeRequest.Prerequisites) > 0 {
+		if err := replacePrerequisitesForChallenge(createdChallenge.ID, createChallengeRequest.Prerequisites); err != nil {
+			return Challenge{}, fmt.Errorf("error while saving prerequisites for challenge: %w", err)
+		}
+	}
+
+	if len(createChallengeRequest.Hints) > 0 {
+		if err := saveHintsForChallenge(createdChallenge.ID, createChallengeRequest.Hints); err != nil {
+			return Challenge{}, err
+		}
+	}
+
 	if createdChallenge.Type == types.AnswerQuestionChallenge {
-		answer := NewAnswer(
-			createdChallenge.ID,
-			createChallengeRequest.Answer,
-		)
-		_, err := answer.Save()
-		if err != nil {
+		for _, value := range AcceptedAnswers(createChallengeRequest.Answer, createChallengeRequest.Answers) {
+			answer := NewAnswer(createdChallenge.ID, value)
+			_, err := answer.Save()
+			if err != nil {
+				return Challenge{}, err
+			}
+		}
+	}
+
+	if createdChallenge.Type == types.MultipleChoiceChallenge {
+		if err := saveOptionsForChallenge(createdChallenge.ID, createChallengeRequest.Options); err != nil {
+			return Challenge{}, err
+		}
+	}
+
+	if createdChallenge.Type == types.OrderingChallenge {
+		if err := saveItemsForChallenge(createdChallenge.ID, createChallengeRequest.Items); err != nil {
 			return Challenge{}, err
 		}
 	}
@@ -61,6 +143,114 @@ func CreateNewChallenge(createChallengeRequest types.CreateChallengeRequest) (Ch
 	return createdChallenge, nil
 }
 
+func (challenge *Challenge) setAnswerMatching(answerMatching types.AnswerMatching) {
+	challenge.AnswerMatchMode = answerMatching.Mode
+	challenge.AnswerMaxDistance = answerMatching.MaxDistance
+	challenge.AnswerNumericTolerance = answerMatching.NumericTolerance
+}
+
+func (challenge Challenge) GetAnswerMatching() types.AnswerMatching {
+	mode := challenge.AnswerMatchMode
+	if mode == "" {
+		mode = types.NormalizedAnswerMatch
+	}
+
+	return types.AnswerMatching{
+		Mode:             mode,
+		MaxDistance:      challenge.AnswerMaxDistance,
+		NumericTolerance: challenge.AnswerNumericTolerance,
+	}
+}
+
+// setAvailability sets the window in which the challenge can be played,
+// timestamps without an offset are interpreted in the event timezone
+func (challenge *Challenge) setAvailability(availableFrom string, availableUntil string) error {
+	location := config.GetEventLocation()
+
+	from, err := utils.ParseEventTime(availableFrom, location)
+	if err != nil {
+		return apperrors.NewValidationError(constants.InvalidAvailableFromError)
+	}
+
+	until, err := utils.ParseEventTime(availableUntil, location)
+	if err != nil {
+		return apperrors.NewValidationError(constants.InvalidAvailableUntilError)
+	}
+
+	challenge.AvailableFrom = from
+	challenge.AvailableUntil = until
+	return nil
+}
+
+// updateAvailability changes the ends of the window that are given and keeps the others,
+// an empty timestamp removes that end of the window
+func (challenge *Challenge) updateAvailability(availableFrom *string, availableUntil *string) error {
+	location := config.GetEventLocation()
+
+	if availableFrom != nil {
+		from, err := utils.ParseEventTime(*availableFrom, location)
+		if err != nil {
+			return apperrors.NewValidationError(constants.InvalidAvailableFromError)
+		}
+		challenge.AvailableFrom = from
+	}
+
+	if availableUntil != nil {
+		until, err := utils.ParseEventTime(*availableUntil, location)
+		if err != nil {
+			return apperrors.NewValidationError(constants.InvalidAvailableUntilError)
+		}
+		challenge.AvailableUntil = until
+	}
+
+	if challenge.AvailableFrom != nil && challenge.AvailableUntil != nil && !challenge.AvailableFrom.Before(*challenge.AvailableUntil) {
+		return apperrors.NewValidationError(constants.InvalidAvailabilityWindowError)
+	}
+	return nil
+}
+
+func (challenge Challenge) IsAvailableAt(moment time.Time) bool {
+	if challenge.AvailableFrom != nil && moment.Before(*challenge.AvailableFrom) {
+		return false
+	}
+	if challenge.AvailableUntil != nil && !moment.Before(*challenge.AvailableUntil) {
+		return false
+	}
+	return true
+}
+
+// CheckAvailability returns a validation error if the challenge cannot be played right now
+func (challenge Challenge) CheckAvailability() error {
+	now := time.Now()
+	if challenge.AvailableFrom != nil && now.Before(*challenge.AvailableFrom) {
+		return apperrors.NewValidationError(constants.ChallengeNotYetAvailableError)
+	}
+	if challenge.AvailableUntil != nil && !now.Before(*challenge.AvailableUntil) {
+		return apperrors.NewValidationError(constants.ChallengeNoLongerAvailableError)
+	}
+	return nil
+}
+
+// CheckVisibleTo hides challenges outside their availability window and the questions of quiz rounds from players,
+// users who manage challenges can always see them
+func (challenge Challenge) CheckVisibleTo(user User) error {
+	if user.HasPermission(types.ManageChallengesPermission) {
+		return nil
+	}
+	if !challenge.IsAvailableAt(time.Now()) {
+		return apperrors.NewNotFoundError("Challenge", strconv.Itoa(int(challenge.ID)))
+	}
+
+	quizChallengeIds, err := GetQuizChallengeIds()
+	if err != nil {
+		return err
+	}
+	if quizChallengeIds[challenge.ID] {
+		return apperrors.NewNotFoundError("Challenge", strconv.Itoa(int(challenge.ID)))
+	}
+	return nil
+}
+
 func (challenge Challenge) Save() (Challenge, error) {
 	conn := GetConnection()
 	if err := conn.Create(&challenge).GetError(); err != nil {
@@ -74,16 +264,46 @@ func (challenge Challenge) Update(updateChallengeRequest types.UpdateChallengeRe
 		return Challenge{}, err
 	}
 
+	// Keep the code of a scan code challenge, so printed QR codes stay valid when switching types back and forth
+	if updateChallengeRequest.Type == types.ScanCodeChallenge && challenge.SecretCode == "" {
+		challenge.SecretCode = generateSecretCode()
+	}
+
 	conn := GetConnection()
 	updatedChallenge, err := conn.UpdateChallenge(challenge, updateChallengeRequest)
 	if err != nil {
 		return Challenge{}, err
 	}
 
-	if err := updatedChallenge.updateUnderlyingAnswer(challenge.Type, updateChallengeRequest.Answer); err != nil {
+	answers := AcceptedAnswers(updateChallengeRequest.Answer, updateChallengeRequest.Answers)
+	if err := updatedChallenge.updateUnderlyingAnswer(challenge.Type, answers); err != nil {
+		return Challenge{}, err
+	}
+
+	if err := updatedChallenge.updateUnderlyingOptions(challenge.Type, updateChallengeRequest.Options); err != nil {
+		return Challenge{}, err
+	}
+
+	if err := updatedChallenge.updateUnderlyingItems(challenge.Type, updateChallengeRequest.Items); err != nil {
 		return Challenge{}, err
 	}
 
+	if updateChallengeRequest.Prerequisites != nil {
+		if err := replacePrerequisitesForChallenge(updatedChallenge.ID, updateChallengeRequest.Prerequisites); err != nil {
+			return Challenge{}, fmt.Errorf("error while updating prerequisites for challenge: %w", err)
+		}
+	}
+
+	if updateChallengeRequest.Hints != nil {
+		if err := saveHintsForChallenge(updatedChallenge.ID, updateChallengeRequest.Hints); err != nil {
+			return Challenge{}, err
+		}
+	}
+
+	if updatedChallenge.Status != challenge.Status || updatedChallenge.TeamChallenge != challenge.TeamChallenge {
+		refreshBingoBonuses()
+	}
+
 	return updatedChallenge, nil
 }
 
@@ -92,6 +312,21 @@ func GetAllChallenges(showInactive bool) ([]Challenge, error) {
 	return conn.GetAllChallenges(showInactive)
 }
 
+// GetChallengesVisibleTo returns the active challenges the user can see, the questions of quiz rounds are only
+// asked during their round and stay hidden from players
+func GetChallengesVisibleTo(user User) ([]Challenge, error) {
+	challenges, err := GetAllChallenges(false)
+	if err != nil || user.HasPermission(types.ManageChallengesPermission) {
+		return challenges, err
+	}
+
+	quizChallengeIds, err := GetQuizChallengeIds()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(challenges, func(challenge Challenge) bool { return quizChallengeIds[challenge.ID] }), nil
+}
+
 func GetChallengeByID(id uint) (Challenge, error) {
 	conn := GetConnection()
 	var challenge Challenge
@@ -109,46 +344,89 @@ func (challenge Challenge) hasSubmissions() (bool, error) {
 	return conn.HasSubmissions(challenge.ID)
 }
 
+func (challenge Challenge) hasHintReveals() (bool, error) {
+	conn := GetConnection()
+	return conn.HasHintReveals(challenge.ID)
+}
+
 func (challenge Challenge) checkForInvalidUpdateFields(updateChallengeRequest types.UpdateChallengeRequest) error {
 	hasSubmission, err := challenge.hasSubmissions()
 	if err != nil {
 		return fmt.Errorf("error while retrieving submissions: %w", err)
 	}
 
+	answers := AcceptedAnswers(updateChallengeRequest.Answer, updateChallengeRequest.Answers)
+
 	if hasSubmission {
 		// Cannot update challenge type if submissions exist
 		if updateChallengeRequest.Type != challenge.Type {
 			return apperrors.NewValidationError("Cannot update challenge type if submissions exist")
 		}
 
-		// Cannot update answer if submissions exist
-		if updateChallengeRequest.Type == types.AnswerQuestionChallenge && updateChallengeRequest.Answer != "" {
-			sameAnswer, err := verifyAnswerForQuestion(challenge.ID, updateChallengeRequest.Answer)
-			if err != nil {
+		// Cannot update answers if an existing submission would no longer be accepted
+		if updateChallengeRequest.Type == types.AnswerQuestionChallenge && len(answers) > 0 {
+			if _, err := getAnswersForQuestion(challenge.ID); err != nil {
 				return fmt.Errorf("error verifying answer: %w", err)
 			}
-			if !sameAnswer {
-				return apperrors.NewValidationError("Cannot update answer if submissions exist")
+			if err := challenge.checkAnswerChangeAllowed(answers); err != nil {
+				return err
 			}
 		}
+
+		// Cannot update options if submissions exist, as submissions reference the option ids
+		if updateChallengeRequest.Type == types.MultipleChoiceChallenge && len(updateChallengeRequest.Options) > 0 {
+			return apperrors.NewValidationError("Cannot update options if submissions exist")
+		}
+
+		// Cannot update items if submissions exist, as submissions reference the item ids
+		if updateChallengeRequest.Type == types.OrderingChallenge && len(updateChallengeRequest.Items) > 0 {
+			return apperrors.NewValidationError("Cannot update items if submissions exist")
+		}
 	}
 
-	if challenge.Type == types.UploadPhotoChallenge && updateChallengeRequest.Type == types.AnswerQuestionChallenge && updateChallengeRequest.Answer == "" {
+	if challenge.Type != types.AnswerQuestionChallenge && updateChallengeRequest.Type == types.AnswerQuestionChallenge && len(answers) == 0 {
 		return apperrors.NewValidationError("Answer cannot be empty when changing to AnswerQuestion challenge type")
 	}
 
+	if challenge.Type != types.MultipleChoiceChallenge && updateChallengeRequest.Type == types.MultipleChoiceChallenge && len(updateChallengeRequest.Options) == 0 {
+		return apperrors.NewValidationError("Options cannot be empty when changing to MultipleChoice challenge type")
+	}
+
+	if challenge.Type != types.OrderingChallenge && updateChallengeRequest.Type == types.OrderingChallenge && len(updateChallengeRequest.Items) == 0 {
+		return apperrors.NewValidationError("Items cannot be empty when changing to Ordering challenge type")
+	}
+
+	if challenge.Type != types.CheckInChallenge && updateChallengeRequest.Type == types.CheckInChallenge && updateChallengeRequest.Location == nil {
+		return apperrors.NewValidationError("Location cannot be empty when changing to CheckIn challenge type")
+	}
+
+	if err := validatePrerequisites(challenge.ID, updateChallengeRequest.Prerequisites); err != nil {
+		return err
+	}
+
+	// Cannot replace hints once revealed, as the reveals reference the hints and were paid for
+	if updateChallengeRequest.Hints != nil {
+		hasReveals, err := challenge.hasHintReveals()
+		if err != nil {
+			return fmt.Errorf("error while retrieving hint reveals: %w", err)
+		}
+		if hasReveals {
+			return apperrors.NewValidationError("Cannot update hints once they have been revealed")
+		}
+	}
+
 	return nil
 }
 
-func (challenge Challenge) updateUnderlyingAnswer(oldType types.ChallengeType, answer string) error {
+func (challenge Challenge) updateUnderlyingAnswer(oldType types.ChallengeType, answers []string) error {
 	if challenge.Type == types.AnswerQuestionChallenge {
-		if err := challenge.createOrUpdateAnswer(oldType, answer); err != nil {
+		if err := challenge.createOrUpdateAnswer(oldType, answers); err != nil {
 			return fmt.Errorf("error while creating or updating answer: %w", err)
 		}
 	}
 
 	// If challenge was previously an AnswerQuestionChallenge, delete the answer
-	if challenge.Type == types.UploadPhotoChallenge && oldType == types.AnswerQuestionChallenge {
+	if challenge.Type != types.AnswerQuestionChallenge && oldType == types.AnswerQuestionChallenge {
 		if err := DeleteAnswer(challenge.ID); err != nil {
 			return err
 		}
@@ -157,22 +435,57 @@ func (challenge Challenge) updateUnderlyingAnswer(oldType types.ChallengeType, a
 	return nil
 }
 
-func (challenge Challenge) createOrUpdateAnswer(oldType types.ChallengeType, answer string) error {
-	// If challenge was previously an answer question challenge, update the answer
-	if oldType == types.AnswerQuestionChallenge && answer != "" {
-		answer := NewAnswer(challenge.ID, answer)
-		_, err := answer.Update()
+func (challenge Challenge) createOrUpdateAnswer(oldType types.ChallengeType, answers []string) error {
+	// If challenge was previously an answer question challenge, replace the set of answers
+	if oldType == types.AnswerQuestionChallenge && len(answers) > 0 {
+		_, err := UpdateAnswers(challenge.ID, answers)
 		if err != nil {
 			return fmt.Errorf("error while updating answer for challenge: %w", err)
 		}
 	}
 
-	if oldType == types.UploadPhotoChallenge {
-		// If challenge was previously an UploadPhotoChallenge, create a new answer
-		answer := NewAnswer(challenge.ID, answer)
-		_, err := answer.Save()
-		if err != nil {
-			return fmt.Errorf("error while creating answer for challenge: %w", err)
+	if oldType != types.AnswerQuestionChallenge {
+		// If challenge was previously a different type of challenge, create the new answers
+		for _, value := range answers {
+			answer := NewAnswer(challenge.ID, value)
+			_, err := answer.Save()
+			if err != nil {
+				return fmt.Errorf("error while creating answer for challenge: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (challenge Challenge) updateUnderlyingOptions(oldType types.ChallengeType, options []types.ChallengeOptionInput) error {
+	if challenge.Type == types.MultipleChoiceChallenge && len(options) > 0 {
+		if err := replaceOptionsForChallenge(challenge.ID, options); err != nil {
+			return err
+		}
+	}
+
+	// If challenge was previously a MultipleChoiceChallenge, delete the options
+	if challenge.Type != types.MultipleChoiceChallenge && oldType == types.MultipleChoiceChallenge {
+		if err := DeleteOptionsForChallenge(challenge.ID); err != nil {
+			return fmt.Errorf("error while deleting options for challenge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (challenge Challenge) updateUnderlyingItems(oldType types.ChallengeType, items []types.ChallengeItemInput) error {
+	if challenge.Type == types.OrderingChallenge && len(items) > 0 {
+		if err := replaceItemsForChallenge(challenge.ID, items); err != nil {
+			return err
+		}
+	}
+
+	// If challenge was previously an OrderingChallenge, delete the items
+	if challenge.Type != types.OrderingChallenge && oldType == types.OrderingChallenge {
+		if err := DeleteItemsForChallenge(challenge.ID); err != nil {
+			return fmt.Errorf("error while deleting items for challenge: %w", err)
 		}
 	}
 
@@ -186,6 +499,26 @@ func (challenge Challenge) Delete() error {
 		return fmt.Errorf("error deleting answer for challenge: %w", err)
 	}
 
+	if err := conn.DeleteOptionsForChallenge(challenge.ID); err != nil {
+		return fmt.Errorf("error deleting options for challenge: %w", err)
+	}
+
+	if err := conn.DeleteItemsForChallenge(challenge.ID); err != nil {
+		return fmt.Errorf("error deleting items for challenge: %w", err)
+	}
+
+	if err := conn.DeletePrerequisitesForChallenge(challenge.ID); err != nil {
+		return fmt.Errorf("error deleting prerequisites for challenge: %w", err)
+	}
+
+	if err := conn.DeleteHintsForChallenge(challenge.ID); err != nil {
+		return fmt.Errorf("error deleting hints for challenge: %w", err)
+	}
+
+	if err := conn.DeleteAttemptsForChallenge(challenge.ID); err != nil {
+		return fmt.Errorf("error deleting attempts for challenge: %w", err)
+	}
+
 	if err := conn.DeleteSubmissionsForChallenge(challenge.ID); err != nil {
 		return fmt.Errorf("error deleting submissions for challenge: %w", err)
 	}
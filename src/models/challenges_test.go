@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 var (
@@ -789,3 +790,418 @@ func TestDeleteChallengeError(t *testing.T) {
 		t.Errorf("error deleting answer for challenge: test_error but got %s", err.Error())
 	}
 }
+
+func TestCreateNewChallengeTypeMultipleChoice(t *testing.T) {
+	SetupMockDb()
+
+	createChallengeRequest := types.CreateChallengeRequest{
+		Name:        testChallenge1.Name,
+		Description: testChallenge1.Description,
+		Points:      testChallenge1.Points,
+		Image:       testChallenge1.Image,
+		Type:        types.MultipleChoiceChallenge,
+		Options: []types.ChallengeOptionInput{
+			{Value: "Paris", Correct: true},
+			{Value: "London"},
+		},
+	}
+
+	challenge, err := CreateNewChallenge(createChallengeRequest)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	options, err := GetOptionsForChallenge(challenge.ID)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(options) != 2 {
+		t.Errorf("expected 2 but got %d", len(options))
+		return
+	}
+	if options[0].Value != "Paris" || !options[0].Correct {
+		t.Errorf("expected Paris to be correct but got %v", options[0])
+	}
+	if options[1].Value != "London" || options[1].Correct {
+		t.Errorf("expected London to be incorrect but got %v", options[1])
+	}
+}
+
+func TestUpdateChallengeUploadToMultipleChoiceNoOptions(t *testing.T) {
+	SetupMockDb()
+
+	challenge, err := testChallenge1.Save()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	updateChallengeRequest := types.UpdateChallengeRequest{
+		Name:        "test_challenge_updated",
+		Description: "test_description_updated",
+		Points:      100,
+		Image:       "test_image_updated",
+		Type:        types.MultipleChoiceChallenge,
+		Status:      types.ActiveChallenge,
+	}
+
+	_, err = challenge.Update(updateChallengeRequest)
+	if err == nil {
+		t.Errorf("expected an error")
+		return
+	}
+
+	if err.Error() != "Options cannot be empty when changing to MultipleChoice challenge type" {
+		t.Errorf("expected Options cannot be empty when changing to MultipleChoice challenge type but got %s", err.Error())
+	}
+}
+
+func TestUpdateChallengeOptionsWithSubmissions(t *testing.T) {
+	mockDb := SetupMockDb()
+
+	challenge := Challenge{ID: 555, Name: "name", Description: "description", Points: 10, Type: types.MultipleChoiceChallenge}
+	_, err := mockDb.AddSubmission(NewSubmission(1, challenge.ID, "1"))
+	if err != nil {
+		t.Errorf("error adding submission %v", err.Error())
+		return
+	}
+
+	updateChallengeRequest := types.UpdateChallengeRequest{
+		Name:        "test_challenge_updated",
+		Description: "test_description_updated",
+		Points:      100,
+		Image:       "test_image_updated",
+		Type:        types.MultipleChoiceChallenge,
+		Status:      types.ActiveChallenge,
+		Options: []types.ChallengeOptionInput{
+			{Value: "Paris", Correct: true},
+			{Value: "London"},
+		},
+	}
+
+	_, err = challenge.Update(updateChallengeRequest)
+	if err == nil {
+		t.Errorf("expected an error")
+		return
+	}
+
+	if err.Error() != "Cannot update options if submissions exist" {
+		t.Errorf("expected Cannot update options if submissions exist but got %s", err.Error())
+	}
+}
+
+func TestUpdateChallengeMultipleChoiceToUpload(t *testing.T) {
+	SetupMockDb()
+	createTestOption(testOption1)
+	createTestOption(testOption2)
+
+	challenge := Challenge{ID: 555, Name: "name", Description: "description", Points: 10, Type: types.MultipleChoiceChallenge}
+	updateChallengeRequest := types.UpdateChallengeRequest{
+		Name:        "test_challenge_updated",
+		Description: "test_description_updated",
+		Points:      100,
+		Image:       "test_image_updated",
+		Type:        types.UploadPhotoChallenge,
+		Status:      types.ActiveChallenge,
+	}
+
+	_, err := challenge.Update(updateChallengeRequest)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	options, err := GetOptionsForChallenge(challenge.ID)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(options) != 0 {
+		t.Errorf("expected options to be deleted but got %d", len(options))
+	}
+}
+
+func TestCreateNewChallengeWithMultipleAnswers(t *testing.T) {
+	SetupMockDb()
+
+	createChallengeRequest := types.CreateChallengeRequest{
+		Name:        testChallenge2.Name,
+		Description: testChallenge2.Description,
+		Points:      testChallenge2.Points,
+		Image:       testChallenge2.Image,
+		Type:        types.AnswerQuestionChallenge,
+		Answer:      "Mount Everest",
+		Answers:     []string{"Chomolungma", "Mount Everest"},
+	}
+
+	challenge, err := CreateNewChallenge(createChallengeRequest)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	answers, err := GetAnswer(challenge.ID)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(answers) != 2 || answers[0] != "Mount Everest" || answers[1] != "Chomolungma" {
+		t.Errorf("expected [Mount Everest Chomolungma] but got %v", answers)
+	}
+}
+
+func TestCreateNewChallengeWithAvailabilityWindow(t *testing.T) {
+	SetupMockDb()
+	t.Setenv("EVENT_TIMEZONE", "Europe/Berlin")
+
+	createChallengeRequest := types.CreateChallengeRequest{
+		Name:           testChallenge1.Name,
+		Description:    testChallenge1.Description,
+		Points:         testChallenge1.Points,
+		Image:          testChallenge1.Image,
+		Type:           testChallenge1.Type,
+		AvailableFrom:  "2025-06-21T18:00",
+		AvailableUntil: "2025-06-21T20:00:00Z",
+	}
+
+	challenge, err := CreateNewChallenge(createChallengeRequest)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	expectedFrom := time.Date(2025, 6, 21, 16, 0, 0, 0, time.UTC)
+	if challenge.AvailableFrom == nil || !challenge.AvailableFrom.Equal(expectedFrom) {
+		t.Errorf("expected available from %s but got %v", expectedFrom, challenge.AvailableFrom)
+	}
+	expectedUntil := time.Date(2025, 6, 21, 20, 0, 0, 0, time.UTC)
+	if challenge.AvailableUntil == nil || !challenge.AvailableUntil.Equal(expectedUntil) {
+		t.Errorf("expected available until %s but got %v", expectedUntil, challenge.AvailableUntil)
+	}
+}
+
+func TestCreateNewChallengeWithInvalidAvailability(t *testing.T) {
+	SetupMockDb()
+
+	createChallengeRequest := types.CreateChallengeRequest{
+		Name:          testChallenge1.Name,
+		Description:   testChallenge1.Description,
+		Points:        testChallenge1.Points,
+		Image:         testChallenge1.Image,
+		Type:          testChallenge1.Type,
+		AvailableFrom: "after dinner",
+	}
+
+	_, err := CreateNewChallenge(createChallengeRequest)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "available_from must be a valid timestamp" {
+		t.Errorf("expected available_from must be a valid timestamp but got %s", err.Error())
+	}
+}
+
+func TestUpdateChallengeKeepsAvailabilityWindow(t *testing.T) {
+	SetupMockDb()
+
+	from := time.Date(2025, 6, 21, 16, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 6, 21, 20, 0, 0, 0, time.UTC)
+	challenge := testChallenge1
+	challenge.AvailableFrom = &from
+	challenge.AvailableUntil = &until
+	challenge, err := challenge.Save()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	updatedChallenge, err := challenge.Update(types.UpdateChallengeRequest{
+		Name:        challenge.Name,
+		Description: challenge.Description,
+		Points:      50,
+		Image:       challenge.Image,
+		Type:        challenge.Type,
+		Status:      challenge.Status,
+	})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if updatedChallenge.AvailableFrom == nil || !updatedChallenge.AvailableFrom.Equal(from) {
+		t.Errorf("expected available from %s but got %v", from, updatedChallenge.AvailableFrom)
+	}
+	if updatedChallenge.AvailableUntil == nil || !updatedChallenge.AvailableUntil.Equal(until) {
+		t.Errorf("expected available until %s but got %v", until, updatedChallenge.AvailableUntil)
+	}
+
+	removed := ""
+	updatedChallenge, err = challenge.Update(types.UpdateChallengeRequest{
+		Name:           challenge.Name,
+		Description:    challenge.Description,
+		Points:         50,
+		Image:          challenge.Image,
+		Type:           challenge.Type,
+		Status:         challenge.Status,
+		AvailableUntil: &removed,
+	})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if updatedChallenge.AvailableFrom == nil || !updatedChallenge.AvailableFrom.Equal(from) {
+		t.Errorf("expected available from %s but got %v", from, updatedChallenge.AvailableFrom)
+	}
+	if updatedChallenge.AvailableUntil != nil {
+		t.Errorf("expected no available until but got %v", updatedChallenge.AvailableUntil)
+	}
+}
+
+func TestUpdateChallengeWithAvailableUntilBeforeKeptAvailableFrom(t *testing.T) {
+	SetupMockDb()
+
+	from := time.Date(2025, 6, 21, 16, 0, 0, 0, time.UTC)
+	challenge := testChallenge1
+	challenge.AvailableFrom = &from
+	challenge, err := challenge.Save()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	until := "2025-06-21T15:00:00Z"
+	_, err = challenge.Update(types.UpdateChallengeRequest{
+		Name:           challenge.Name,
+		Description:    challenge.Description,
+		Points:         challenge.Points,
+		Image:          challenge.Image,
+		Type:           challenge.Type,
+		Status:         challenge.Status,
+		AvailableUntil: &until,
+	})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+
+	if err.Error() != "available_from must be before available_until" {
+		t.Errorf("expected available_from must be before available_until but got %s", err.Error())
+	}
+}
+
+func TestChallengeIsAvailableAt(t *testing.T) {
+	from := time.Date(2025, 6, 21, 18, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 6, 21, 20, 0, 0, 0, time.UTC)
+	challenge := Challenge{AvailableFrom: &from, AvailableUntil: &until}
+
+	if challenge.IsAvailableAt(from.Add(-time.Minute)) {
+		t.Errorf("expected challenge not to be available before the window")
+	}
+	if !challenge.IsAvailableAt(from) {
+		t.Errorf("expected challenge to be available at the start of the window")
+	}
+	if challenge.IsAvailableAt(until) {
+		t.Errorf("expected challenge not to be available at the end of the window")
+	}
+	if !(Challenge{}).IsAvailableAt(until) {
+		t.Errorf("expected challenge without a window to always be available")
+	}
+}
+
+func TestChallengeCheckAvailability(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	err := Challenge{AvailableFrom: &future}.CheckAvailability()
+	if err == nil || err.Error() != "challenge is not available yet" {
+		t.Errorf("expected challenge is not available yet but got %v", err)
+	}
+
+	err = Challenge{AvailableUntil: &past}.CheckAvailability()
+	if err == nil || err.Error() != "challenge is no longer available" {
+		t.Errorf("expected challenge is no longer available but got %v", err)
+	}
+
+	err = Challenge{AvailableFrom: &past, AvailableUntil: &future}.CheckAvailability()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestChallengeCheckVisibleTo(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	challenge := Challenge{ID: 5, AvailableFrom: &future}
+
+	err := challenge.CheckVisibleTo(User{Role: types.Player})
+	if err == nil || err.Error() != "Challenge with key 5 not found." {
+		t.Errorf("expected Challenge with key 5 not found. but got %v", err)
+	}
+
+	err = challenge.CheckVisibleTo(User{Role: types.Admin})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestChallengeCheckVisibleToHidesQuizChallenges(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.Create(&QuizQuestion{RoundID: 1, ChallengeID: 5})
+	challenge := Challenge{ID: 5}
+
+	err := challenge.CheckVisibleTo(User{Role: types.Player})
+	if err == nil || err.Error() != "Challenge with key 5 not found." {
+		t.Errorf("expected Challenge with key 5 not found. but got %v", err)
+	}
+
+	err = challenge.CheckVisibleTo(User{Role: types.Admin})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestGetAllChallengesHidesUnavailable(t *testing.T) {
+	SetupMockDb()
+
+	future := time.Now().Add(time.Hour)
+	scheduledChallenge := testChallenge2
+	scheduledChallenge.AvailableFrom = &future
+
+	_, _ = testChallenge1.Save()
+	_, _ = scheduledChallenge.Save()
+
+	challenges, err := GetAllChallenges(false)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(challenges) != 1 || challenges[0].Name != testChallenge1.Name {
+		t.Errorf("expected only %s but got %v", testChallenge1.Name, challenges)
+	}
+}
+
+func TestGetAllChallengesShowsUnavailableToAdmin(t *testing.T) {
+	SetupMockDb()
+
+	future := time.Now().Add(time.Hour)
+	scheduledChallenge := testChallenge2
+	scheduledChallenge.AvailableFrom = &future
+	_, _ = scheduledChallenge.Save()
+
+	challenges, err := GetAllChallenges(true)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(challenges) != 1 {
+		t.Errorf("expected 1 but got %d", len(challenges))
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+)
+
+func (challenge *Challenge) setCheckInLocation(location types.CheckInLocation) {
+	challenge.CheckInLatitude = location.Latitude
+	challenge.CheckInLongitude = location.Longitude
+	challenge.CheckInRadius = location.Radius
+}
+
+func (challenge Challenge) GetCheckInLocation() types.CheckInLocation {
+	return types.CheckInLocation{
+		Latitude:  challenge.CheckInLatitude,
+		Longitude: challenge.CheckInLongitude,
+		Radius:    challenge.CheckInRadius,
+	}
+}
+
+// GetCheckInDistance returns how far the location in the answer is from the location of the challenge, in meters
+func (challenge Challenge) GetCheckInDistance(answer string) (float64, error) {
+	latitude, longitude, _, err := utils.ParseDeviceLocation(answer)
+	if err != nil {
+		return 0, apperrors.NewValidationError(constants.InvalidDeviceLocationError)
+	}
+	return utils.DistanceInMeters(challenge.CheckInLatitude, challenge.CheckInLongitude, latitude, longitude), nil
+}
+
+// verifyAnswerForCheckIn passes if the device is within the radius of the challenge location.
+// Readings less accurate than the radius cannot tell inside from outside, so the player is asked to try again.
+func verifyAnswerForCheckIn(challenge Challenge, answer string) (bool, error) {
+	_, _, accuracy, err := utils.ParseDeviceLocation(answer)
+	if err != nil {
+		return false, apperrors.NewValidationError(constants.InvalidDeviceLocationError)
+	}
+	if accuracy > challenge.CheckInRadius {
+		return false, apperrors.NewValidationError(constants.LocationNotAccurateError)
+	}
+
+	distance, err := challenge.GetCheckInDistance(answer)
+	if err != nil {
+		return false, err
+	}
+	return distance <= challenge.CheckInRadius, nil
+}
+
+// AddCheckInDistances fills in how far from the location of a check-in challenge every submission was made
+func (challenge Challenge) AddCheckInDistances(submissions []types.SubmissionForChallenge) {
+	if challenge.Type != types.CheckInChallenge {
+		return
+	}
+
+	for i := range submissions {
+		if distance, err := challenge.GetCheckInDistance(submissions[i].Answer); err == nil {
+			submissions[i].Distance = &distance
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+// the ceremony venue, the photo spot is about 850 meters away
+var testCheckInChallenge = Challenge{ID: 66, Name: "ceremony", Description: "Check in at the ceremony", Points: 10,
+	Type: types.CheckInChallenge, Status: types.ActiveChallenge,
+	CheckInLatitude: 52.3791, CheckInLongitude: 4.9003, CheckInRadius: 100}
+
+func TestVerifyAnswerForCheckInWithinRadius(t *testing.T) {
+	correct, err := verifyAnswerForCheckIn(testCheckInChallenge, "52.3795,4.9008,15")
+	if err != nil || !correct {
+		t.Errorf("expected a check-in within the radius to pass but got %v %v", correct, err)
+	}
+}
+
+func TestVerifyAnswerForCheckInOutsideRadius(t *testing.T) {
+	correct, err := verifyAnswerForCheckIn(testCheckInChallenge, "52.3731,4.8926,15")
+	if err != nil || correct {
+		t.Errorf("expected a check-in outside the radius to fail but got %v %v", correct, err)
+	}
+}
+
+func TestVerifyAnswerForCheckInNotAccurate(t *testing.T) {
+	_, err := verifyAnswerForCheckIn(testCheckInChallenge, "52.3791,4.9003,500")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "location is not accurate enough, try again when the device has a better fix" {
+		t.Errorf("expected location not accurate error but got %s", err.Error())
+	}
+}
+
+func TestVerifyAnswerForCheckInInvalidLocation(t *testing.T) {
+	_, err := verifyAnswerForCheckIn(testCheckInChallenge, "the ceremony")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "answer must be the latitude, longitude and accuracy of the device" {
+		t.Errorf("expected invalid device location error but got %s", err.Error())
+	}
+}
+
+func TestAddCheckInDistances(t *testing.T) {
+	submissions := []types.SubmissionForChallenge{
+		{Id: 1, Answer: "52.3791,4.9003,5"},
+		{Id: 2, Answer: "52.3731,4.8926,5"},
+		{Id: 3, Answer: "invalid"},
+	}
+
+	testCheckInChallenge.AddCheckInDistances(submissions)
+
+	if submissions[0].Distance == nil || *submissions[0].Distance != 0 {
+		t.Errorf("expected a distance of 0 but got %v", submissions[0].Distance)
+	}
+	if submissions[1].Distance == nil || *submissions[1].Distance < 840 || *submissions[1].Distance > 860 {
+		t.Errorf("expected a distance of about 850 meters but got %v", submissions[1].Distance)
+	}
+	if submissions[2].Distance != nil {
+		t.Errorf("expected no distance for an invalid location but got %v", *submissions[2].Distance)
+	}
+}
+
+func TestAddCheckInDistancesIgnoresOtherChallenges(t *testing.T) {
+	submissions := []types.SubmissionForChallenge{{Id: 1, Answer: "52.3791,4.9003,5"}}
+
+	testChallenge123.AddCheckInDistances(submissions)
+
+	if submissions[0].Distance != nil {
+		t.Errorf("expected no distance for a question challenge but got %v", *submissions[0].Distance)
+	}
+}
+
+func TestUpdateChallengeToCheckInWithoutLocation(t *testing.T) {
+	SetupMockDb()
+
+	_, err := testChallenge123.Update(types.UpdateChallengeRequest{
+		Name:        testChallenge123.Name,
+		Description: testChallenge123.Description,
+		Points:      testChallenge123.Points,
+		Image:       testChallenge123.Image,
+		Status:      testChallenge123.Status,
+		Type:        types.CheckInChallenge,
+	})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if err.Error() != "Location cannot be empty when changing to CheckIn challenge type" {
+		t.Errorf("expected location required error but got %s", err.Error())
+	}
+}
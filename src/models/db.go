@@ -2,6 +2,7 @@ package models
 
 import (
 	"the-wedding-game-api/types"
+	"time"
 )
 
 var databaseConnection DatabaseInterface
@@ -18,13 +19,80 @@ type DatabaseInterface interface {
 	GetGallery() ([]types.GalleryItem, error)
 	HasSubmissions(challengeId uint) (bool, error)
 	UpdateChallenge(challengeId Challenge, updateChallengeRequest types.UpdateChallengeRequest) (Challenge, error)
-	UpdateAnswer(challengeId uint, answer string) (Answer, error)
+	UpdateAnswer(challengeId uint, answers []string) ([]Answer, error)
+	UpdateAnswerById(answer Answer) (Answer, error)
+	DeleteAnswerById(challengeId uint, answerId uint) error
 	DeleteAnswer(challengeId uint) error
 	GetSubmissionsForChallenge(challengeId uint) ([]types.SubmissionForChallenge, error)
 	DeleteChallenge(challengeId uint) error
 	DeleteSubmissionsForChallenge(challengeId uint) error
 	DeleteAnswerForChallenge(challengeId uint) error
-	GetAnswerForChallenge(challengeId uint) (string, error)
+	GetAnswersForChallenge(challengeId uint) ([]Answer, error)
+	GetOptionsForChallenge(challengeId uint) ([]ChallengeOption, error)
+	DeleteOptionsForChallenge(challengeId uint) error
+	GetChallengesByIds(challengeIds []uint) ([]Challenge, error)
+	GetAllPrerequisites() ([]ChallengePrerequisite, error)
+	ReplacePrerequisitesForChallenge(challengeId uint, prerequisiteIds []uint) error
+	DeletePrerequisitesForChallenge(challengeId uint) error
+	GetHintsForChallenge(challengeId uint) ([]ChallengeHint, error)
+	ReplaceHintsForChallenge(challengeId uint, hints []ChallengeHint) error
+	DeleteHintsForChallenge(challengeId uint) error
+	GetHintRevealsForUser(userId uint) ([]HintReveal, error)
+	HasHintReveals(challengeId uint) (bool, error)
+	CountFailedAttempts(userId uint, challengeId uint) (uint, error)
+	SaveAttempt(attempt Attempt, maxAttempts uint) (Attempt, error)
+	GetAttemptsForChallenge(challengeId uint) ([]types.AttemptForChallenge, error)
+	DeleteAttemptsForChallenge(challengeId uint) error
+	GetAllTeams() ([]Team, error)
+	GetTeamMembers(teamId uint) ([]User, error)
+	UpdateTeam(team Team) (Team, error)
+	DeleteTeam(teamId uint) error
+	SetUserTeam(userId uint, teamId *uint) error
+	SetUserRole(userId uint, role types.UserRole) error
+	GetAllUsers() ([]User, error)
+	SetUserPassword(userId uint, passwordHash string) error
+	GetTeamLeaderboard() ([]types.TeamLeaderboardEntry, error)
+	GetTeamSubmissions(userId uint) ([]Submission, error)
+	GetSubmission(userId uint, challengeId uint) (*Submission, error)
+	UpdateSubmission(submission Submission) (Submission, error)
+	SaveRankedSubmission(submission Submission, award func(previousSolvers uint) (uint, error)) (Submission, error)
+	SaveQuizAnswer(attempt Attempt, award func(previousSolvers uint) (uint, error)) (Submission, error)
+	GetSubmissionsByState(state types.SubmissionState) ([]types.ModerationSubmission, error)
+	IsAnswerUsedElsewhere(answer string, userId uint, challengeId uint) (bool, error)
+	GetAudioMessages() ([]types.AudioMessage, error)
+	GetChallengeSubmissions(challengeId uint) ([]Submission, error)
+	GetItemsForChallenge(challengeId uint) ([]ChallengeItem, error)
+	DeleteItemsForChallenge(challengeId uint) error
+	ResolveChallenge(challengeId uint, outcome string, resolvedAt time.Time, submissions []Submission) error
+	GetBingoBoard() (*BingoBoard, error)
+	GetBingoCells(boardId uint) ([]BingoCell, error)
+	ReplaceBingoBoard(board BingoBoard, cells []BingoCell) (BingoBoard, []BingoCell, error)
+	DeleteBingoBoard() error
+	GetBingoCompletions(challengeIds []uint) ([]types.BingoCompletion, error)
+	GetQuizRounds() ([]QuizRound, error)
+	GetQuizQuestions(roundId uint) ([]QuizQuestion, error)
+	GetAllQuizQuestions() ([]QuizQuestion, error)
+	StartQuizRound(roundId uint, startedAt time.Time) error
+	GetQuizResults(roundId uint) ([]types.QuizResult, error)
+	GetPointsEntries(userId uint) ([]PointsEntry, error)
+	RecordBingoBonuses(bonuses map[uint]uint) error
+	GetAccessTokenByRefreshToken(refreshToken string) (*AccessToken, error)
+	ReplaceAccessToken(accessTokenId uint, accessToken AccessToken) (AccessToken, error)
+	DeleteAccessToken(token string) error
+	DeleteExpiredAccessTokens(now int64) (int64, error)
+	GetGuests() ([]types.GuestResponse, error)
+	GetGuestByInviteCode(inviteCode string) (*Guest, error)
+	CreateGuests(guests []Guest) ([]Guest, error)
+	GetGuestByUserID(userId uint) (*Guest, error)
+	ClaimGuest(guestId uint, userId uint) error
+	CreateUserForGuest(user User, guestId uint) (User, error)
+	GetSetting(key string) (*string, error)
+	SetSetting(key string, value string) error
+	GetLoginLockout(key string) (*LoginLockout, error)
+	GetLoginLockouts() ([]LoginLockout, error)
+	ReserveLogin(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (LoginLockout, bool, error)
+	ReleaseLogin(key string) error
+	DeleteLoginLockout(key string) error
 	GetError() error
 }
 
@@ -3,16 +3,20 @@ package models
 import (
 	"errors"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 type MockDB struct {
 	items       []interface{}
 	submissions []Submission
 	Error       error
+	BingoError  error // only fails RecordBingoBonuses, to test changes that are saved before the bonuses
 }
 
 func (m *MockDB) GetSession() DatabaseInterface {
@@ -77,7 +81,7 @@ func (m *MockDB) Find(dest interface{}, _ ...interface{}) DatabaseInterface {
 	return m
 }
 
-func (m *MockDB) GetAllChallenges(_ bool) ([]Challenge, error) {
+func (m *MockDB) GetAllChallenges(showInactive bool) ([]Challenge, error) {
 	var challenges []Challenge
 
 	m.Find(&challenges)
@@ -85,7 +89,17 @@ func (m *MockDB) GetAllChallenges(_ bool) ([]Challenge, error) {
 		return nil, apperrors.NewDatabaseError(m.Error.Error())
 	}
 
-	return challenges, nil
+	if showInactive {
+		return challenges, nil
+	}
+
+	var available []Challenge
+	for _, challenge := range challenges {
+		if challenge.IsAvailableAt(time.Now()) {
+			available = append(available, challenge)
+		}
+	}
+	return available, nil
 }
 
 func (m *MockDB) GetPointsForUser(_ uint) (uint, error) {
@@ -114,9 +128,9 @@ func (m *MockDB) GetGallery() ([]types.GalleryItem, error) {
 	}
 
 	return []types.GalleryItem{
-		{Url: "https://example.com/image1.jpg", SubmittedBy: "user1"},
-		{Url: "invalid_url", SubmittedBy: "user2"},
-		{Url: "https://example.com/image3.jpg", SubmittedBy: "user3"},
+		{Url: "https://example.com/image1.jpg", SubmittedBy: "user1", MediaType: types.ImageMedia},
+		{Url: "invalid_url", SubmittedBy: "user2", MediaType: types.ImageMedia},
+		{Url: "https://example.com/image3.jpg", SubmittedBy: "user3", MediaType: types.ImageMedia},
 	}, nil
 }
 
@@ -159,6 +173,33 @@ func (m *MockDB) UpdateChallenge(challenge Challenge, updated types.UpdateChalle
 	challenge.Image = updated.Image
 	challenge.Status = updated.Status
 	challenge.Type = updated.Type
+	if updated.AnswerMatching.Mode != "" {
+		challenge.setAnswerMatching(updated.AnswerMatching)
+	}
+	if err := challenge.updateAvailability(updated.AvailableFrom, updated.AvailableUntil); err != nil {
+		return Challenge{}, err
+	}
+	if updated.MaxAttempts != nil {
+		challenge.MaxAttempts = *updated.MaxAttempts
+	}
+	if updated.Scoring != nil {
+		challenge.setScoringRules(*updated.Scoring)
+	}
+	if updated.Team != nil {
+		challenge.TeamChallenge = *updated.Team
+	}
+	if updated.MaxDuration != nil {
+		challenge.MaxDurationSeconds = *updated.MaxDuration
+	}
+	if updated.Prediction != nil {
+		challenge.setPredictionRules(*updated.Prediction)
+	}
+	if updated.PartialCredit != nil {
+		challenge.OrderingPartialCredit = *updated.PartialCredit
+	}
+	if updated.Location != nil {
+		challenge.setCheckInLocation(*updated.Location)
+	}
 
 	m.items = append(m.items, challenge)
 
@@ -177,19 +218,62 @@ func (m *MockDB) DeleteAnswer(challengeId uint) error {
 	return nil
 }
 
-func (m *MockDB) UpdateAnswer(challengeId uint, value string) (Answer, error) {
+func (m *MockDB) UpdateAnswer(challengeId uint, values []string) ([]Answer, error) {
 	if m.Error != nil {
-		return Answer{}, apperrors.NewDatabaseError(m.Error.Error())
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
 	}
 
 	if challengeId == 999 {
+		return nil, apperrors.NewRecordNotFoundError("Answer with key 999 not found")
+	}
+
+	m.removeAnswers(challengeId, 0)
+	answers := make([]Answer, len(values))
+	for i, value := range values {
+		answers[i] = Answer{ChallengeID: challengeId, Value: value}
+		m.items = append(m.items, &answers[i])
+	}
+
+	return answers, nil
+}
+
+func (m *MockDB) UpdateAnswerById(answer Answer) (Answer, error) {
+	if m.Error != nil {
+		return Answer{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	if answer.ChallengeID == 999 {
 		return Answer{}, apperrors.NewRecordNotFoundError("Answer with key 999 not found")
 	}
 
-	return Answer{
-		ChallengeID: challengeId,
-		Value:       value,
-	}, nil
+	for _, item := range m.items {
+		if existing, ok := item.(*Answer); ok && existing.ID == answer.ID && existing.ChallengeID == answer.ChallengeID {
+			existing.Value = answer.Value
+		}
+	}
+
+	return answer, nil
+}
+
+func (m *MockDB) DeleteAnswerById(challengeId uint, answerId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	m.removeAnswers(challengeId, answerId)
+	return nil
+}
+
+// removeAnswers removes the answers of the challenge from the mock, or only the given answer if answerId is set
+func (m *MockDB) removeAnswers(challengeId uint, answerId uint) {
+	var remaining []interface{}
+	for _, item := range m.items {
+		if answer, ok := item.(*Answer); ok && answer.ChallengeID == challengeId && (answerId == 0 || answer.ID == answerId) {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
 }
 
 func (m *MockDB) GetError() error {
@@ -271,10 +355,1167 @@ func (m *MockDB) DeleteSubmissionsForChallenge(challengeId uint) error {
 	return nil
 }
 
-func (m *MockDB) GetAnswerForChallenge(_ uint) (string, error) {
+func (m *MockDB) GetAnswersForChallenge(challengeId uint) ([]Answer, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var answers = make([]Answer, 0)
+	for _, item := range m.items {
+		switch answer := item.(type) {
+		case *Answer:
+			if answer.ChallengeID == challengeId {
+				answers = append(answers, *answer)
+			}
+		case Answer:
+			if answer.ChallengeID == challengeId {
+				answers = append(answers, answer)
+			}
+		}
+	}
+
+	return answers, nil
+}
+
+func (m *MockDB) GetOptionsForChallenge(challengeId uint) ([]ChallengeOption, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var options = make([]ChallengeOption, 0)
+	for _, item := range m.items {
+		switch option := item.(type) {
+		case *ChallengeOption:
+			if option.ChallengeID == challengeId {
+				options = append(options, *option)
+			}
+		case ChallengeOption:
+			if option.ChallengeID == challengeId {
+				options = append(options, option)
+			}
+		}
+	}
+
+	return options, nil
+}
+
+func (m *MockDB) DeleteOptionsForChallenge(challengeId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var remaining []interface{}
+	for _, item := range m.items {
+		if option, ok := item.(*ChallengeOption); ok && option.ChallengeID == challengeId {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
+
+	return nil
+}
+
+func (m *MockDB) GetItemsForChallenge(challengeId uint) ([]ChallengeItem, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var items = make([]ChallengeItem, 0)
+	for _, item := range m.items {
+		if challengeItem, ok := item.(*ChallengeItem); ok && challengeItem.ChallengeID == challengeId {
+			items = append(items, *challengeItem)
+		}
+	}
+	slices.SortFunc(items, func(a, b ChallengeItem) int {
+		return int(a.Position) - int(b.Position)
+	})
+
+	return items, nil
+}
+
+func (m *MockDB) DeleteItemsForChallenge(challengeId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var remaining []interface{}
+	for _, item := range m.items {
+		if challengeItem, ok := item.(*ChallengeItem); ok && challengeItem.ChallengeID == challengeId {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
+
+	return nil
+}
+
+func (m *MockDB) GetChallengesByIds(challengeIds []uint) ([]Challenge, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var challenges = make([]Challenge, 0)
+	for _, item := range m.items {
+		switch challenge := item.(type) {
+		case *Challenge:
+			if slices.Contains(challengeIds, challenge.ID) {
+				challenges = append(challenges, *challenge)
+			}
+		case Challenge:
+			if slices.Contains(challengeIds, challenge.ID) {
+				challenges = append(challenges, challenge)
+			}
+		}
+	}
+
+	return challenges, nil
+}
+
+func (m *MockDB) GetAllPrerequisites() ([]ChallengePrerequisite, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var prerequisites = make([]ChallengePrerequisite, 0)
+	for _, item := range m.items {
+		switch prerequisite := item.(type) {
+		case *ChallengePrerequisite:
+			prerequisites = append(prerequisites, *prerequisite)
+		case ChallengePrerequisite:
+			prerequisites = append(prerequisites, prerequisite)
+		}
+	}
+
+	return prerequisites, nil
+}
+
+func (m *MockDB) ReplacePrerequisitesForChallenge(challengeId uint, prerequisiteIds []uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	m.removePrerequisites(func(prerequisite *ChallengePrerequisite) bool {
+		return prerequisite.ChallengeID == challengeId
+	})
+	for _, prerequisiteId := range prerequisiteIds {
+		m.items = append(m.items, &ChallengePrerequisite{ChallengeID: challengeId, PrerequisiteID: prerequisiteId})
+	}
+
+	return nil
+}
+
+func (m *MockDB) DeletePrerequisitesForChallenge(challengeId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	m.removePrerequisites(func(prerequisite *ChallengePrerequisite) bool {
+		return prerequisite.ChallengeID == challengeId || prerequisite.PrerequisiteID == challengeId
+	})
+
+	return nil
+}
+
+func (m *MockDB) removePrerequisites(matches func(prerequisite *ChallengePrerequisite) bool) {
+	var remaining []interface{}
+	for _, item := range m.items {
+		if prerequisite, ok := item.(*ChallengePrerequisite); ok && matches(prerequisite) {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
+}
+
+func (m *MockDB) GetHintsForChallenge(challengeId uint) ([]ChallengeHint, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var hints = make([]ChallengeHint, 0)
+	for _, item := range m.items {
+		if hint, ok := item.(*ChallengeHint); ok && hint.ChallengeID == challengeId {
+			hints = append(hints, *hint)
+		}
+	}
+
+	return hints, nil
+}
+
+func (m *MockDB) ReplaceHintsForChallenge(challengeId uint, hints []ChallengeHint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var remaining []interface{}
+	for _, item := range m.items {
+		if hint, ok := item.(*ChallengeHint); ok && hint.ChallengeID == challengeId {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	for i := range hints {
+		remaining = append(remaining, &hints[i])
+	}
+	m.items = remaining
+
+	return nil
+}
+
+func (m *MockDB) DeleteHintsForChallenge(challengeId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var remaining []interface{}
+	for _, item := range m.items {
+		if hint, ok := item.(*ChallengeHint); ok && hint.ChallengeID == challengeId {
+			continue
+		}
+		if reveal, ok := item.(*HintReveal); ok && reveal.ChallengeID == challengeId {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
+
+	return nil
+}
+
+func (m *MockDB) GetHintRevealsForUser(userId uint) ([]HintReveal, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var reveals = make([]HintReveal, 0)
+	for _, item := range m.items {
+		if reveal, ok := item.(*HintReveal); ok && reveal.UserID == userId {
+			reveals = append(reveals, *reveal)
+		}
+	}
+
+	return reveals, nil
+}
+
+func (m *MockDB) HasHintReveals(challengeId uint) (bool, error) {
+	if m.Error != nil {
+		return false, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if reveal, ok := item.(*HintReveal); ok && reveal.ChallengeID == challengeId {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *MockDB) CountFailedAttempts(userId uint, challengeId uint) (uint, error) {
+	if m.Error != nil {
+		return 0, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var count uint
+	for _, item := range m.items {
+		if attempt, ok := item.(*Attempt); ok && attempt.UserID == userId && attempt.ChallengeID == challengeId && !attempt.Correct {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *MockDB) SaveAttempt(attempt Attempt, maxAttempts uint) (Attempt, error) {
+	failed, err := m.CountFailedAttempts(attempt.UserID, attempt.ChallengeID)
+	if err != nil {
+		return Attempt{}, err
+	}
+	if maxAttempts > 0 && failed >= maxAttempts {
+		return Attempt{}, apperrors.NewValidationError(constants.NoAttemptsLeftError)
+	}
+
+	m.items = append(m.items, &attempt)
+	return attempt, nil
+}
+
+func (m *MockDB) GetAttemptsForChallenge(challengeId uint) ([]types.AttemptForChallenge, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var attempts = make([]types.AttemptForChallenge, 0)
+	for _, item := range m.items {
+		if attempt, ok := item.(*Attempt); ok && attempt.ChallengeID == challengeId {
+			attempts = append(attempts, types.AttemptForChallenge{
+				Id:        attempt.ID,
+				Value:     attempt.Value,
+				Correct:   attempt.Correct,
+				UserId:    attempt.UserID,
+				Username:  "user" + strconv.Itoa(int(attempt.UserID)),
+				CreatedAt: attempt.CreatedAt,
+			})
+		}
+	}
+
+	return attempts, nil
+}
+
+func (m *MockDB) DeleteAttemptsForChallenge(challengeId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var remaining []interface{}
+	for _, item := range m.items {
+		if attempt, ok := item.(*Attempt); ok && attempt.ChallengeID == challengeId {
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
+
+	return nil
+}
+
+func (m *MockDB) GetAllTeams() ([]Team, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var teams = make([]Team, 0)
+	for _, item := range m.items {
+		if team, ok := item.(*Team); ok {
+			teams = append(teams, *team)
+		}
+	}
+
+	return teams, nil
+}
+
+func (m *MockDB) GetTeamMembers(teamId uint) ([]User, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var members = make([]User, 0)
+	for _, item := range m.items {
+		if user, ok := item.(*User); ok && user.TeamID != nil && *user.TeamID == teamId {
+			members = append(members, *user)
+		}
+	}
+
+	return members, nil
+}
+
+func (m *MockDB) UpdateTeam(team Team) (Team, error) {
+	if m.Error != nil {
+		return Team{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	if team.ID == 999 {
+		return Team{}, apperrors.NewRecordNotFoundError("Team with ID 999 not found")
+	}
+
+	return team, nil
+}
+
+func (m *MockDB) DeleteTeam(teamId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	if teamId == 999 {
+		return apperrors.NewRecordNotFoundError("Team with ID 999 not found")
+	}
+
+	var remaining []interface{}
+	for _, item := range m.items {
+		if team, ok := item.(*Team); ok && team.ID == teamId {
+			continue
+		}
+		if user, ok := item.(*User); ok && user.TeamID != nil && *user.TeamID == teamId {
+			user.TeamID = nil
+		}
+		remaining = append(remaining, item)
+	}
+	m.items = remaining
+
+	return nil
+}
+
+func (m *MockDB) SetUserTeam(userId uint, teamId *uint) error {
 	if m.Error != nil {
-		return "", apperrors.NewDatabaseError(m.Error.Error())
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if user, ok := item.(*User); ok && user.ID == userId {
+			user.TeamID = teamId
+			return nil
+		}
 	}
 
-	return "mock_answer", nil
+	return apperrors.NewRecordNotFoundError("User with ID " + strconv.Itoa(int(userId)) + " not found")
+}
+
+func (m *MockDB) SetUserRole(userId uint, role types.UserRole) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if user, ok := item.(*User); ok && user.ID == userId {
+			user.Role = role
+			if role == types.Player {
+				user.PasswordHash = nil
+			}
+			return nil
+		}
+	}
+
+	return apperrors.NewRecordNotFoundError("User with ID " + strconv.Itoa(int(userId)) + " not found")
+}
+
+func (m *MockDB) GetAllUsers() ([]User, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var users = make([]User, 0)
+	for _, item := range m.items {
+		if user, ok := item.(*User); ok {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+func (m *MockDB) SetUserPassword(userId uint, passwordHash string) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if user, ok := item.(*User); ok && user.ID == userId {
+			user.PasswordHash = &passwordHash
+			return nil
+		}
+	}
+
+	return apperrors.NewRecordNotFoundError("User with ID " + strconv.Itoa(int(userId)) + " not found")
+}
+
+func (m *MockDB) GetTeamLeaderboard() ([]types.TeamLeaderboardEntry, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	return []types.TeamLeaderboardEntry{
+		{Name: "team1", Points: 300},
+		{Name: "team2", Points: 100},
+	}, nil
+}
+
+func (m *MockDB) GetTeamSubmissions(userId uint) ([]Submission, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	teams := make(map[uint]uint)
+	teamChallenges := make(map[uint]bool)
+	for _, item := range m.items {
+		if user, ok := item.(*User); ok && user.TeamID != nil {
+			teams[user.ID] = *user.TeamID
+		}
+		if challenge, ok := item.(*Challenge); ok && challenge.TeamChallenge {
+			teamChallenges[challenge.ID] = true
+		}
+	}
+
+	teamId, ok := teams[userId]
+	if !ok {
+		return nil, nil
+	}
+
+	var teamSubmissions []Submission
+	for _, submission := range m.allSubmissions() {
+		memberTeamId, isMember := teams[submission.UserID]
+		if isMember && memberTeamId == teamId && submission.UserID != userId && teamChallenges[submission.ChallengeID] && submission.IsApproved() {
+			teamSubmissions = append(teamSubmissions, submission)
+		}
+	}
+
+	return teamSubmissions, nil
+}
+
+// allSubmissions returns the submissions added with AddSubmission and the ones created through Create
+func (m *MockDB) allSubmissions() []Submission {
+	submissions := slices.Clone(m.submissions)
+	for _, item := range m.items {
+		if submission, ok := item.(*Submission); ok {
+			submissions = append(submissions, *submission)
+		}
+	}
+	return submissions
+}
+
+func (m *MockDB) GetSubmission(userId uint, challengeId uint) (*Submission, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, submission := range m.allSubmissions() {
+		if submission.UserID == userId && submission.ChallengeID == challengeId {
+			return &submission, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockDB) UpdateSubmission(submission Submission) (Submission, error) {
+	if m.Error != nil {
+		return Submission{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for i := range m.submissions {
+		if m.submissions[i].ID == submission.ID {
+			m.submissions[i] = submission
+			return submission, nil
+		}
+	}
+	for _, item := range m.items {
+		if existing, ok := item.(*Submission); ok && existing.ID == submission.ID {
+			*existing = submission
+			return submission, nil
+		}
+	}
+
+	return Submission{}, apperrors.NewRecordNotFoundError("Submission with ID " + strconv.Itoa(int(submission.ID)) + " not found")
+}
+
+func (m *MockDB) SaveRankedSubmission(submission Submission, award func(previousSolvers uint) (uint, error)) (Submission, error) {
+	if m.Error != nil {
+		return Submission{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var previousSolvers uint
+	for _, existing := range m.allSubmissions() {
+		if submission.ID != 0 && existing.ID == submission.ID {
+			continue
+		}
+		if existing.ChallengeID == submission.ChallengeID && existing.State != types.RejectedSubmission {
+			previousSolvers++
+		}
+	}
+
+	points, err := award(previousSolvers)
+	if err != nil {
+		return Submission{}, err
+	}
+	submission.Points = points
+
+	if submission.ID != 0 {
+		return m.UpdateSubmission(submission)
+	}
+	m.items = append(m.items, &submission)
+	return submission, nil
+}
+
+func (m *MockDB) SaveQuizAnswer(attempt Attempt, award func(previousSolvers uint) (uint, error)) (Submission, error) {
+	if m.Error != nil {
+		return Submission{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if existing, ok := item.(*Attempt); ok && existing.UserID == attempt.UserID && existing.ChallengeID == attempt.ChallengeID {
+			return Submission{}, apperrors.NewValidationError(constants.QuizQuestionAnsweredError)
+		}
+	}
+	for _, existing := range m.allSubmissions() {
+		if existing.UserID == attempt.UserID && existing.ChallengeID == attempt.ChallengeID {
+			return Submission{}, apperrors.NewValidationError(constants.QuizQuestionAnsweredError)
+		}
+	}
+
+	m.items = append(m.items, &attempt)
+	if !attempt.Correct {
+		return Submission{}, nil
+	}
+	return m.SaveRankedSubmission(NewSubmission(attempt.UserID, attempt.ChallengeID, attempt.Value), award)
+}
+
+func (m *MockDB) GetSubmissionsByState(state types.SubmissionState) ([]types.ModerationSubmission, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var submissions = make([]types.ModerationSubmission, 0)
+	for _, submission := range m.allSubmissions() {
+		if submission.State == state {
+			submissions = append(submissions, types.ModerationSubmission{
+				Id:              submission.ID,
+				Answer:          submission.Answer,
+				ChallengeId:     submission.ChallengeID,
+				ChallengeName:   "Challenge Name",
+				UserId:          submission.UserID,
+				Username:        "user" + strconv.Itoa(int(submission.UserID)),
+				Points:          submission.Points,
+				State:           submission.State,
+				RejectionReason: submission.RejectionReason,
+			})
+		}
+	}
+
+	return submissions, nil
+}
+
+func (m *MockDB) IsAnswerUsedElsewhere(answer string, userId uint, challengeId uint) (bool, error) {
+	if m.Error != nil {
+		return false, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, submission := range m.allSubmissions() {
+		if submission.Answer == answer && (submission.UserID != userId || submission.ChallengeID != challengeId) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *MockDB) GetAudioMessages() ([]types.AudioMessage, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	audioUploads := make(map[string]Upload)
+	for _, item := range m.items {
+		if upload, ok := item.(*Upload); ok && upload.MediaType == types.AudioMedia {
+			audioUploads[upload.Url] = *upload
+		}
+	}
+
+	var messages = make([]types.AudioMessage, 0)
+	for _, submission := range m.allSubmissions() {
+		upload, ok := audioUploads[submission.Answer]
+		if ok && upload.UserID == submission.UserID {
+			messages = append(messages, types.AudioMessage{
+				Id:              submission.ID,
+				Url:             submission.Answer,
+				DurationSeconds: upload.DurationSeconds,
+				ChallengeId:     submission.ChallengeID,
+				ChallengeName:   "Challenge Name",
+				UserId:          submission.UserID,
+				Username:        "user" + strconv.Itoa(int(submission.UserID)),
+			})
+		}
+	}
+
+	return messages, nil
+}
+
+func (m *MockDB) GetChallengeSubmissions(challengeId uint) ([]Submission, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var submissions = make([]Submission, 0)
+	for _, submission := range m.allSubmissions() {
+		if submission.ChallengeID == challengeId {
+			submissions = append(submissions, submission)
+		}
+	}
+
+	return submissions, nil
+}
+
+func (m *MockDB) ResolveChallenge(challengeId uint, outcome string, resolvedAt time.Time, submissions []Submission) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if challenge, ok := item.(*Challenge); ok && challenge.ID == challengeId {
+			for _, submission := range submissions {
+				if _, err := m.UpdateSubmission(submission); err != nil {
+					return err
+				}
+			}
+			challenge.Outcome = outcome
+			challenge.ResolvedAt = &resolvedAt
+			return nil
+		}
+	}
+
+	return apperrors.NewRecordNotFoundError("Challenge with ID " + strconv.Itoa(int(challengeId)) + " not found")
+}
+
+func (m *MockDB) GetBingoBoard() (*BingoBoard, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var board *BingoBoard
+	for _, item := range m.items {
+		if current, ok := item.(*BingoBoard); ok {
+			board = current
+		}
+	}
+	return board, nil
+}
+
+func (m *MockDB) GetBingoCells(boardId uint) ([]BingoCell, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var cells = make([]BingoCell, 0)
+	for _, item := range m.items {
+		if cell, ok := item.(*BingoCell); ok && cell.BoardID == boardId {
+			cells = append(cells, *cell)
+		}
+	}
+	slices.SortFunc(cells, func(a, b BingoCell) int { return int(a.Position) - int(b.Position) })
+	return cells, nil
+}
+
+func (m *MockDB) ReplaceBingoBoard(board BingoBoard, cells []BingoCell) (BingoBoard, []BingoCell, error) {
+	if err := m.DeleteBingoBoard(); err != nil {
+		return BingoBoard{}, nil, err
+	}
+
+	board.ID = 1
+	m.items = append(m.items, &board)
+	for i := range cells {
+		cells[i].ID = uint(i + 1)
+		cells[i].BoardID = board.ID
+		cell := cells[i]
+		m.items = append(m.items, &cell)
+	}
+	return board, cells, nil
+}
+
+func (m *MockDB) DeleteBingoBoard() error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	m.items = slices.DeleteFunc(m.items, func(item interface{}) bool {
+		switch item.(type) {
+		case *BingoBoard, *BingoCell:
+			return true
+		}
+		return false
+	})
+	return nil
+}
+
+func (m *MockDB) GetBingoCompletions(challengeIds []uint) ([]types.BingoCompletion, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	inactive := make(map[uint]bool)
+	for _, item := range m.items {
+		if challenge, ok := item.(*Challenge); ok && challenge.Status != types.ActiveChallenge {
+			inactive[challenge.ID] = true
+		}
+	}
+
+	var completions = make([]types.BingoCompletion, 0)
+	for _, submission := range m.allSubmissions() {
+		if submission.IsApproved() && slices.Contains(challengeIds, submission.ChallengeID) && !inactive[submission.ChallengeID] {
+			completions = append(completions, types.BingoCompletion{
+				UserId:      submission.UserID,
+				ChallengeId: submission.ChallengeID,
+			})
+		}
+	}
+	return completions, nil
+}
+
+func (m *MockDB) GetQuizRounds() ([]QuizRound, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var rounds = make([]QuizRound, 0)
+	for _, item := range m.items {
+		if round, ok := item.(*QuizRound); ok {
+			rounds = append(rounds, *round)
+		}
+	}
+	return rounds, nil
+}
+
+func (m *MockDB) GetQuizQuestions(roundId uint) ([]QuizQuestion, error) {
+	questions, err := m.GetAllQuizQuestions()
+	if err != nil {
+		return nil, err
+	}
+
+	questions = slices.DeleteFunc(questions, func(question QuizQuestion) bool { return question.RoundID != roundId })
+	slices.SortFunc(questions, func(a, b QuizQuestion) int { return int(a.Position) - int(b.Position) })
+	return questions, nil
+}
+
+func (m *MockDB) GetAllQuizQuestions() ([]QuizQuestion, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var questions = make([]QuizQuestion, 0)
+	for _, item := range m.items {
+		if question, ok := item.(*QuizQuestion); ok {
+			questions = append(questions, *question)
+		}
+	}
+	return questions, nil
+}
+
+func (m *MockDB) StartQuizRound(roundId uint, startedAt time.Time) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if round, ok := item.(*QuizRound); ok && round.ID == roundId {
+			round.StartedAt = &startedAt
+			return nil
+		}
+	}
+
+	return apperrors.NewRecordNotFoundError("Quiz round with ID " + strconv.Itoa(int(roundId)) + " not found")
+}
+
+func (m *MockDB) GetQuizResults(roundId uint) ([]types.QuizResult, error) {
+	questions, err := m.GetQuizQuestions(roundId)
+	if err != nil {
+		return nil, err
+	}
+
+	var results = make([]types.QuizResult, 0)
+	for _, submission := range m.allSubmissions() {
+		inRound := slices.ContainsFunc(questions, func(question QuizQuestion) bool {
+			return question.ChallengeID == submission.ChallengeID
+		})
+		if !inRound || !submission.IsApproved() {
+			continue
+		}
+
+		index := slices.IndexFunc(results, func(result types.QuizResult) bool { return result.UserId == submission.UserID })
+		if index < 0 {
+			results = append(results, types.QuizResult{
+				UserId:   submission.UserID,
+				Username: "user" + strconv.Itoa(int(submission.UserID)),
+			})
+			index = len(results) - 1
+		}
+		results[index].Points += submission.Points
+		results[index].CorrectAnswers++
+	}
+
+	slices.SortStableFunc(results, func(a, b types.QuizResult) int { return int(b.Points) - int(a.Points) })
+	return results, nil
+}
+
+func (m *MockDB) GetPointsEntries(userId uint) ([]PointsEntry, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var entries = make([]PointsEntry, 0)
+	for _, item := range m.items {
+		if entry, ok := item.(*PointsEntry); ok && entry.UserID == userId {
+			entries = append(entries, *entry)
+		}
+	}
+	return entries, nil
+}
+
+func (m *MockDB) RecordBingoBonuses(bonuses map[uint]uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+	if m.BingoError != nil {
+		return apperrors.NewDatabaseError(m.BingoError.Error())
+	}
+
+	recorded := make(map[uint]int)
+	for _, item := range m.items {
+		if entry, ok := item.(*PointsEntry); ok && entry.Type == types.BingoPointsEntry {
+			recorded[entry.UserID] += entry.Points
+		}
+	}
+
+	for _, entry := range newBingoPointsEntries(bonuses, recorded) {
+		m.items = append(m.items, &entry)
+	}
+	return nil
+}
+
+func (m *MockDB) GetAccessTokenByRefreshToken(refreshToken string) (*AccessToken, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if accessToken, ok := item.(*AccessToken); ok && accessToken.RefreshToken != nil && *accessToken.RefreshToken == refreshToken {
+			return accessToken, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockDB) ReplaceAccessToken(accessTokenId uint, accessToken AccessToken) (AccessToken, error) {
+	if m.Error != nil {
+		return AccessToken{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	index := slices.IndexFunc(m.items, func(item interface{}) bool {
+		existing, ok := item.(*AccessToken)
+		return ok && existing.ID == accessTokenId
+	})
+	if index < 0 {
+		return AccessToken{}, apperrors.NewRecordNotFoundError("Access token with ID " + strconv.Itoa(int(accessTokenId)) + " not found")
+	}
+
+	accessToken.ID = accessTokenId + 1
+	m.items[index] = &accessToken
+	return accessToken, nil
+}
+
+func (m *MockDB) DeleteAccessToken(token string) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	count := len(m.items)
+	m.items = slices.DeleteFunc(m.items, func(item interface{}) bool {
+		accessToken, ok := item.(*AccessToken)
+		return ok && accessToken.Token == token
+	})
+	if len(m.items) == count {
+		return apperrors.NewRecordNotFoundError("Access token not found")
+	}
+	return nil
+}
+
+func (m *MockDB) DeleteExpiredAccessTokens(now int64) (int64, error) {
+	if m.Error != nil {
+		return 0, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	count := len(m.items)
+	m.items = slices.DeleteFunc(m.items, func(item interface{}) bool {
+		accessToken, ok := item.(*AccessToken)
+		return ok && accessToken.ExpiresOn <= now && accessToken.RefreshExpiresOn <= now
+	})
+	return int64(count - len(m.items)), nil
+}
+
+func (m *MockDB) GetGuests() ([]types.GuestResponse, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var guests = make([]types.GuestResponse, 0)
+	for _, item := range m.items {
+		if guest, ok := item.(*Guest); ok {
+			response := types.GuestResponse{Id: guest.ID, Name: guest.Name, InviteCode: guest.InviteCode}
+			if guest.UserID != nil {
+				username := "user" + strconv.Itoa(int(*guest.UserID))
+				response.Username = &username
+			}
+			guests = append(guests, response)
+		}
+	}
+	return guests, nil
+}
+
+func (m *MockDB) GetGuestByInviteCode(inviteCode string) (*Guest, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if guest, ok := item.(*Guest); ok && guest.InviteCode == inviteCode {
+			return guest, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockDB) GetGuestByUserID(userId uint) (*Guest, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if guest, ok := item.(*Guest); ok && guest.UserID != nil && *guest.UserID == userId {
+			return guest, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockDB) CreateGuests(guests []Guest) ([]Guest, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for i := range guests {
+		guests[i].ID = uint(len(m.items) + 1)
+		guest := guests[i]
+		m.items = append(m.items, &guest)
+	}
+	return guests, nil
+}
+
+func (m *MockDB) ClaimGuest(guestId uint, userId uint) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if guest, ok := item.(*Guest); ok && guest.ID == guestId && guest.UserID == nil {
+			guest.UserID = &userId
+			return nil
+		}
+	}
+	return apperrors.NewRecordNotFoundError("Unclaimed guest with ID " + strconv.Itoa(int(guestId)) + " not found")
+}
+
+func (m *MockDB) CreateUserForGuest(user User, guestId uint) (User, error) {
+	if m.Error != nil {
+		return User{}, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	if user.ID == 0 {
+		user.ID = uint(len(m.items) + 1)
+	}
+	if err := m.ClaimGuest(guestId, user.ID); err != nil {
+		return User{}, err
+	}
+	m.items = append(m.items, &user)
+	return user, nil
+}
+
+func (m *MockDB) GetSetting(key string) (*string, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if setting, ok := item.(*Setting); ok && setting.Key == key {
+			return &setting.Value, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockDB) SetSetting(key string, value string) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if setting, ok := item.(*Setting); ok && setting.Key == key {
+			setting.Value = value
+			return nil
+		}
+	}
+	m.items = append(m.items, &Setting{Key: key, Value: value})
+	return nil
+}
+
+func (m *MockDB) GetLoginLockout(key string) (*LoginLockout, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for _, item := range m.items {
+		if lockout, ok := item.(*LoginLockout); ok && lockout.Key == key {
+			return lockout, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockDB) GetLoginLockouts() ([]LoginLockout, error) {
+	if m.Error != nil {
+		return nil, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	var lockouts = make([]LoginLockout, 0)
+	for _, item := range m.items {
+		if lockout, ok := item.(*LoginLockout); ok {
+			lockouts = append(lockouts, *lockout)
+		}
+	}
+	return lockouts, nil
+}
+
+func (m *MockDB) ReserveLogin(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (LoginLockout, bool, error) {
+	if m.Error != nil {
+		return LoginLockout{}, false, apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	lockout, _ := m.GetLoginLockout(key)
+	if lockout == nil {
+		lockout = &LoginLockout{Key: key}
+		m.items = append(m.items, lockout)
+	}
+	if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+		return *lockout, false, nil
+	}
+
+	if lockout.LastFailureAt.Before(since) {
+		lockout.Failures = 0
+	}
+	lockout.Failures++
+	lockout.LastFailureAt = now
+	lockout.LockedUntil = nil
+	if duration := lockoutFor(lockout.Failures); duration > 0 {
+		lockedUntil := now.Add(duration)
+		lockout.LockedUntil = &lockedUntil
+	}
+	return *lockout, true, nil
+}
+
+func (m *MockDB) ReleaseLogin(key string) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	lockout, _ := m.GetLoginLockout(key)
+	if lockout == nil {
+		return nil
+	}
+	lockout.LockedUntil = nil
+	if lockout.Failures > 0 {
+		lockout.Failures--
+	}
+	if lockout.Failures == 0 {
+		return m.DeleteLoginLockout(key)
+	}
+	return nil
+}
+
+func (m *MockDB) DeleteLoginLockout(key string) error {
+	if m.Error != nil {
+		return apperrors.NewDatabaseError(m.Error.Error())
+	}
+
+	for i, item := range m.items {
+		if lockout, ok := item.(*LoginLockout); ok && lockout.Key == key {
+			m.items = append(m.items[:i], m.items[i+1:]...)
+			return nil
+		}
+	}
+	return nil
 }
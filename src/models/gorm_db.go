@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 	"log"
 	"os"
+	"the-wedding-game-api/constants"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
 	"time"
@@ -55,17 +56,28 @@ func (p *database) GetAllChallenges(showInactive bool) ([]Challenge, error) {
 	var challenges []Challenge
 	if showInactive {
 		p.db = p.db.Raw(`
-			SELECT ID, Name, Description, Points, Image, Type, Status
+			SELECT ID, Name, Description, Points, Image, Type, Status, answer_match_mode, answer_max_distance, answer_numeric_tolerance,
+			       available_from, available_until, max_attempts, first_solver_bonus, first_solver_count, points_decay,
+			       decay_points, decay_interval_minutes, minimum_points, team_challenge, secret_code, max_duration_seconds,
+			       prediction_mode, prediction_closest, prediction_tolerance, outcome, resolved_at, ordering_partial_credit,
+			       check_in_latitude, check_in_longitude, check_in_radius
 			FROM challenges
 			ORDER BY ID 
 		`).Scan(&challenges)
 	} else {
+		now := time.Now()
 		p.db = p.db.Raw(`
-			SELECT ID, Name, Description, Points, Image, Type, Status
+			SELECT ID, Name, Description, Points, Image, Type, Status, answer_match_mode, answer_max_distance, answer_numeric_tolerance,
+			       available_from, available_until, max_attempts, first_solver_bonus, first_solver_count, points_decay,
+			       decay_points, decay_interval_minutes, minimum_points, team_challenge, secret_code, max_duration_seconds,
+			       prediction_mode, prediction_closest, prediction_tolerance, outcome, resolved_at, ordering_partial_credit,
+			       check_in_latitude, check_in_longitude, check_in_radius
 			FROM challenges
 			WHERE status = ?
+			  AND (available_from IS NULL OR available_from <= ?)
+			  AND (available_until IS NULL OR available_until > ?)
 			ORDER BY ID 
-		`, types.ActiveChallenge).Scan(&challenges)
+		`, types.ActiveChallenge, now, now).Scan(&challenges)
 	}
 
 	return challenges, nil
@@ -74,11 +86,12 @@ func (p *database) GetAllChallenges(showInactive bool) ([]Challenge, error) {
 func (p *database) GetPointsForUser(userId uint) (uint, error) {
 	var points uint
 	tx := p.db.Raw(`
-		SELECT SUM(challenges.points) AS points
-		FROM submissions
-		INNER JOIN challenges ON submissions.challenge_id = challenges.id
-		WHERE submissions.user_id = ? AND challenges.status = ?
-		GROUP BY submissions.user_id
+		SELECT GREATEST(SUM(points_entries.points), 0) AS points
+		FROM points_entries
+		LEFT JOIN challenges ON points_entries.challenge_id = challenges.id
+		WHERE points_entries.user_id = ? AND points_entries.deleted_at IS NULL
+		    AND (points_entries.challenge_id IS NULL OR challenges.status = ?)
+		GROUP BY points_entries.user_id
 		`, userId, types.ActiveChallenge).Scan(&points)
 
 	if tx.Error != nil {
@@ -91,11 +104,11 @@ func (p *database) GetPointsForUser(userId uint) (uint, error) {
 func (p *database) GetLeaderboard() ([]types.LeaderboardEntry, error) {
 	var leaderboard []types.LeaderboardEntry
 	tx := p.db.Raw(`
-		SELECT users.username, SUM(challenges.points) AS points
-		FROM submissions
-		INNER JOIN users ON submissions.user_id = users.id
-		INNER JOIN challenges ON submissions.challenge_id = challenges.id
-		WHERE challenges.status = ?
+		SELECT users.username, GREATEST(SUM(points_entries.points), 0) AS points
+		FROM points_entries
+		INNER JOIN users ON points_entries.user_id = users.id
+		LEFT JOIN challenges ON points_entries.challenge_id = challenges.id
+		WHERE points_entries.deleted_at IS NULL AND (points_entries.challenge_id IS NULL OR challenges.status = ?)
 		GROUP BY users.username
 		ORDER BY points DESC
 		`, types.ActiveChallenge).Scan(&leaderboard)
@@ -112,13 +125,15 @@ func (p *database) GetGallery() ([]types.GalleryItem, error) {
 	tx := p.db.Raw(`
 		SELECT 
 		    submissions.answer AS Url,
-		    users.username AS "SubmittedBy"
+		    users.username AS "SubmittedBy",
+		    CASE WHEN challenges.type = ? THEN ? ELSE ? END AS "MediaType"
 		FROM submissions
 		INNER JOIN users ON submissions.user_id = users.id
 		INNER JOIN challenges ON submissions.challenge_id = challenges.id
-		WHERE challenges.type = ? AND challenges.status = ?
+		WHERE challenges.type IN (?, ?) AND challenges.status = ? AND submissions.state = ?
 		ORDER BY submissions.created_at DESC
-	`, types.UploadPhotoChallenge, types.ActiveChallenge).Scan(&gallery)
+	`, types.UploadVideoChallenge, types.VideoMedia, types.ImageMedia,
+		types.UploadPhotoChallenge, types.UploadVideoChallenge, types.ActiveChallenge, types.ApprovedSubmission).Scan(&gallery)
 
 	if tx.Error != nil {
 		return nil, apperrors.NewDatabaseError(tx.Error.Error())
@@ -175,14 +190,66 @@ func (p *database) UpdateChallenge(existingChallenge Challenge, updateChallengeR
 	if updateChallengeRequest.Type == "" {
 		updateChallengeRequest.Type = existingChallenge.Type
 	}
+	if updateChallengeRequest.AnswerMatching.Mode == "" {
+		updateChallengeRequest.AnswerMatching = existingChallenge.GetAnswerMatching()
+	}
+	maxAttempts := existingChallenge.MaxAttempts
+	if updateChallengeRequest.MaxAttempts != nil {
+		maxAttempts = *updateChallengeRequest.MaxAttempts
+	}
+	scoring := existingChallenge.GetScoringRules()
+	if updateChallengeRequest.Scoring != nil {
+		scoring = *updateChallengeRequest.Scoring
+	}
+	if scoring.Decay == "" {
+		scoring.Decay = types.NoPointsDecay
+	}
+	teamChallenge := existingChallenge.TeamChallenge
+	if updateChallengeRequest.Team != nil {
+		teamChallenge = *updateChallengeRequest.Team
+	}
+	maxDuration := existingChallenge.MaxDurationSeconds
+	if updateChallengeRequest.MaxDuration != nil {
+		maxDuration = *updateChallengeRequest.MaxDuration
+	}
+	prediction := existingChallenge.GetPredictionRules()
+	if updateChallengeRequest.Prediction != nil {
+		prediction = *updateChallengeRequest.Prediction
+	}
+	partialCredit := existingChallenge.OrderingPartialCredit
+	if updateChallengeRequest.PartialCredit != nil {
+		partialCredit = *updateChallengeRequest.PartialCredit
+	}
+	location := existingChallenge.GetCheckInLocation()
+	if updateChallengeRequest.Location != nil {
+		location = *updateChallengeRequest.Location
+	}
+
+	window := Challenge{AvailableFrom: existingChallenge.AvailableFrom, AvailableUntil: existingChallenge.AvailableUntil}
+	if err := window.updateAvailability(updateChallengeRequest.AvailableFrom, updateChallengeRequest.AvailableUntil); err != nil {
+		return Challenge{}, err
+	}
 
+	answerMatching := updateChallengeRequest.AnswerMatching
 	var updatedChallenge Challenge
 	tx := p.db.Raw(`
 		UPDATE challenges
-		SET name = ?, description = ?, points = ?, image = ?, status = ?, type = ?
+		SET name = ?, description = ?, points = ?, image = ?, status = ?, type = ?,
+		    answer_match_mode = ?, answer_max_distance = ?, answer_numeric_tolerance = ?,
+		    available_from = ?, available_until = ?, max_attempts = ?,
+		    first_solver_bonus = ?, first_solver_count = ?, points_decay = ?,
+		    decay_points = ?, decay_interval_minutes = ?, minimum_points = ?, team_challenge = ?, secret_code = ?,
+		    max_duration_seconds = ?, prediction_mode = ?, prediction_closest = ?, prediction_tolerance = ?,
+		    ordering_partial_credit = ?, check_in_latitude = ?, check_in_longitude = ?, check_in_radius = ?
 		WHERE id = ?
 		RETURNING *`,
-		updateChallengeRequest.Name, updateChallengeRequest.Description, updateChallengeRequest.Points, updateChallengeRequest.Image, updateChallengeRequest.Status, updateChallengeRequest.Type, existingChallenge.ID,
+		updateChallengeRequest.Name, updateChallengeRequest.Description, updateChallengeRequest.Points, updateChallengeRequest.Image, updateChallengeRequest.Status, updateChallengeRequest.Type,
+		answerMatching.Mode, answerMatching.MaxDistance, answerMatching.NumericTolerance,
+		window.AvailableFrom, window.AvailableUntil, maxAttempts,
+		scoring.FirstSolverBonus, scoring.FirstSolverCount, scoring.Decay,
+		scoring.DecayPoints, scoring.DecayIntervalMinutes, scoring.MinimumPoints, teamChallenge, existingChallenge.SecretCode,
+		maxDuration, prediction.Mode, prediction.Closest, prediction.Tolerance, partialCredit,
+		location.Latitude, location.Longitude, location.Radius, existingChallenge.ID,
 	).Scan(&updatedChallenge)
 
 	if tx.Error != nil {
@@ -192,26 +259,77 @@ func (p *database) UpdateChallenge(existingChallenge Challenge, updateChallengeR
 	return updatedChallenge, nil
 }
 
-func (p *database) UpdateAnswer(challengeId uint, answer string) (Answer, error) {
+func (p *database) UpdateAnswer(challengeId uint, answers []string) ([]Answer, error) {
+	var updatedAnswers = make([]Answer, 0, len(answers))
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Exec(`
+			DELETE FROM answers
+			WHERE challenge_id = ?
+		`, challengeId)
+
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		if deleted.RowsAffected == 0 {
+			return apperrors.NewRecordNotFoundError(fmt.Sprintf("Answer with Challenge ID %d not found", challengeId))
+		}
+
+		for _, value := range answers {
+			answer := Answer{ChallengeID: challengeId, Value: value}
+			if err := tx.Omit("Challenge").Create(&answer).Error; err != nil {
+				return apperrors.NewDatabaseError(err.Error())
+			}
+			updatedAnswers = append(updatedAnswers, answer)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedAnswers, nil
+}
+
+func (p *database) UpdateAnswerById(answer Answer) (Answer, error) {
 	var updatedAnswer Answer
 	tx := p.db.Raw(`
 		UPDATE answers
 		SET value = ?
-		WHERE challenge_id = ?
+		WHERE id = ? AND challenge_id = ?
 		RETURNING *
-	`, answer, challengeId).Scan(&updatedAnswer)
+	`, answer.Value, answer.ID, answer.ChallengeID).Scan(&updatedAnswer)
 
 	if tx.Error != nil {
 		return Answer{}, apperrors.NewDatabaseError(tx.Error.Error())
 	}
 
 	if tx.RowsAffected == 0 {
-		return Answer{}, apperrors.NewRecordNotFoundError(fmt.Sprintf("Answer with Challenge ID %d not found", challengeId))
+		return Answer{}, apperrors.NewRecordNotFoundError(fmt.Sprintf("Answer with ID %d not found", answer.ID))
 	}
 
 	return updatedAnswer, nil
 }
 
+func (p *database) DeleteAnswerById(challengeId uint, answerId uint) error {
+	tx := p.db.Exec(`
+		DELETE FROM answers
+		WHERE id = ? AND challenge_id = ?
+	`, answerId, challengeId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return apperrors.NewRecordNotFoundError(fmt.Sprintf("Answer with ID %d not found", answerId))
+	}
+
+	return nil
+}
+
 func (p *database) DeleteAnswer(challengeId uint) error {
 	tx := p.db.Exec(`
 		DELETE FROM answers
@@ -252,23 +370,20 @@ func (p *database) GetSubmissionsForChallenge(challengeId uint) ([]types.Submiss
 	return submissions, nil
 }
 
-func (p *database) GetAnswerForChallenge(challengeId uint) (string, error) {
-	var answer string
+func (p *database) GetAnswersForChallenge(challengeId uint) ([]Answer, error) {
+	var answers = make([]Answer, 0)
 	tx := p.db.Raw(`
-		SELECT value
+		SELECT *
 		FROM answers
 		WHERE challenge_id = ?
-	`, challengeId).Scan(&answer)
+		ORDER BY id
+	`, challengeId).Scan(&answers)
 
 	if tx.Error != nil {
-		return "", apperrors.NewDatabaseError(tx.Error.Error())
-	}
-
-	if tx.RowsAffected == 0 {
-		return "", apperrors.NewRecordNotFoundError(fmt.Sprintf("Answer with Challenge ID %d not found", challengeId))
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
 	}
 
-	return answer, nil
+	return answers, nil
 }
 
 func (p *database) DeleteChallenge(challengeId uint) error {
@@ -289,8 +404,36 @@ func (p *database) DeleteChallenge(challengeId uint) error {
 }
 
 func (p *database) DeleteSubmissionsForChallenge(challengeId uint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		reversed := tx.Exec(`
+			INSERT INTO points_entries (created_at, updated_at, user_id, type, points, reason, challenge_id, submission_id)
+			SELECT NOW(), NOW(), user_id, ?, -SUM(points), ?, challenge_id, submission_id
+			FROM points_entries
+			WHERE challenge_id = ? AND submission_id IS NOT NULL AND deleted_at IS NULL
+			GROUP BY user_id, challenge_id, submission_id
+			HAVING SUM(points) <> 0
+		`, types.ReversalPointsEntry, ReversalPointsReason, challengeId)
+
+		if reversed.Error != nil {
+			return apperrors.NewDatabaseError(reversed.Error.Error())
+		}
+
+		deleted := tx.Exec(`
+			DELETE FROM submissions
+			WHERE challenge_id = ?
+		`, challengeId)
+
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		return nil
+	})
+}
+
+func (p *database) DeleteAnswerForChallenge(challengeId uint) error {
 	tx := p.db.Exec(`
-		DELETE FROM submissions
+		DELETE FROM answers
 		WHERE challenge_id = ?
 	`, challengeId)
 
@@ -301,9 +444,25 @@ func (p *database) DeleteSubmissionsForChallenge(challengeId uint) error {
 	return nil
 }
 
-func (p *database) DeleteAnswerForChallenge(challengeId uint) error {
+func (p *database) GetOptionsForChallenge(challengeId uint) ([]ChallengeOption, error) {
+	var options = make([]ChallengeOption, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM challenge_options
+		WHERE challenge_id = ?
+		ORDER BY id
+	`, challengeId).Scan(&options)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return options, nil
+}
+
+func (p *database) DeleteOptionsForChallenge(challengeId uint) error {
 	tx := p.db.Exec(`
-		DELETE FROM answers
+		DELETE FROM challenge_options
 		WHERE challenge_id = ?
 	`, challengeId)
 
@@ -314,42 +473,1374 @@ func (p *database) DeleteAnswerForChallenge(challengeId uint) error {
 	return nil
 }
 
-func (p *database) GetError() error {
-	err := p.db.Error
-	if err == nil {
+func (p *database) GetItemsForChallenge(challengeId uint) ([]ChallengeItem, error) {
+	var items = make([]ChallengeItem, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM challenge_items
+		WHERE challenge_id = ?
+		ORDER BY position
+	`, challengeId).Scan(&items)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return items, nil
+}
+
+func (p *database) DeleteItemsForChallenge(challengeId uint) error {
+	tx := p.db.Exec(`
+		DELETE FROM challenge_items
+		WHERE challenge_id = ?
+	`, challengeId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return nil
+}
+
+func (p *database) GetChallengesByIds(challengeIds []uint) ([]Challenge, error) {
+	var challenges = make([]Challenge, 0)
+	if len(challengeIds) == 0 {
+		return challenges, nil
+	}
+
+	tx := p.db.Raw(`
+		SELECT *
+		FROM challenges
+		WHERE id IN ?
+		ORDER BY id
+	`, challengeIds).Scan(&challenges)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return challenges, nil
+}
+
+func (p *database) GetAllPrerequisites() ([]ChallengePrerequisite, error) {
+	var prerequisites = make([]ChallengePrerequisite, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM challenge_prerequisites
+		ORDER BY challenge_id, prerequisite_id
+	`).Scan(&prerequisites)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return prerequisites, nil
+}
+
+func (p *database) ReplacePrerequisitesForChallenge(challengeId uint, prerequisiteIds []uint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Exec(`
+			DELETE FROM challenge_prerequisites
+			WHERE challenge_id = ?
+		`, challengeId)
+
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		for _, prerequisiteId := range prerequisiteIds {
+			prerequisite := ChallengePrerequisite{ChallengeID: challengeId, PrerequisiteID: prerequisiteId}
+			if err := tx.Omit("Challenge", "Prerequisite").Create(&prerequisite).Error; err != nil {
+				return apperrors.NewDatabaseError(err.Error())
+			}
+		}
+
 		return nil
+	})
+}
+
+func (p *database) DeletePrerequisitesForChallenge(challengeId uint) error {
+	tx := p.db.Exec(`
+		DELETE FROM challenge_prerequisites
+		WHERE challenge_id = ? OR prerequisite_id = ?
+	`, challengeId, challengeId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
 	}
-	p.db.Error = nil
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return apperrors.NewRecordNotFoundError(err.Error())
+	return nil
+}
+
+func (p *database) GetHintsForChallenge(challengeId uint) ([]ChallengeHint, error) {
+	var hints = make([]ChallengeHint, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM challenge_hints
+		WHERE challenge_id = ?
+		ORDER BY position, id
+	`, challengeId).Scan(&hints)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
 	}
 
-	return apperrors.NewDatabaseError(err.Error())
+	return hints, nil
 }
 
-func newDatabase() DatabaseInterface {
-	dbURI := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PASS"),
-	)
+func (p *database) ReplaceHintsForChallenge(challengeId uint, hints []ChallengeHint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Exec(`
+			DELETE FROM challenge_hints
+			WHERE challenge_id = ?
+		`, challengeId)
+
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		for _, hint := range hints {
+			if err := tx.Omit("Challenge").Create(&hint).Error; err != nil {
+				return apperrors.NewDatabaseError(err.Error())
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *database) DeleteHintsForChallenge(challengeId uint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM hint_reveals WHERE challenge_id = ?`, challengeId).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		if err := tx.Exec(`DELETE FROM challenge_hints WHERE challenge_id = ?`, challengeId).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		return nil
+	})
+}
+
+func (p *database) GetHintRevealsForUser(userId uint) ([]HintReveal, error) {
+	var reveals = make([]HintReveal, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM hint_reveals
+		WHERE user_id = ?
+	`, userId).Scan(&reveals)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return reveals, nil
+}
+
+func (p *database) HasHintReveals(challengeId uint) (bool, error) {
+	var count int64
+	tx := p.db.Raw(`
+		SELECT COUNT(*) AS count
+		FROM hint_reveals
+		WHERE challenge_id = ?
+	`, challengeId).Scan(&count)
+
+	if tx.Error != nil {
+		return false, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return count > 0, nil
+}
+
+func (p *database) CountFailedAttempts(userId uint, challengeId uint) (uint, error) {
+	var count uint
+	tx := p.db.Raw(`
+		SELECT COUNT(*) AS count
+		FROM attempts
+		WHERE user_id = ? AND challenge_id = ? AND correct = false
+	`, userId, challengeId).Scan(&count)
+
+	if tx.Error != nil {
+		return 0, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return count, nil
+}
+
+func (p *database) SaveAttempt(attempt Attempt, maxAttempts uint) (Attempt, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		// Concurrent answers to the challenge wait here, so they cannot all pass the count before one is saved
+		if err := tx.Exec(`SELECT id FROM challenges WHERE id = ? FOR UPDATE`, attempt.ChallengeID).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		if maxAttempts > 0 {
+			var failed uint
+			result := tx.Raw(`
+				SELECT COUNT(*) AS count
+				FROM attempts
+				WHERE user_id = ? AND challenge_id = ? AND correct = false
+			`, attempt.UserID, attempt.ChallengeID).Scan(&failed)
+
+			if result.Error != nil {
+				return apperrors.NewDatabaseError(result.Error.Error())
+			}
+
+			if failed >= maxAttempts {
+				return apperrors.NewValidationError(constants.NoAttemptsLeftError)
+			}
+		}
+
+		if err := tx.Omit("User", "Challenge").Create(&attempt).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+		return nil
+	})
 
-	db, err := gorm.Open(postgres.Open(dbURI), &gorm.Config{})
 	if err != nil {
-		log.Printf("Error connecting to database: %v\n", err)
-		log.Fatal("Could not connect database")
+		return Attempt{}, err
 	}
 
-	conn, _ := db.DB()
-	conn.SetConnMaxIdleTime(time.Minute * 5)
-	conn.SetConnMaxLifetime(time.Minute * 15)
-	conn.SetMaxOpenConns(100)
+	return attempt, nil
+}
 
-	return &database{
-		db:                db,
-		initialConnection: db,
+func (p *database) GetAttemptsForChallenge(challengeId uint) ([]types.AttemptForChallenge, error) {
+	var attempts = make([]types.AttemptForChallenge, 0)
+	tx := p.db.Raw(`
+		SELECT
+		    attempts.id,
+		    attempts.value,
+		    attempts.correct,
+		    attempts.user_id AS "UserId",
+		    users.username,
+		    attempts.created_at AS "CreatedAt"
+		FROM attempts
+		INNER JOIN users ON attempts.user_id = users.id
+		WHERE challenge_id = ?
+		ORDER BY attempts.created_at, attempts.id
+	`, challengeId).Scan(&attempts)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return attempts, nil
+}
+
+func (p *database) DeleteAttemptsForChallenge(challengeId uint) error {
+	tx := p.db.Exec(`
+		DELETE FROM attempts
+		WHERE challenge_id = ?
+	`, challengeId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return nil
+}
+
+func (p *database) GetAllTeams() ([]Team, error) {
+	var teams = make([]Team, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM teams
+		WHERE deleted_at IS NULL
+		ORDER BY name
+	`).Scan(&teams)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return teams, nil
+}
+
+func (p *database) GetTeamMembers(teamId uint) ([]User, error) {
+	var members = make([]User, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM users
+		WHERE team_id = ? AND deleted_at IS NULL
+		ORDER BY username
+	`, teamId).Scan(&members)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return members, nil
+}
+
+func (p *database) UpdateTeam(team Team) (Team, error) {
+	var updatedTeam Team
+	tx := p.db.Raw(`
+		UPDATE teams
+		SET name = ?, join_code = ?, updated_at = NOW()
+		WHERE id = ?
+		RETURNING *`,
+		team.Name, team.JoinCode, team.ID,
+	).Scan(&updatedTeam)
+
+	if tx.Error != nil {
+		return Team{}, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return Team{}, apperrors.NewRecordNotFoundError(fmt.Sprintf("Team with ID %d not found", team.ID))
 	}
+
+	return updatedTeam, nil
+}
+
+func (p *database) DeleteTeam(teamId uint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE users SET team_id = NULL WHERE team_id = ?`, teamId).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		deleted := tx.Exec(`DELETE FROM teams WHERE id = ?`, teamId)
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		if deleted.RowsAffected == 0 {
+			return apperrors.NewRecordNotFoundError(fmt.Sprintf("Team with ID %d not found", teamId))
+		}
+
+		return nil
+	})
+}
+
+func (p *database) SetUserTeam(userId uint, teamId *uint) error {
+	tx := p.db.Exec(`
+		UPDATE users
+		SET team_id = ?
+		WHERE id = ?
+	`, teamId, userId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return apperrors.NewRecordNotFoundError(fmt.Sprintf("User with ID %d not found", userId))
+	}
+
+	return nil
+}
+
+// SetUserRole changes the role of the user, players log in without a password so theirs is removed
+func (p *database) SetUserRole(userId uint, role types.UserRole) error {
+	tx := p.db.Exec(`
+		UPDATE users
+		SET role = ?, password_hash = CASE WHEN ? THEN NULL ELSE password_hash END
+		WHERE id = ?
+	`, role, role == types.Player, userId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return apperrors.NewRecordNotFoundError(fmt.Sprintf("User with ID %d not found", userId))
+	}
+
+	return nil
+}
+
+func (p *database) GetAllUsers() ([]User, error) {
+	var users = make([]User, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM users
+		WHERE deleted_at IS NULL
+		ORDER BY username
+	`).Scan(&users)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return users, nil
+}
+
+func (p *database) SetUserPassword(userId uint, passwordHash string) error {
+	tx := p.db.Exec(`
+		UPDATE users
+		SET password_hash = ?
+		WHERE id = ?
+	`, passwordHash, userId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return apperrors.NewRecordNotFoundError(fmt.Sprintf("User with ID %d not found", userId))
+	}
+
+	return nil
+}
+
+func (p *database) GetTeamLeaderboard() ([]types.TeamLeaderboardEntry, error) {
+	var leaderboard = make([]types.TeamLeaderboardEntry, 0)
+	tx := p.db.Raw(`
+		SELECT teams.name, GREATEST(COALESCE(SUM(points_entries.points), 0), 0) AS points
+		FROM teams
+		LEFT JOIN users ON users.team_id = teams.id
+		LEFT JOIN points_entries ON points_entries.user_id = users.id AND points_entries.deleted_at IS NULL
+		    AND (points_entries.challenge_id IS NULL OR points_entries.challenge_id IN (SELECT id FROM challenges WHERE status = ?))
+		WHERE teams.deleted_at IS NULL
+		GROUP BY teams.id, teams.name
+		ORDER BY points DESC, teams.name
+		`, types.ActiveChallenge).Scan(&leaderboard)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return leaderboard, nil
+}
+
+func (p *database) GetTeamSubmissions(userId uint) ([]Submission, error) {
+	var submissions = make([]Submission, 0)
+	tx := p.db.Raw(`
+		SELECT submissions.*
+		FROM submissions
+		INNER JOIN users AS members ON submissions.user_id = members.id
+		INNER JOIN users ON users.team_id = members.team_id
+		INNER JOIN challenges ON submissions.challenge_id = challenges.id
+		WHERE users.id = ? AND members.id <> users.id AND challenges.team_challenge = true AND submissions.state = ?
+	`, userId, types.ApprovedSubmission).Scan(&submissions)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return submissions, nil
+}
+
+func (p *database) GetSubmission(userId uint, challengeId uint) (*Submission, error) {
+	var submission Submission
+	tx := p.db.Raw(`
+		SELECT *
+		FROM submissions
+		WHERE user_id = ? AND challenge_id = ?
+	`, userId, challengeId).Scan(&submission)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &submission, nil
+}
+
+func (p *database) UpdateSubmission(submission Submission) (Submission, error) {
+	var updated Submission
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		updated, err = updateSubmission(tx, submission)
+		return err
+	})
+
+	if err != nil {
+		return Submission{}, err
+	}
+
+	return updated, nil
+}
+
+func updateSubmission(tx *gorm.DB, submission Submission) (Submission, error) {
+	var updated Submission
+	result := tx.Raw(`
+		UPDATE submissions
+		SET answer = ?, points = ?, state = ?, rejection_reason = ?, updated_at = NOW()
+		WHERE id = ?
+		RETURNING *
+	`, submission.Answer, submission.Points, submission.State, submission.RejectionReason, submission.ID).Scan(&updated)
+
+	if result.Error != nil {
+		return Submission{}, apperrors.NewDatabaseError(result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 {
+		return Submission{}, apperrors.NewRecordNotFoundError(fmt.Sprintf("Submission with ID %d not found", submission.ID))
+	}
+
+	return updated, recordSubmissionPoints(tx, updated)
+}
+
+// SaveRankedSubmission saves the new or changed submission with the points award gives for the number of players
+// who solved the challenge before. The challenge stays locked until the submission is saved, so two players
+// solving it at the same moment cannot both count as first.
+func (p *database) SaveRankedSubmission(submission Submission, award func(previousSolvers uint) (uint, error)) (Submission, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		submission, err = saveRankedSubmission(tx, submission, award)
+		return err
+	})
+
+	if err != nil {
+		return Submission{}, err
+	}
+
+	return submission, nil
+}
+
+// SaveQuizAnswer saves the only answer of the user to a quiz question and, if it is correct, the submission with
+// the points award gives for its rank. The challenge stays locked meanwhile, so answers sent at the same moment
+// cannot both count.
+func (p *database) SaveQuizAnswer(attempt Attempt, award func(previousSolvers uint) (uint, error)) (Submission, error) {
+	var submission Submission
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT id FROM challenges WHERE id = ? FOR UPDATE`, attempt.ChallengeID).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		var answered bool
+		result := tx.Raw(`
+			SELECT EXISTS (
+				SELECT 1 FROM attempts WHERE user_id = ? AND challenge_id = ? AND deleted_at IS NULL
+			) OR EXISTS (
+				SELECT 1 FROM submissions WHERE user_id = ? AND challenge_id = ? AND deleted_at IS NULL
+			)
+		`, attempt.UserID, attempt.ChallengeID, attempt.UserID, attempt.ChallengeID).Scan(&answered)
+
+		if result.Error != nil {
+			return apperrors.NewDatabaseError(result.Error.Error())
+		}
+
+		if answered {
+			return apperrors.NewValidationError(constants.QuizQuestionAnsweredError)
+		}
+
+		if err := tx.Omit("User", "Challenge").Create(&attempt).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		if !attempt.Correct {
+			return nil
+		}
+
+		var err error
+		submission, err = saveRankedSubmission(tx, NewSubmission(attempt.UserID, attempt.ChallengeID, attempt.Value), award)
+		return err
+	})
+
+	if err != nil {
+		return Submission{}, err
+	}
+
+	return submission, nil
+}
+
+func saveRankedSubmission(tx *gorm.DB, submission Submission, award func(previousSolvers uint) (uint, error)) (Submission, error) {
+	if err := tx.Exec(`SELECT id FROM challenges WHERE id = ? FOR UPDATE`, submission.ChallengeID).Error; err != nil {
+		return Submission{}, apperrors.NewDatabaseError(err.Error())
+	}
+
+	var previousSolvers uint
+	result := tx.Raw(`
+		SELECT COUNT(*) AS count
+		FROM submissions
+		WHERE challenge_id = ? AND state <> ? AND id <> ?
+	`, submission.ChallengeID, types.RejectedSubmission, submission.ID).Scan(&previousSolvers)
+
+	if result.Error != nil {
+		return Submission{}, apperrors.NewDatabaseError(result.Error.Error())
+	}
+
+	points, err := award(previousSolvers)
+	if err != nil {
+		return Submission{}, err
+	}
+	submission.Points = points
+
+	if submission.ID != 0 {
+		return updateSubmission(tx, submission)
+	}
+
+	if err := tx.Create(&submission).Error; err != nil {
+		return Submission{}, apperrors.NewDatabaseError(err.Error())
+	}
+	return submission, nil
+}
+
+// recordSubmissionPoints adds the ledger entry for the change in points of the submission, if there is one
+func recordSubmissionPoints(tx *gorm.DB, submission Submission) error {
+	var recorded int
+	result := tx.Raw(`
+		SELECT COALESCE(SUM(points), 0)
+		FROM points_entries
+		WHERE submission_id = ? AND deleted_at IS NULL
+	`, submission.ID).Scan(&recorded)
+
+	if result.Error != nil {
+		return apperrors.NewDatabaseError(result.Error.Error())
+	}
+
+	entry := newSubmissionPointsEntry(submission, recorded)
+	if entry == nil {
+		return nil
+	}
+
+	if err := tx.Omit("User").Create(entry).Error; err != nil {
+		return apperrors.NewDatabaseError(err.Error())
+	}
+
+	return nil
+}
+
+func (p *database) GetSubmissionsByState(state types.SubmissionState) ([]types.ModerationSubmission, error) {
+	var submissions = make([]types.ModerationSubmission, 0)
+	tx := p.db.Raw(`
+		SELECT
+		    submissions.id,
+		    submissions.answer,
+		    submissions.challenge_id AS "ChallengeId",
+		    challenges.name AS "ChallengeName",
+		    submissions.user_id AS "UserId",
+		    users.username,
+		    submissions.points,
+		    submissions.state,
+		    submissions.rejection_reason AS "RejectionReason",
+		    submissions.created_at AS "CreatedAt"
+		FROM submissions
+		INNER JOIN users ON submissions.user_id = users.id
+		INNER JOIN challenges ON submissions.challenge_id = challenges.id
+		WHERE submissions.state = ? AND challenges.type IN (?, ?)
+		ORDER BY submissions.created_at
+	`, state, types.UploadPhotoChallenge, types.UploadVideoChallenge).Scan(&submissions)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return submissions, nil
+}
+
+// IsAnswerUsedElsewhere returns true if the answer was submitted by another user or for another challenge
+func (p *database) IsAnswerUsedElsewhere(answer string, userId uint, challengeId uint) (bool, error) {
+	var count int64
+	tx := p.db.Raw(`
+		SELECT COUNT(*) AS count
+		FROM submissions
+		WHERE answer = ? AND NOT (user_id = ? AND challenge_id = ?)
+	`, answer, userId, challengeId).Scan(&count)
+
+	if tx.Error != nil {
+		return false, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return count > 0, nil
+}
+
+func (p *database) GetError() error {
+	err := p.db.Error
+	if err == nil {
+		return nil
+	}
+	p.db.Error = nil
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.NewRecordNotFoundError(err.Error())
+	}
+
+	return apperrors.NewDatabaseError(err.Error())
+}
+
+func newDatabase() DatabaseInterface {
+	dbURI := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
+		os.Getenv("DB_HOST"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_USER"),
+		os.Getenv("DB_NAME"),
+		os.Getenv("DB_PASS"),
+	)
+
+	db, err := gorm.Open(postgres.Open(dbURI), &gorm.Config{})
+	if err != nil {
+		log.Printf("Error connecting to database: %v\n", err)
+		log.Fatal("Could not connect database")
+	}
+
+	conn, _ := db.DB()
+	conn.SetConnMaxIdleTime(time.Minute * 5)
+	conn.SetConnMaxLifetime(time.Minute * 15)
+	conn.SetMaxOpenConns(100)
+
+	return &database{
+		db:                db,
+		initialConnection: db,
+	}
+}
+
+func (p *database) GetAudioMessages() ([]types.AudioMessage, error) {
+	var messages = make([]types.AudioMessage, 0)
+	tx := p.db.Raw(`
+		SELECT
+		    submissions.id,
+		    submissions.answer AS Url,
+		    uploads.duration_seconds AS "DurationSeconds",
+		    submissions.challenge_id AS "ChallengeId",
+		    challenges.name AS "ChallengeName",
+		    submissions.user_id AS "UserId",
+		    users.username,
+		    submissions.created_at AS "CreatedAt"
+		FROM submissions
+		INNER JOIN users ON submissions.user_id = users.id
+		INNER JOIN challenges ON submissions.challenge_id = challenges.id
+		INNER JOIN uploads ON uploads.url = submissions.answer AND uploads.user_id = submissions.user_id
+		WHERE challenges.type = ? AND uploads.media_type = ?
+		ORDER BY submissions.created_at
+	`, types.UploadAudioChallenge, types.AudioMedia).Scan(&messages)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return messages, nil
+}
+
+func (p *database) GetChallengeSubmissions(challengeId uint) ([]Submission, error) {
+	var submissions = make([]Submission, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM submissions
+		WHERE challenge_id = ? AND deleted_at IS NULL
+		ORDER BY created_at
+	`, challengeId).Scan(&submissions)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return submissions, nil
+}
+
+// ResolveChallenge posts the outcome and saves the scored guesses at once, so a failure leaves the previous outcome
+// and points in place
+func (p *database) ResolveChallenge(challengeId uint, outcome string, resolvedAt time.Time, submissions []Submission) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			UPDATE challenges
+			SET outcome = ?, resolved_at = ?
+			WHERE id = ?
+		`, outcome, resolvedAt, challengeId)
+
+		if result.Error != nil {
+			return apperrors.NewDatabaseError(result.Error.Error())
+		}
+
+		if result.RowsAffected == 0 {
+			return apperrors.NewRecordNotFoundError(fmt.Sprintf("Challenge with ID %d not found", challengeId))
+		}
+
+		for _, submission := range submissions {
+			if _, err := updateSubmission(tx, submission); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *database) GetBingoBoard() (*BingoBoard, error) {
+	var boards = make([]BingoBoard, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM bingo_boards
+		WHERE deleted_at IS NULL
+		ORDER BY id DESC
+		LIMIT 1
+	`).Scan(&boards)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if len(boards) == 0 {
+		return nil, nil
+	}
+	return &boards[0], nil
+}
+
+func (p *database) GetBingoCells(boardId uint) ([]BingoCell, error) {
+	var cells = make([]BingoCell, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM bingo_cells
+		WHERE board_id = ? AND deleted_at IS NULL
+		ORDER BY position
+	`, boardId).Scan(&cells)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return cells, nil
+}
+
+func (p *database) ReplaceBingoBoard(board BingoBoard, cells []BingoCell) (BingoBoard, []BingoCell, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM bingo_cells`).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		if err := tx.Exec(`DELETE FROM bingo_boards`).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		if err := tx.Create(&board).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		for i := range cells {
+			cells[i].BoardID = board.ID
+			if err := tx.Omit("Board", "Challenge").Create(&cells[i]).Error; err != nil {
+				return apperrors.NewDatabaseError(err.Error())
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return BingoBoard{}, nil, err
+	}
+	return board, cells, nil
+}
+
+func (p *database) DeleteBingoBoard() error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM bingo_cells`).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		if err := tx.Exec(`DELETE FROM bingo_boards`).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		return nil
+	})
+}
+
+func (p *database) GetBingoCompletions(challengeIds []uint) ([]types.BingoCompletion, error) {
+	var completions = make([]types.BingoCompletion, 0)
+	if len(challengeIds) == 0 {
+		return completions, nil
+	}
+
+	tx := p.db.Raw(`
+		SELECT DISTINCT users.id AS user_id, submissions.challenge_id
+		FROM submissions
+		INNER JOIN users AS members ON submissions.user_id = members.id
+		INNER JOIN challenges ON submissions.challenge_id = challenges.id
+		INNER JOIN users ON users.id = members.id
+		    OR (challenges.team_challenge = true AND users.team_id = members.team_id)
+		WHERE submissions.challenge_id IN ? AND challenges.status = ? AND submissions.state = ?
+	`, challengeIds, types.ActiveChallenge, types.ApprovedSubmission).Scan(&completions)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return completions, nil
+}
+
+func (p *database) GetQuizRounds() ([]QuizRound, error) {
+	var rounds = make([]QuizRound, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM quiz_rounds
+		WHERE deleted_at IS NULL
+		ORDER BY id
+	`).Scan(&rounds)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return rounds, nil
+}
+
+func (p *database) GetQuizQuestions(roundId uint) ([]QuizQuestion, error) {
+	var questions = make([]QuizQuestion, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM quiz_questions
+		WHERE round_id = ? AND deleted_at IS NULL
+		ORDER BY position
+	`, roundId).Scan(&questions)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return questions, nil
+}
+
+func (p *database) GetAllQuizQuestions() ([]QuizQuestion, error) {
+	var questions = make([]QuizQuestion, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM quiz_questions
+		WHERE deleted_at IS NULL
+		ORDER BY round_id, position
+	`).Scan(&questions)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return questions, nil
+}
+
+func (p *database) StartQuizRound(roundId uint, startedAt time.Time) error {
+	tx := p.db.Exec(`
+		UPDATE quiz_rounds
+		SET started_at = ?
+		WHERE id = ?
+	`, startedAt, roundId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return nil
+}
+
+func (p *database) GetQuizResults(roundId uint) ([]types.QuizResult, error) {
+	var results = make([]types.QuizResult, 0)
+	tx := p.db.Raw(`
+		SELECT users.id AS user_id, users.username, SUM(submissions.points) AS points, COUNT(*) AS correct_answers
+		FROM submissions
+		INNER JOIN quiz_questions ON submissions.challenge_id = quiz_questions.challenge_id
+		INNER JOIN users ON submissions.user_id = users.id
+		WHERE quiz_questions.round_id = ? AND submissions.state = ?
+		GROUP BY users.id, users.username
+		ORDER BY points DESC, users.username
+	`, roundId, types.ApprovedSubmission).Scan(&results)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return results, nil
+}
+
+func (p *database) GetPointsEntries(userId uint) ([]PointsEntry, error) {
+	var entries = make([]PointsEntry, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM points_entries
+		WHERE user_id = ? AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`, userId).Scan(&entries)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return entries, nil
+}
+
+// RecordBingoBonuses adds the ledger entries that bring the bingo bonus recorded for every user in line with the bonuses,
+// users without a bonus get back to none. The lock keeps two updates from recording the same difference twice.
+func (p *database) RecordBingoBonuses(bonuses map[uint]uint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('bingo_bonuses'))`).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		var rows []struct {
+			UserID uint
+			Points int
+		}
+		result := tx.Raw(`
+			SELECT user_id, COALESCE(SUM(points), 0) AS points
+			FROM points_entries
+			WHERE type = ? AND deleted_at IS NULL
+			GROUP BY user_id
+		`, types.BingoPointsEntry).Scan(&rows)
+
+		if result.Error != nil {
+			return apperrors.NewDatabaseError(result.Error.Error())
+		}
+
+		recorded := make(map[uint]int, len(rows))
+		for _, row := range rows {
+			recorded[row.UserID] = row.Points
+		}
+
+		for _, entry := range newBingoPointsEntries(bonuses, recorded) {
+			if err := tx.Omit("User").Create(&entry).Error; err != nil {
+				return apperrors.NewDatabaseError(err.Error())
+			}
+		}
+
+		return nil
+	})
+}
+
+func (p *database) GetAccessTokenByRefreshToken(refreshToken string) (*AccessToken, error) {
+	var accessToken AccessToken
+	tx := p.db.Raw(`
+		SELECT *
+		FROM access_tokens
+		WHERE refresh_token = ? AND deleted_at IS NULL
+	`, refreshToken).Scan(&accessToken)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &accessToken, nil
+}
+
+func (p *database) ReplaceAccessToken(accessTokenId uint, accessToken AccessToken) (AccessToken, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Exec(`
+			DELETE FROM access_tokens
+			WHERE id = ?
+		`, accessTokenId)
+
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		// The token was refreshed or revoked in the meantime
+		if deleted.RowsAffected == 0 {
+			return apperrors.NewRecordNotFoundError(fmt.Sprintf("Access token with ID %d not found", accessTokenId))
+		}
+
+		if err := tx.Omit("User").Create(&accessToken).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	return accessToken, nil
+}
+
+func (p *database) DeleteAccessToken(token string) error {
+	tx := p.db.Exec(`
+		DELETE FROM access_tokens
+		WHERE token = ?
+	`, token)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return apperrors.NewRecordNotFoundError("Access token not found")
+	}
+
+	return nil
+}
+
+func (p *database) DeleteExpiredAccessTokens(now int64) (int64, error) {
+	tx := p.db.Exec(`
+		DELETE FROM access_tokens
+		WHERE expires_on <= ? AND refresh_expires_on <= ?
+	`, now, now)
+
+	if tx.Error != nil {
+		return 0, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return tx.RowsAffected, nil
+}
+
+func (p *database) GetGuests() ([]types.GuestResponse, error) {
+	var guests = make([]types.GuestResponse, 0)
+	tx := p.db.Raw(`
+		SELECT guests.id, guests.name, guests.invite_code, users.username
+		FROM guests
+		LEFT JOIN users ON guests.user_id = users.id
+		WHERE guests.deleted_at IS NULL
+		ORDER BY guests.name, guests.id
+	`).Scan(&guests)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return guests, nil
+}
+
+func (p *database) GetGuestByInviteCode(inviteCode string) (*Guest, error) {
+	var guest Guest
+	tx := p.db.Raw(`
+		SELECT *
+		FROM guests
+		WHERE invite_code = ? AND deleted_at IS NULL
+	`, inviteCode).Scan(&guest)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &guest, nil
+}
+
+func (p *database) GetGuestByUserID(userId uint) (*Guest, error) {
+	var guest Guest
+	tx := p.db.Raw(`
+		SELECT *
+		FROM guests
+		WHERE user_id = ? AND deleted_at IS NULL
+	`, userId).Scan(&guest)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &guest, nil
+}
+
+func (p *database) CreateGuests(guests []Guest) ([]Guest, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		for i := range guests {
+			if err := tx.Omit("User").Create(&guests[i]).Error; err != nil {
+				return apperrors.NewDatabaseError(err.Error())
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return guests, nil
+}
+
+func (p *database) ClaimGuest(guestId uint, userId uint) error {
+	return claimGuest(p.db, guestId, userId)
+}
+
+// CreateUserForGuest saves the new user and links the guest to them, the user is not kept if the guest was claimed meanwhile
+func (p *database) CreateUserForGuest(user User, guestId uint) (User, error) {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return apperrors.NewDatabaseError(err.Error())
+		}
+
+		return claimGuest(tx, guestId, user.ID)
+	})
+
+	if err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func claimGuest(db *gorm.DB, guestId uint, userId uint) error {
+	tx := db.Exec(`
+		UPDATE guests
+		SET user_id = ?, updated_at = NOW()
+		WHERE id = ? AND user_id IS NULL
+	`, userId, guestId)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return apperrors.NewRecordNotFoundError(fmt.Sprintf("Unclaimed guest with ID %d not found", guestId))
+	}
+
+	return nil
+}
+
+func (p *database) GetSetting(key string) (*string, error) {
+	var values []string
+	tx := p.db.Raw(`
+		SELECT value
+		FROM settings
+		WHERE key = ? AND deleted_at IS NULL
+	`, key).Scan(&values)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	return &values[0], nil
+}
+
+func (p *database) SetSetting(key string, value string) error {
+	tx := p.db.Exec(`
+		INSERT INTO settings (created_at, updated_at, key, value)
+		VALUES (NOW(), NOW(), ?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW(), deleted_at = NULL
+	`, key, value)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return nil
+}
+
+func (p *database) GetLoginLockout(key string) (*LoginLockout, error) {
+	var lockout LoginLockout
+	tx := p.db.Raw(`
+		SELECT *
+		FROM login_lockouts
+		WHERE key = ? AND deleted_at IS NULL
+	`, key).Scan(&lockout)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &lockout, nil
+}
+
+func (p *database) GetLoginLockouts() ([]LoginLockout, error) {
+	var lockouts = make([]LoginLockout, 0)
+	tx := p.db.Raw(`
+		SELECT *
+		FROM login_lockouts
+		WHERE deleted_at IS NULL
+		ORDER BY key
+	`).Scan(&lockouts)
+
+	if tx.Error != nil {
+		return nil, apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return lockouts, nil
+}
+
+func (p *database) ReserveLogin(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (LoginLockout, bool, error) {
+	var lockout LoginLockout
+	reserved := false
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		// The upsert locks the row until the transaction ends, concurrent logins of the key wait for the lockout
+		upserted := tx.Raw(`
+			INSERT INTO login_lockouts (created_at, updated_at, key, failures, last_failure_at)
+			VALUES (NOW(), NOW(), ?, 1, ?)
+			ON CONFLICT (key) DO UPDATE SET
+				failures = CASE
+					WHEN login_lockouts.locked_until > ? THEN login_lockouts.failures
+					WHEN login_lockouts.last_failure_at < ? THEN 1
+					ELSE login_lockouts.failures + 1
+				END,
+				last_failure_at = CASE
+					WHEN login_lockouts.locked_until > ? THEN login_lockouts.last_failure_at
+					ELSE EXCLUDED.last_failure_at
+				END,
+				updated_at = NOW()
+			RETURNING *
+		`, key, now, now, since, now).Scan(&lockout)
+
+		if upserted.Error != nil {
+			return apperrors.NewDatabaseError(upserted.Error.Error())
+		}
+
+		if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+			return nil
+		}
+
+		var lockedUntil *time.Time
+		if duration := lockoutFor(lockout.Failures); duration > 0 {
+			until := now.Add(duration)
+			lockedUntil = &until
+		}
+
+		locked := tx.Exec(`
+			UPDATE login_lockouts
+			SET locked_until = ?, updated_at = NOW()
+			WHERE key = ?
+		`, lockedUntil, key)
+
+		if locked.Error != nil {
+			return apperrors.NewDatabaseError(locked.Error.Error())
+		}
+
+		lockout.LockedUntil = lockedUntil
+		reserved = true
+		return nil
+	})
+
+	if err != nil {
+		return LoginLockout{}, false, err
+	}
+
+	return lockout, reserved, nil
+}
+
+func (p *database) ReleaseLogin(key string) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		released := tx.Exec(`
+			UPDATE login_lockouts
+			SET failures = failures - 1, locked_until = NULL, updated_at = NOW()
+			WHERE key = ? AND failures > 0
+		`, key)
+
+		if released.Error != nil {
+			return apperrors.NewDatabaseError(released.Error.Error())
+		}
+
+		deleted := tx.Exec(`
+			DELETE FROM login_lockouts
+			WHERE key = ? AND failures = 0
+		`, key)
+
+		if deleted.Error != nil {
+			return apperrors.NewDatabaseError(deleted.Error.Error())
+		}
+
+		return nil
+	})
+}
+
+func (p *database) DeleteLoginLockout(key string) error {
+	tx := p.db.Exec(`
+		DELETE FROM login_lockouts
+		WHERE key = ?
+	`, key)
+
+	if tx.Error != nil {
+		return apperrors.NewDatabaseError(tx.Error.Error())
+	}
+
+	return nil
 }
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+)
+
+// Guest is an invited guest, the user who logs in with the invite code first claims the guest
+type Guest struct {
+	gorm.Model
+	Name       string `gorm:"not null"`
+	InviteCode string `gorm:"unique;not null"`
+	UserID     *uint  `gorm:"unique"`
+	User       *User
+}
+
+func NewGuest(name string, inviteCode string) Guest {
+	return Guest{
+		Name:       name,
+		InviteCode: utils.NormalizeCode(inviteCode),
+	}
+}
+
+func (guest Guest) Save() (Guest, error) {
+	conn := GetConnection()
+	if err := conn.Create(&guest).GetError(); err != nil {
+		return Guest{}, err
+	}
+	return guest, nil
+}
+
+// ImportGuests adds the guests to the guest list, invite codes are generated for guests without one.
+// The request is expected to hold different invite codes, either all guests are added or none.
+func ImportGuests(importGuestsRequest types.ImportGuestsRequest) ([]Guest, error) {
+	conn := GetConnection()
+	guests := make([]Guest, len(importGuestsRequest.Guests))
+	for i, guestRequest := range importGuestsRequest.Guests {
+		inviteCode := utils.NormalizeCode(guestRequest.InviteCode)
+		if inviteCode == "" {
+			inviteCode = generateJoinCode()
+		}
+		guests[i] = NewGuest(guestRequest.Name, inviteCode)
+
+		existing, err := conn.GetGuestByInviteCode(guests[i].InviteCode)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, apperrors.NewValidationError(constants.InviteCodeTakenError)
+		}
+	}
+
+	return conn.CreateGuests(guests)
+}
+
+func GetGuests() ([]types.GuestResponse, error) {
+	conn := GetConnection()
+	return conn.GetGuests()
+}
+
+// GetGuestForLogin checks the invite code the user logs in with and returns the guest it belongs to,
+// or nil if registration is open and no code is given. New users are passed as nil.
+// Users who claimed a guest always need its invite code, so nobody else can log in as them.
+func GetGuestForLogin(user *User, inviteCode string) (*Guest, error) {
+	conn := GetConnection()
+	inviteCode = utils.NormalizeCode(inviteCode)
+
+	if user != nil {
+		claimed, err := conn.GetGuestByUserID(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if claimed != nil {
+			if inviteCode == "" {
+				return nil, apperrors.NewAuthenticationError(constants.InviteCodeRequiredError)
+			}
+			if inviteCode != claimed.InviteCode {
+				return nil, apperrors.NewAuthenticationError(constants.InvalidInviteCodeError)
+			}
+			return claimed, nil
+		}
+	}
+
+	if inviteCode == "" {
+		open, err := IsRegistrationOpen()
+		if err != nil {
+			return nil, err
+		}
+		if open {
+			return nil, nil
+		}
+		return nil, apperrors.NewAuthenticationError(constants.InviteCodeRequiredError)
+	}
+
+	guest, err := conn.GetGuestByInviteCode(inviteCode)
+	if err != nil {
+		return nil, err
+	}
+	if guest == nil {
+		return nil, apperrors.NewAuthenticationError(constants.InvalidInviteCodeError)
+	}
+	if guest.UserID != nil {
+		return nil, apperrors.NewAuthenticationError(constants.InviteCodeUsedError)
+	}
+	return guest, nil
+}
+
+// Register saves the new user and claims the guest for them at once, so a failed claim leaves no user behind
+func (guest Guest) Register(user User) (User, Guest, error) {
+	conn := GetConnection()
+	user, err := conn.CreateUserForGuest(user, guest.ID)
+	if err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return User{}, Guest{}, apperrors.NewAuthenticationError(constants.InviteCodeUsedError)
+		}
+		return User{}, Guest{}, err
+	}
+
+	guest.UserID = &user.ID
+	return user, guest, nil
+}
+
+// Claim links the guest to the user, unless the guest is already claimed by them
+func (guest Guest) Claim(userId uint) (Guest, error) {
+	if guest.UserID != nil && *guest.UserID == userId {
+		return guest, nil
+	}
+
+	conn := GetConnection()
+	if err := conn.ClaimGuest(guest.ID, userId); err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return Guest{}, apperrors.NewAuthenticationError(constants.InviteCodeUsedError)
+		}
+		return Guest{}, err
+	}
+
+	guest.UserID = &userId
+	return guest, nil
+}
@@ -0,0 +1,235 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func createGuest(id uint, inviteCode string, userId *uint) *Guest {
+	database := GetConnection()
+	guest := &Guest{Model: gorm.Model{ID: id}, Name: "Guest", InviteCode: inviteCode, UserID: userId}
+	database.Create(guest)
+	return guest
+}
+
+func TestNewGuestNormalizesInviteCode(t *testing.T) {
+	guest := NewGuest("Aunt May", " may123 ")
+	if guest.Name != "Aunt May" || guest.InviteCode != "MAY123" || guest.UserID != nil {
+		t.Errorf("expected unclaimed guest Aunt May with invite code MAY123 but got %v", guest)
+	}
+}
+
+func TestImportGuestsGeneratesInviteCodes(t *testing.T) {
+	SetupMockDb()
+
+	guests, err := ImportGuests(types.ImportGuestsRequest{Guests: []types.GuestRequest{
+		{Name: "Aunt May", InviteCode: "may123"},
+		{Name: "Uncle Ben", InviteCode: "  "},
+	}})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(guests) != 2 || guests[0].InviteCode != "MAY123" || len(guests[1].InviteCode) != 6 {
+		t.Errorf("expected invite codes MAY123 and a generated one but got %v", guests)
+	}
+}
+
+func TestImportGuestsInviteCodeTaken(t *testing.T) {
+	SetupMockDb()
+	createGuest(1, "MAY123", nil)
+
+	_, err := ImportGuests(types.ImportGuestsRequest{Guests: []types.GuestRequest{{Name: "Aunt May", InviteCode: "may123"}}})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "invite code is already taken" {
+		t.Errorf("expected validation error 'invite code is already taken' but got %s", err.Error())
+	}
+}
+
+func TestGetGuestForLoginOpenRegistrationWithoutCode(t *testing.T) {
+	SetupMockDb()
+
+	guest, err := GetGuestForLogin(nil, "")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if guest != nil {
+		t.Errorf("expected no guest but got %v", guest)
+	}
+}
+
+func TestGetGuestForLoginClosedRegistrationWithoutCode(t *testing.T) {
+	SetupMockDb()
+	_ = SetRegistrationOpen(false)
+
+	_, err := GetGuestForLogin(nil, "")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsAuthenticationError(err) || err.Error() != "invite code is required" {
+		t.Errorf("expected authentication error 'invite code is required' but got %s", err.Error())
+	}
+}
+
+func TestGetGuestForLoginWithCode(t *testing.T) {
+	SetupMockDb()
+	_ = SetRegistrationOpen(false)
+	createGuest(1, "MAY123", nil)
+
+	guest, err := GetGuestForLogin(nil, "may123")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if guest == nil || guest.ID != 1 {
+		t.Errorf("expected guest 1 but got %v", guest)
+	}
+}
+
+func TestGetGuestForLoginInvalidCode(t *testing.T) {
+	SetupMockDb()
+	createGuest(1, "MAY123", nil)
+
+	_, err := GetGuestForLogin(nil, "unknown")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsAuthenticationError(err) || err.Error() != "invalid invite code" {
+		t.Errorf("expected authentication error 'invalid invite code' but got %s", err.Error())
+	}
+}
+
+func TestGetGuestForLoginCodeClaimedByAnotherUser(t *testing.T) {
+	SetupMockDb()
+	userId := uint(2)
+	createGuest(1, "MAY123", &userId)
+
+	_, err := GetGuestForLogin(&User{Model: gorm.Model{ID: 3}}, "MAY123")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsAuthenticationError(err) || err.Error() != "invite code was already used by another guest" {
+		t.Errorf("expected authentication error 'invite code was already used by another guest' but got %s", err.Error())
+	}
+}
+
+func TestGetGuestForLoginClaimedGuestNeedsCode(t *testing.T) {
+	SetupMockDb()
+	userId := uint(2)
+	createGuest(1, "MAY123", &userId)
+
+	_, err := GetGuestForLogin(&User{Model: gorm.Model{ID: userId}}, "")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsAuthenticationError(err) || err.Error() != "invite code is required" {
+		t.Errorf("expected authentication error 'invite code is required' but got %s", err.Error())
+	}
+}
+
+func TestGetGuestForLoginClaimedGuestWithCode(t *testing.T) {
+	SetupMockDb()
+	userId := uint(2)
+	createGuest(1, "MAY123", &userId)
+
+	guest, err := GetGuestForLogin(&User{Model: gorm.Model{ID: userId}}, "may123")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if guest == nil || guest.ID != 1 {
+		t.Errorf("expected guest 1 but got %v", guest)
+	}
+}
+
+func TestClaimGuest(t *testing.T) {
+	SetupMockDb()
+	guest := createGuest(1, "MAY123", nil)
+
+	claimed, err := guest.Claim(2)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if claimed.UserID == nil || *claimed.UserID != 2 {
+		t.Errorf("expected guest claimed by user 2 but got %v", claimed.UserID)
+	}
+}
+
+func TestClaimGuestAlreadyClaimed(t *testing.T) {
+	SetupMockDb()
+	userId := uint(2)
+	createGuest(1, "MAY123", &userId)
+
+	_, err := Guest{Model: gorm.Model{ID: 1}, InviteCode: "MAY123"}.Claim(3)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected authentication error but got %s", err.Error())
+	}
+}
+
+func TestRegisterGuest(t *testing.T) {
+	SetupMockDb()
+	guest := createGuest(1, "MAY123", nil)
+
+	user, claimed, err := guest.Register(User{Model: gorm.Model{ID: 2}, Username: "newuser"})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if user.ID != 2 || claimed.UserID == nil || *claimed.UserID != 2 {
+		t.Errorf("expected guest claimed by the new user 2 but got %v", claimed.UserID)
+	}
+}
+
+func TestRegisterGuestAlreadyClaimed(t *testing.T) {
+	mockDB := SetupMockDb()
+	userId := uint(2)
+	createGuest(1, "MAY123", &userId)
+
+	_, _, err := Guest{Model: gorm.Model{ID: 1}, InviteCode: "MAY123"}.Register(User{Model: gorm.Model{ID: 3}, Username: "newuser"})
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsAuthenticationError(err) {
+		t.Errorf("expected authentication error but got %s", err.Error())
+	}
+
+	for _, item := range mockDB.items {
+		if _, ok := item.(*User); ok {
+			t.Errorf("expected the new user not to be saved but got %v", item)
+		}
+	}
+}
+
+func TestRegistrationIsOpenByDefault(t *testing.T) {
+	SetupMockDb()
+
+	open, err := IsRegistrationOpen()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if !open {
+		t.Errorf("expected registration to be open")
+	}
+
+	_ = SetRegistrationOpen(false)
+	if open, _ = IsRegistrationOpen(); open {
+		t.Errorf("expected registration to be closed")
+	}
+}
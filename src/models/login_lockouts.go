@@ -0,0 +1,44 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// LoginLockout counts the failed logins of a key, a username or an IP address, for limiters shared between instances
+type LoginLockout struct {
+	gorm.Model
+	Key           string    `gorm:"unique;not null"`
+	Failures      uint      `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+}
+
+// GetLoginLockout returns the failed logins of the key, or nil if none are recorded
+func GetLoginLockout(key string) (*LoginLockout, error) {
+	conn := GetConnection()
+	return conn.GetLoginLockout(key)
+}
+
+func GetLoginLockouts() ([]LoginLockout, error) {
+	conn := GetConnection()
+	return conn.GetLoginLockouts()
+}
+
+// ReserveLogin counts a login attempt of the key as failed unless it is locked out at now, and locks it out for
+// lockoutFor(failures). Failures from before since are forgotten. It returns false if the key is locked out.
+func ReserveLogin(key string, now time.Time, since time.Time, lockoutFor func(failures uint) time.Duration) (LoginLockout, bool, error) {
+	conn := GetConnection()
+	return conn.ReserveLogin(key, now, since, lockoutFor)
+}
+
+// ReleaseLogin takes back a reserved attempt of the key and the lockout it set, keys without failures are deleted
+func ReleaseLogin(key string) error {
+	conn := GetConnection()
+	return conn.ReleaseLogin(key)
+}
+
+func DeleteLoginLockout(key string) error {
+	conn := GetConnection()
+	return conn.DeleteLoginLockout(key)
+}
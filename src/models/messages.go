@@ -0,0 +1,10 @@
+package models
+
+import "the-wedding-game-api/types"
+
+// GetAudioMessages returns the voice messages recorded for the couple, oldest first.
+// They are only listed for admins and never shown in the public gallery.
+func GetAudioMessages() ([]types.AudioMessage, error) {
+	conn := GetConnection()
+	return conn.GetAudioMessages()
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+	"time"
+)
+
+func TestGetAudioMessages(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	if _, err := NewAudioUpload(1, utils.UploadedFile{Url: testAudioUrl}, 42*time.Second).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if _, err := NewUpload(1, utils.UploadedFile{Url: testUploadUrl}).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	database.Create(&Submission{UserID: 1, ChallengeID: testAudioChallenge.ID, Answer: testAudioUrl, State: types.ApprovedSubmission})
+	database.Create(&Submission{UserID: 1, ChallengeID: testPhotoChallenge.ID, Answer: testUploadUrl, State: types.ApprovedSubmission})
+
+	messages, err := GetAudioMessages()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if len(messages) != 1 {
+		t.Errorf("expected 1 message but got %d", len(messages))
+		return
+	}
+	if messages[0].Url != testAudioUrl || messages[0].DurationSeconds != 42 || messages[0].UserId != 1 {
+		t.Errorf("unexpected message %v", messages[0])
+	}
+}
+
+func TestCompleteAudioChallengeIsApproved(t *testing.T) {
+	SetupMockDb()
+
+	submission, err := CompleteChallenge(1, testAudioChallenge, testAudioUrl)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if submission.State != types.ApprovedSubmission {
+		t.Errorf("expected audio messages to skip moderation but got %s", submission.State)
+	}
+}
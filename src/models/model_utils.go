@@ -1,5 +1,10 @@
 package models
 
+import (
+	"math/rand/v2"
+	"slices"
+)
+
 func IsChallengeInSubmissions(challengeId uint, submissions []Submission) bool {
 	for _, submission := range submissions {
 		if submission.ChallengeID == challengeId {
@@ -8,3 +13,13 @@ func IsChallengeInSubmissions(challengeId uint, submissions []Submission) bool {
 	}
 	return false
 }
+
+// shuffleForUser returns the values in an order that is random but always the same for the user and seed
+func shuffleForUser[T any](values []T, userId uint, seed uint) []T {
+	shuffled := slices.Clone(values)
+	random := rand.New(rand.NewPCG(uint64(userId), uint64(seed)))
+	random.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+func GetSubmissionByID(id uint) (Submission, error) {
+	conn := GetConnection()
+	var submission Submission
+	if err := conn.First(&submission, id).GetError(); err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return Submission{}, apperrors.NewNotFoundError("Submission", strconv.Itoa(int(id)))
+		}
+		return Submission{}, err
+	}
+	return submission, nil
+}
+
+// GetModerationQueue returns the photo and video submissions in the given state, oldest first
+func GetModerationQueue(state types.SubmissionState) ([]types.ModerationSubmission, error) {
+	conn := GetConnection()
+	return conn.GetSubmissionsByState(state)
+}
+
+// Approve makes the photo or video count for points, the leaderboard and the gallery
+func (s Submission) Approve() (Submission, error) {
+	return s.moderate(types.ApprovedSubmission, "")
+}
+
+// Reject keeps the photo or video out of the game, the player sees the reason and can upload another one
+func (s Submission) Reject(reason string) (Submission, error) {
+	return s.moderate(types.RejectedSubmission, reason)
+}
+
+func (s Submission) moderate(state types.SubmissionState, reason string) (Submission, error) {
+	challenge, err := GetChallengeByID(s.ChallengeID)
+	if err != nil {
+		return Submission{}, err
+	}
+	if !challenge.IsModerated() {
+		return Submission{}, apperrors.NewValidationError(constants.SubmissionNotModeratedError)
+	}
+
+	s.State = state
+	s.RejectionReason = reason
+
+	conn := GetConnection()
+	moderated, err := conn.UpdateSubmission(s)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	refreshBingoBonuses()
+	return moderated, nil
+}
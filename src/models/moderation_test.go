@@ -0,0 +1,156 @@
+package models
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+var testPhotoChallenge = Challenge{ID: 77, Name: "photo", Description: "description", Points: 20,
+	Type: types.UploadPhotoChallenge, Status: types.ActiveChallenge}
+
+func TestCompleteChallengeWithPhotoIsPending(t *testing.T) {
+	SetupMockDb()
+
+	submission, err := CompleteChallenge(1, testPhotoChallenge, "https://example.com/photo.jpg")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if submission.State != types.PendingSubmission || submission.Points != 20 {
+		t.Errorf("expected a pending submission worth 20 points but got %v", submission)
+	}
+
+	completed, err := IsChallengeCompleted(1, testPhotoChallenge.ID)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if completed {
+		t.Errorf("expected a pending photo not to complete the challenge")
+	}
+}
+
+func TestCompleteChallengeReplacesRejectedPhoto(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	rejected := Submission{UserID: 1, ChallengeID: testPhotoChallenge.ID, Answer: "https://example.com/blurry.jpg",
+		State: types.RejectedSubmission, RejectionReason: "blurry"}
+	rejected.ID = 5
+	database.Create(&rejected)
+
+	submission, err := CompleteChallenge(1, testPhotoChallenge, "https://example.com/sharp.jpg")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if submission.ID != 5 || submission.State != types.PendingSubmission || submission.RejectionReason != "" {
+		t.Errorf("expected the rejected submission to be pending again but got %v", submission)
+	}
+	if rejected.Answer != "https://example.com/sharp.jpg" {
+		t.Errorf("expected the new photo to replace the rejected one but got %s", rejected.Answer)
+	}
+}
+
+func TestGetCompletedChallengesSkipsPendingPhotos(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	database.Create(&Submission{UserID: 1, ChallengeID: 1, State: types.ApprovedSubmission})
+	database.Create(&Submission{UserID: 1, ChallengeID: 2, State: types.PendingSubmission})
+	database.Create(&Submission{UserID: 1, ChallengeID: 3, State: types.RejectedSubmission})
+
+	completed, err := GetCompletedChallenges(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(completed) != 1 || completed[0].ChallengeID != 1 {
+		t.Errorf("expected only the approved submission but got %v", completed)
+	}
+}
+
+func TestApproveSubmission(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	pending := Submission{UserID: 1, ChallengeID: testPhotoChallenge.ID, State: types.PendingSubmission}
+	database.Create(&testPhotoChallenge)
+	database.Create(&pending)
+
+	approved, err := pending.Approve()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if !approved.IsApproved() || !pending.IsApproved() {
+		t.Errorf("expected the submission to be approved but got %v", approved)
+	}
+}
+
+func TestRejectSubmission(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	pending := Submission{UserID: 1, ChallengeID: testPhotoChallenge.ID, State: types.PendingSubmission}
+	database.Create(&testPhotoChallenge)
+	database.Create(&pending)
+
+	rejected, err := pending.Reject("not a photo of the cake")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if rejected.State != types.RejectedSubmission || rejected.RejectionReason != "not a photo of the cake" {
+		t.Errorf("expected the submission to be rejected with the reason but got %v", rejected)
+	}
+}
+
+func TestRejectSubmissionForOtherChallengeType(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	submission := Submission{UserID: 1, ChallengeID: testChallenge123.ID, State: types.ApprovedSubmission}
+	database.Create(&testChallenge123)
+	database.Create(&submission)
+
+	_, err := submission.Reject("wrong")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+}
+
+func TestGetModerationQueue(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	database.Create(&Submission{UserID: 1, ChallengeID: 1, State: types.PendingSubmission})
+	database.Create(&Submission{UserID: 2, ChallengeID: 1, State: types.ApprovedSubmission})
+
+	queue, err := GetModerationQueue(types.PendingSubmission)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(queue) != 1 || queue[0].UserId != 1 {
+		t.Errorf("expected the pending submission but got %v", queue)
+	}
+}
+
+func TestGetSubmissionByIDNotFound(t *testing.T) {
+	SetupMockDb()
+
+	_, err := GetSubmissionByID(999)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsNotFoundError(err) {
+		t.Errorf("expected not found error but got %s", err.Error())
+	}
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"slices"
+	"the-wedding-game-api/types"
+)
+
+// PointsEntry is one scoring event of a user, the points of a user are the sum of their entries.
+// Entries are never changed, a correction is recorded as a new entry.
+type PointsEntry struct {
+	gorm.Model
+	UserID       uint                  `gorm:"not null;index"`
+	Type         types.PointsEntryType `gorm:"not null"`
+	Points       int                   `gorm:"not null"`
+	Reason       string                `gorm:"not null"`
+	ChallengeID  *uint                 `gorm:"index"`
+	SubmissionID *uint                 `gorm:"index"`
+	ActorID      *uint
+	User         User
+}
+
+var SubmissionPointsReason = "Challenge completed"
+var ReversalPointsReason = "Points of the submission withdrawn"
+var BingoPointsReason = "Bingo bonus"
+var BingoReversalPointsReason = "Bingo bonus withdrawn"
+
+func NewPointsAdjustment(userId uint, actorId uint, createPointsAdjustmentRequest types.CreatePointsAdjustmentRequest) PointsEntry {
+	points := int(createPointsAdjustmentRequest.Points)
+	if createPointsAdjustmentRequest.Type == types.PenaltyPointsEntry {
+		points = -points
+	}
+
+	return PointsEntry{
+		UserID:  userId,
+		Type:    createPointsAdjustmentRequest.Type,
+		Points:  points,
+		Reason:  createPointsAdjustmentRequest.Reason,
+		ActorID: &actorId,
+	}
+}
+
+func (entry PointsEntry) Save() (PointsEntry, error) {
+	conn := GetConnection()
+	if err := conn.Create(&entry).GetError(); err != nil {
+		return PointsEntry{}, err
+	}
+	return entry, nil
+}
+
+// GetPointsLedger returns the entries of the user, oldest first
+func GetPointsLedger(userId uint) ([]PointsEntry, error) {
+	conn := GetConnection()
+	return conn.GetPointsEntries(userId)
+}
+
+// newSubmissionPointsEntry returns the entry that brings the points recorded for the submission in line with
+// what it counts for now, or nil if they already are. Approved submissions count for their points, others for none.
+func newSubmissionPointsEntry(submission Submission, recorded int) *PointsEntry {
+	points := 0
+	if submission.IsApproved() {
+		points = int(submission.Points)
+	}
+	if points == recorded {
+		return nil
+	}
+
+	entry := PointsEntry{
+		UserID:       submission.UserID,
+		Type:         types.SubmissionPointsEntry,
+		Points:       points - recorded,
+		Reason:       SubmissionPointsReason,
+		ChallengeID:  &submission.ChallengeID,
+		SubmissionID: &submission.ID,
+	}
+	if entry.Points < 0 {
+		entry.Type = types.ReversalPointsEntry
+		entry.Reason = ReversalPointsReason
+	}
+	return &entry
+}
+
+// newBingoPointsEntries returns the entries that bring the bingo bonus recorded for every user in line with
+// the bonus of their card now, users missing from the bonuses have none. Users are in order of their ID.
+func newBingoPointsEntries(bonuses map[uint]uint, recorded map[uint]int) []PointsEntry {
+	userIds := make([]uint, 0, len(bonuses)+len(recorded))
+	for userId := range bonuses {
+		userIds = append(userIds, userId)
+	}
+	for userId := range recorded {
+		if _, ok := bonuses[userId]; !ok {
+			userIds = append(userIds, userId)
+		}
+	}
+	slices.Sort(userIds)
+
+	var entries []PointsEntry
+	for _, userId := range userIds {
+		points := int(bonuses[userId]) - recorded[userId]
+		if points == 0 {
+			continue
+		}
+
+		entry := PointsEntry{
+			UserID: userId,
+			Type:   types.BingoPointsEntry,
+			Points: points,
+			Reason: BingoPointsReason,
+		}
+		if points < 0 {
+			entry.Reason = BingoReversalPointsReason
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// AfterCreate records the points of a new submission in the ledger, in the same transaction
+func (s *Submission) AfterCreate(tx *gorm.DB) error {
+	return recordSubmissionPoints(tx, *s)
+}
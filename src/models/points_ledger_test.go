@@ -0,0 +1,99 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"testing"
+	"the-wedding-game-api/types"
+)
+
+func TestNewPointsAdjustment(t *testing.T) {
+	bonus := NewPointsAdjustment(2, 1, types.CreatePointsAdjustmentRequest{Type: types.BonusPointsEntry, Points: 50, Reason: "best dancer"})
+	if bonus.UserID != 2 || bonus.Points != 50 || bonus.Type != types.BonusPointsEntry || bonus.Reason != "best dancer" || *bonus.ActorID != 1 {
+		t.Errorf("expected a bonus of 50 points by user 1 but got %v", bonus)
+	}
+
+	penalty := NewPointsAdjustment(2, 1, types.CreatePointsAdjustmentRequest{Type: types.PenaltyPointsEntry, Points: 20, Reason: "cheating"})
+	if penalty.Points != -20 || penalty.Type != types.PenaltyPointsEntry {
+		t.Errorf("expected a penalty of -20 points but got %v", penalty)
+	}
+}
+
+func TestNewSubmissionPointsEntry(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     types.SubmissionState
+		points    uint
+		recorded  int
+		expected  int
+		entryType types.PointsEntryType
+	}{
+		{"completed", types.ApprovedSubmission, 100, 0, 100, types.SubmissionPointsEntry},
+		{"pending", types.PendingSubmission, 100, 0, 0, ""},
+		{"approved after moderation", types.ApprovedSubmission, 80, 0, 80, types.SubmissionPointsEntry},
+		{"rejected after approval", types.RejectedSubmission, 80, 80, -80, types.ReversalPointsEntry},
+		{"unchanged", types.ApprovedSubmission, 100, 100, 0, ""},
+		{"prediction resolved lower", types.ApprovedSubmission, 40, 100, -60, types.ReversalPointsEntry},
+	}
+
+	for _, test := range tests {
+		submission := Submission{Model: gorm.Model{ID: 3}, UserID: 2, ChallengeID: 1, Points: test.points, State: test.state}
+		entry := newSubmissionPointsEntry(submission, test.recorded)
+
+		if test.expected == 0 {
+			if entry != nil {
+				t.Errorf("%s: expected no entry but got %v", test.name, entry)
+			}
+			continue
+		}
+
+		if entry == nil || entry.Points != test.expected || entry.Type != test.entryType {
+			t.Errorf("%s: expected a %s entry of %d points but got %v", test.name, test.entryType, test.expected, entry)
+			continue
+		}
+		if entry.UserID != 2 || *entry.ChallengeID != 1 || *entry.SubmissionID != 3 || entry.ActorID != nil {
+			t.Errorf("%s: expected the entry to point to the submission but got %v", test.name, entry)
+		}
+	}
+}
+
+func TestNewBingoPointsEntries(t *testing.T) {
+	bonuses := map[uint]uint{1: 60, 2: 10, 3: 20}
+	recorded := map[uint]int{1: 10, 2: 10, 4: 20}
+
+	entries := newBingoPointsEntries(bonuses, recorded)
+
+	expected := []struct {
+		userId uint
+		points int
+		reason string
+	}{
+		{1, 50, BingoPointsReason},
+		{3, 20, BingoPointsReason},
+		{4, -20, BingoReversalPointsReason},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries but got %v", len(expected), entries)
+	}
+	for i, entry := range entries {
+		if entry.UserID != expected[i].userId || entry.Points != expected[i].points || entry.Reason != expected[i].reason ||
+			entry.Type != types.BingoPointsEntry || entry.ChallengeID != nil {
+			t.Errorf("expected %v but got %v", expected[i], entry)
+		}
+	}
+}
+
+func TestGetPointsLedger(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.Create(&PointsEntry{UserID: 1, Type: types.BonusPointsEntry, Points: 50})
+	mockDB.Create(&PointsEntry{UserID: 2, Type: types.PenaltyPointsEntry, Points: -20})
+	mockDB.Create(&PointsEntry{UserID: 1, Type: types.SubmissionPointsEntry, Points: 100})
+
+	entries, err := GetPointsLedger(1)
+	if err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	if len(entries) != 2 || entries[0].Points != 50 || entries[1].Points != 100 {
+		t.Errorf("expected the 2 entries of user 1 but got %v", entries)
+	}
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+	"time"
+)
+
+func (challenge *Challenge) setPredictionRules(predictionRules types.PredictionRules) {
+	challenge.PredictionMode = predictionRules.Mode
+	challenge.PredictionClosest = predictionRules.Closest
+	challenge.PredictionTolerance = predictionRules.Tolerance
+}
+
+func (challenge Challenge) GetPredictionRules() types.PredictionRules {
+	mode := challenge.PredictionMode
+	if mode == "" {
+		mode = types.ExactPrediction
+	}
+
+	closest := challenge.PredictionClosest
+	if mode == types.ClosestPrediction && closest == 0 {
+		closest = 1
+	}
+
+	return types.PredictionRules{
+		Mode:      mode,
+		Closest:   closest,
+		Tolerance: challenge.PredictionTolerance,
+	}
+}
+
+// IsResolved returns true once the admin posted the outcome of a prediction challenge
+func (challenge Challenge) IsResolved() bool {
+	return challenge.ResolvedAt != nil
+}
+
+// verifyAnswerForPrediction accepts any guess until the outcome is posted, guesses are scored on resolution.
+// Closest and tolerance predictions compare numbers, so their guesses must be numbers.
+func verifyAnswerForPrediction(challenge Challenge, answer string) (bool, error) {
+	if challenge.IsResolved() {
+		return false, apperrors.NewValidationError(constants.PredictionResolvedError)
+	}
+
+	if challenge.GetPredictionRules().Mode != types.ExactPrediction {
+		if _, err := utils.ParseNumber(answer); err != nil {
+			return false, apperrors.NewValidationError(constants.PredictionMustBeNumberError)
+		}
+	}
+	return true, nil
+}
+
+// Resolve posts the outcome of a prediction challenge and writes the awarded points onto the guesses,
+// so the leaderboard reflects them right away. Resolving again replaces the outcome and the points.
+func (challenge Challenge) Resolve(outcome string) (Challenge, []types.PredictionResult, error) {
+	if challenge.Type != types.PredictionChallenge {
+		return Challenge{}, nil, apperrors.NewValidationError(constants.NotAPredictionError)
+	}
+
+	rules := challenge.GetPredictionRules()
+	if rules.Mode != types.ExactPrediction {
+		if _, err := utils.ParseNumber(outcome); err != nil {
+			return Challenge{}, nil, apperrors.NewValidationError(constants.PredictionOutcomeMustBeNumberError)
+		}
+	}
+
+	conn := GetConnection()
+	submissions, err := conn.GetChallengeSubmissions(challenge.ID)
+	if err != nil {
+		return Challenge{}, nil, err
+	}
+
+	guesses := make([]string, len(submissions))
+	for i := range submissions {
+		guesses[i] = submissions[i].Answer
+	}
+	winners := pickPredictionWinners(rules, outcome, guesses)
+
+	var results = make([]types.PredictionResult, 0, len(submissions))
+	for i, submission := range submissions {
+		// Losing guesses are rejected, otherwise they would count as completions for prerequisites and bingo cells
+		submission.State = types.RejectedSubmission
+		submission.RejectionReason = constants.PredictionLostReason
+		submission.Points = 0
+		if winners[i] {
+			submission.State = types.ApprovedSubmission
+			submission.RejectionReason = ""
+			hintCost, err := getHintCostForUser(submission.UserID, challenge.ID)
+			if err != nil {
+				return Challenge{}, nil, err
+			}
+			if hintCost < challenge.Points {
+				submission.Points = challenge.Points - hintCost
+			}
+		}
+
+		submissions[i] = submission
+		results = append(results, types.PredictionResult{
+			SubmissionId: submission.ID,
+			UserId:       submission.UserID,
+			Guess:        submission.Answer,
+			Winner:       winners[i],
+			Points:       submission.Points,
+		})
+	}
+
+	resolvedAt := time.Now()
+	if err := conn.ResolveChallenge(challenge.ID, outcome, resolvedAt, submissions); err != nil {
+		return Challenge{}, nil, err
+	}
+	refreshBingoBonuses()
+
+	challenge.Outcome = outcome
+	challenge.ResolvedAt = &resolvedAt
+	return challenge, results, nil
+}
+
+// pickPredictionWinners returns for every guess whether it wins against the outcome
+func pickPredictionWinners(rules types.PredictionRules, outcome string, guesses []string) []bool {
+	winners := make([]bool, len(guesses))
+	if rules.Mode == types.ExactPrediction {
+		for i, guess := range guesses {
+			winners[i] = isExactPrediction(outcome, guess)
+		}
+		return winners
+	}
+
+	expected, err := utils.ParseNumber(outcome)
+	if err != nil {
+		return winners
+	}
+
+	distances := make([]float64, len(guesses))
+	for i, guess := range guesses {
+		distances[i] = math.Inf(1)
+		if value, err := utils.ParseNumber(guess); err == nil {
+			distances[i] = math.Abs(value - expected)
+		}
+	}
+
+	maxDistance := rules.Tolerance
+	if rules.Mode == types.ClosestPrediction {
+		sorted := slices.Clone(distances)
+		slices.Sort(sorted)
+		if len(sorted) == 0 {
+			return winners
+		}
+		maxDistance = sorted[min(int(rules.Closest), len(sorted))-1]
+	}
+
+	for i, distance := range distances {
+		winners[i] = !math.IsInf(distance, 1) && distance <= maxDistance
+	}
+	return winners
+}
+
+// isExactPrediction compares numbers by value and anything else like a normalized answer
+func isExactPrediction(outcome string, guess string) bool {
+	expected, expectedErr := utils.ParseNumber(outcome)
+	value, valueErr := utils.ParseNumber(guess)
+	if expectedErr == nil && valueErr == nil {
+		return expected == value
+	}
+	return utils.NormalizeAnswer(outcome) == utils.NormalizeAnswer(guess)
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"slices"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+var testPredictionChallenge = Challenge{ID: 88, Name: "speech", Description: "How long will the best-man speech last?",
+	Points: 50, Type: types.PredictionChallenge, Status: types.ActiveChallenge, PredictionMode: types.ClosestPrediction}
+
+func TestGetPredictionRulesDefaults(t *testing.T) {
+	rules := Challenge{}.GetPredictionRules()
+	if rules.Mode != types.ExactPrediction {
+		t.Errorf("expected exact predictions by default but got %s", rules.Mode)
+	}
+
+	rules = testPredictionChallenge.GetPredictionRules()
+	if rules.Mode != types.ClosestPrediction || rules.Closest != 1 {
+		t.Errorf("expected the single closest guess to win by default but got %v", rules)
+	}
+}
+
+func TestPickPredictionWinnersExact(t *testing.T) {
+	rules := types.PredictionRules{Mode: types.ExactPrediction}
+	winners := pickPredictionWinners(rules, "Aunt Mary", []string{"aunt mary", "Uncle Bob", "AUNT MARY!"})
+	if !slices.Equal(winners, []bool{true, false, true}) {
+		t.Errorf("unexpected winners %v", winners)
+	}
+
+	winners = pickPredictionWinners(rules, "7", []string{"7.0", "7,5", "seven"})
+	if !slices.Equal(winners, []bool{true, false, false}) {
+		t.Errorf("unexpected winners %v", winners)
+	}
+}
+
+func TestPickPredictionWinnersClosest(t *testing.T) {
+	rules := types.PredictionRules{Mode: types.ClosestPrediction, Closest: 2}
+	winners := pickPredictionWinners(rules, "10", []string{"4", "12", "9", "30"})
+	if !slices.Equal(winners, []bool{false, true, true, false}) {
+		t.Errorf("expected the two closest guesses to win but got %v", winners)
+	}
+}
+
+func TestPickPredictionWinnersClosestSharesTies(t *testing.T) {
+	rules := types.PredictionRules{Mode: types.ClosestPrediction, Closest: 1}
+	winners := pickPredictionWinners(rules, "10", []string{"8", "12", "15"})
+	if !slices.Equal(winners, []bool{true, true, false}) {
+		t.Errorf("expected tied guesses to share the win but got %v", winners)
+	}
+}
+
+func TestPickPredictionWinnersTolerance(t *testing.T) {
+	rules := types.PredictionRules{Mode: types.TolerancePrediction, Tolerance: 1.5}
+	winners := pickPredictionWinners(rules, "10", []string{"8.5", "11", "12", "not a number"})
+	if !slices.Equal(winners, []bool{true, true, false, false}) {
+		t.Errorf("unexpected winners %v", winners)
+	}
+}
+
+func TestVerifyAnswerForPredictionRequiresNumber(t *testing.T) {
+	_, err := verifyAnswerForPrediction(testPredictionChallenge, "about ten minutes")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "prediction must be a number" {
+		t.Errorf("expected prediction must be a number but got %s", err.Error())
+	}
+
+	correct, err := verifyAnswerForPrediction(testPredictionChallenge, "10")
+	if err != nil || !correct {
+		t.Errorf("expected the guess to be accepted but got %v %v", correct, err)
+	}
+}
+
+func TestVerifyAnswerForResolvedPrediction(t *testing.T) {
+	resolved := testPredictionChallenge
+	resolved.ResolvedAt = &resolved.CreatedAt
+
+	_, err := verifyAnswerForPrediction(resolved, "10")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if err.Error() != "the outcome of this prediction was already posted" {
+		t.Errorf("expected prediction resolved error but got %s", err.Error())
+	}
+}
+
+func TestCompletePredictionIsPendingWithoutPoints(t *testing.T) {
+	SetupMockDb()
+
+	submission, err := CompleteChallenge(1, testPredictionChallenge, "10")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if submission.State != types.PendingSubmission || submission.Points != 0 {
+		t.Errorf("expected a pending guess without points but got %v", submission)
+	}
+}
+
+func TestResolvePrediction(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	challenge := testPredictionChallenge
+	database.Create(&challenge)
+	database.Create(&Submission{Model: gorm.Model{ID: 1}, UserID: 1, ChallengeID: challenge.ID, Answer: "9", State: types.PendingSubmission})
+	database.Create(&Submission{Model: gorm.Model{ID: 2}, UserID: 2, ChallengeID: challenge.ID, Answer: "20", State: types.PendingSubmission})
+
+	resolved, results, err := challenge.Resolve("10")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if resolved.Outcome != "10" || !resolved.IsResolved() {
+		t.Errorf("expected a resolved challenge but got %v", resolved)
+	}
+
+	expected := []types.PredictionResult{
+		{SubmissionId: 1, UserId: 1, Guess: "9", Winner: true, Points: 50},
+		{SubmissionId: 2, UserId: 2, Guess: "20", Winner: false, Points: 0},
+	}
+	if !slices.Equal(results, expected) {
+		t.Errorf("expected %v but got %v", expected, results)
+	}
+
+	for _, submission := range database.(*MockDB).allSubmissions() {
+		if submission.ID == 1 && submission.State != types.ApprovedSubmission {
+			t.Errorf("expected the winning guess to be approved but got %v", submission)
+		}
+		if submission.ID == 2 && submission.State != types.RejectedSubmission {
+			t.Errorf("expected the losing guess to be rejected but got %v", submission)
+		}
+	}
+}
+
+func TestResolveNonPrediction(t *testing.T) {
+	SetupMockDb()
+
+	_, _, err := testChallenge123.Resolve("10")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "only prediction challenges can be resolved" {
+		t.Errorf("expected not a prediction error but got %s", err.Error())
+	}
+}
+
+func TestResolvePredictionWithTextOutcome(t *testing.T) {
+	SetupMockDb()
+
+	_, _, err := testPredictionChallenge.Resolve("ten minutes")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if err.Error() != "outcome must be a number" {
+		t.Errorf("expected outcome must be a number but got %s", err.Error())
+	}
+}
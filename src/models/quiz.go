@@ -0,0 +1,344 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"slices"
+	"strconv"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"time"
+)
+
+// QuizRound is a live trivia round, once started every question is open for QuestionSeconds, one after the other
+type QuizRound struct {
+	gorm.Model
+	Name            string `gorm:"not null"`
+	QuestionSeconds uint   `gorm:"not null"`
+	StartedAt       *time.Time
+}
+
+// QuizQuestion places a challenge in a round, positions start at 0. A challenge is asked in one round at most.
+type QuizQuestion struct {
+	gorm.Model
+	RoundID     uint `gorm:"not null;index"`
+	ChallengeID uint `gorm:"not null;uniqueIndex"`
+	Position    uint `gorm:"not null"`
+	Round       QuizRound
+	Challenge   Challenge
+}
+
+// CurrentQuizQuestion is the question of the running round that is open right now
+type CurrentQuizQuestion struct {
+	Round    QuizRound
+	Question QuizQuestion
+	Total    uint
+	OpensAt  time.Time
+	ClosesAt time.Time
+}
+
+func NewQuizRound(name string, questionSeconds uint) QuizRound {
+	return QuizRound{
+		Name:            name,
+		QuestionSeconds: questionSeconds,
+	}
+}
+
+func (round QuizRound) Save() (QuizRound, error) {
+	conn := GetConnection()
+	if err := conn.Create(&round).GetError(); err != nil {
+		return QuizRound{}, err
+	}
+	return round, nil
+}
+
+func (question QuizQuestion) Save() (QuizQuestion, error) {
+	conn := GetConnection()
+	if err := conn.Create(&question).GetError(); err != nil {
+		return QuizQuestion{}, err
+	}
+	return question, nil
+}
+
+// CreateQuizRound creates a round asking the challenges in the given order, it starts when the admin starts it.
+// The request is expected to hold different challenges.
+func CreateQuizRound(createQuizRoundRequest types.CreateQuizRoundRequest) (QuizRound, []QuizQuestion, error) {
+	challengeIds := createQuizRoundRequest.Challenges
+	conn := GetConnection()
+	challenges, err := conn.GetChallengesByIds(challengeIds)
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+	if len(challenges) != len(challengeIds) {
+		return QuizRound{}, nil, apperrors.NewValidationError(constants.InvalidQuizRoundError)
+	}
+
+	for _, challenge := range challenges {
+		if challenge.Type != types.AnswerQuestionChallenge && challenge.Type != types.MultipleChoiceChallenge {
+			return QuizRound{}, nil, apperrors.NewValidationError(constants.QuizChallengeTypeError)
+		}
+	}
+
+	quizChallengeIds, err := GetQuizChallengeIds()
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+	if slices.ContainsFunc(challengeIds, func(challengeId uint) bool { return quizChallengeIds[challengeId] }) {
+		return QuizRound{}, nil, apperrors.NewValidationError(constants.InvalidQuizRoundError)
+	}
+
+	round, err := NewQuizRound(createQuizRoundRequest.Name, createQuizRoundRequest.QuestionSeconds).Save()
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+
+	questions := make([]QuizQuestion, len(challengeIds))
+	for i, challengeId := range challengeIds {
+		question := QuizQuestion{RoundID: round.ID, ChallengeID: challengeId, Position: uint(i)}
+		if questions[i], err = question.Save(); err != nil {
+			return QuizRound{}, nil, err
+		}
+	}
+	return round, questions, nil
+}
+
+func GetQuizRounds() ([]QuizRound, error) {
+	conn := GetConnection()
+	return conn.GetQuizRounds()
+}
+
+// GetQuizRound returns the round and its questions in the order they are asked
+func GetQuizRound(roundId uint) (QuizRound, []QuizQuestion, error) {
+	rounds, err := GetQuizRounds()
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+
+	index := slices.IndexFunc(rounds, func(round QuizRound) bool { return round.ID == roundId })
+	if index < 0 {
+		return QuizRound{}, nil, apperrors.NewNotFoundError("Quiz round", strconv.Itoa(int(roundId)))
+	}
+
+	conn := GetConnection()
+	questions, err := conn.GetQuizQuestions(roundId)
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+	return rounds[index], questions, nil
+}
+
+// GetQuizChallengeIds returns the challenges asked in a quiz round, players can only see and answer them during the round
+func GetQuizChallengeIds() (map[uint]bool, error) {
+	conn := GetConnection()
+	questions, err := conn.GetAllQuizQuestions()
+	if err != nil {
+		return nil, err
+	}
+
+	challengeIds := make(map[uint]bool, len(questions))
+	for _, question := range questions {
+		challengeIds[question.ChallengeID] = true
+	}
+	return challengeIds, nil
+}
+
+// CheckNotInQuizRound returns a validation error for challenges that can only be answered during their quiz round
+func (challenge Challenge) CheckNotInQuizRound() error {
+	quizChallengeIds, err := GetQuizChallengeIds()
+	if err != nil {
+		return err
+	}
+	if quizChallengeIds[challenge.ID] {
+		return apperrors.NewValidationError(constants.QuizChallengeError)
+	}
+	return nil
+}
+
+// CheckNotAskedInQuizRound returns a validation error if the challenge is a question of a quiz round,
+// deleting it would leave a question the round cannot ask
+func (challenge Challenge) CheckNotAskedInQuizRound() error {
+	quizChallengeIds, err := GetQuizChallengeIds()
+	if err != nil {
+		return err
+	}
+	if quizChallengeIds[challenge.ID] {
+		return apperrors.NewValidationError(constants.ChallengeInQuizRoundError)
+	}
+	return nil
+}
+
+// EndsAt returns when the last of the given number of questions closes, or nil if the round was not started
+func (round QuizRound) EndsAt(questionCount int) *time.Time {
+	if round.StartedAt == nil {
+		return nil
+	}
+	endsAt := round.StartedAt.Add(time.Duration(questionCount) * round.questionDuration())
+	return &endsAt
+}
+
+func (round QuizRound) GetStatus(questionCount int, now time.Time) types.QuizRoundStatus {
+	if round.StartedAt == nil {
+		return types.PendingQuizRound
+	}
+	if now.Before(*round.EndsAt(questionCount)) {
+		return types.RunningQuizRound
+	}
+	return types.FinishedQuizRound
+}
+
+func (round QuizRound) questionDuration() time.Duration {
+	return time.Duration(round.QuestionSeconds) * time.Second
+}
+
+// StartQuizRound opens the first question of the round right away, only one round can run at a time
+func StartQuizRound(roundId uint) (QuizRound, []QuizQuestion, error) {
+	round, questions, err := GetQuizRound(roundId)
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+	if round.StartedAt != nil {
+		return QuizRound{}, nil, apperrors.NewValidationError(constants.QuizRoundAlreadyStartedError)
+	}
+
+	current, err := getRunningQuizRound(time.Now())
+	if err != nil {
+		return QuizRound{}, nil, err
+	}
+	if current != nil {
+		return QuizRound{}, nil, apperrors.NewValidationError(constants.QuizRoundRunningError)
+	}
+
+	startedAt := time.Now()
+	conn := GetConnection()
+	if err := conn.StartQuizRound(round.ID, startedAt); err != nil {
+		return QuizRound{}, nil, err
+	}
+	round.StartedAt = &startedAt
+	return round, questions, nil
+}
+
+// getRunningQuizRound returns the round running at the given moment with its open question, or nil if none is running
+func getRunningQuizRound(now time.Time) (*CurrentQuizQuestion, error) {
+	rounds, err := GetQuizRounds()
+	if err != nil {
+		return nil, err
+	}
+
+	conn := GetConnection()
+	for _, round := range rounds {
+		if round.StartedAt == nil || now.Before(*round.StartedAt) {
+			continue
+		}
+
+		questions, err := conn.GetQuizQuestions(round.ID)
+		if err != nil {
+			return nil, err
+		}
+		if round.GetStatus(len(questions), now) != types.RunningQuizRound {
+			continue
+		}
+
+		index := int(now.Sub(*round.StartedAt) / round.questionDuration())
+		opensAt := round.StartedAt.Add(time.Duration(index) * round.questionDuration())
+		return &CurrentQuizQuestion{
+			Round:    round,
+			Question: questions[index],
+			Total:    uint(len(questions)),
+			OpensAt:  opensAt,
+			ClosesAt: opensAt.Add(round.questionDuration()),
+		}, nil
+	}
+	return nil, nil
+}
+
+// GetCurrentQuizQuestion returns the question that is open right now
+func GetCurrentQuizQuestion() (CurrentQuizQuestion, error) {
+	current, err := getRunningQuizRound(time.Now())
+	if err != nil {
+		return CurrentQuizQuestion{}, err
+	}
+	if current == nil {
+		return CurrentQuizQuestion{}, apperrors.NewNotFoundError("Quiz question", "current")
+	}
+	return *current, nil
+}
+
+// IsQuizQuestionAnswered returns true once the user answered the question, every question can be answered once
+func IsQuizQuestionAnswered(userId uint, challengeId uint) (bool, error) {
+	conn := GetConnection()
+	failed, err := conn.CountFailedAttempts(userId, challengeId)
+	if err != nil {
+		return false, err
+	}
+	if failed > 0 {
+		return true, nil
+	}
+
+	submission, err := conn.GetSubmission(userId, challengeId)
+	if err != nil {
+		return false, err
+	}
+	return submission != nil, nil
+}
+
+// AnswerQuizQuestion checks the answer to the open question, a correct answer scores more the faster it is given
+func AnswerQuizQuestion(userId uint, challengeId uint, answer string) (bool, uint, error) {
+	answeredAt := time.Now()
+	current, err := getRunningQuizRound(answeredAt)
+	if err != nil {
+		return false, 0, err
+	}
+	if current == nil || current.Question.ChallengeID != challengeId {
+		return false, 0, apperrors.NewValidationError(constants.QuizQuestionClosedError)
+	}
+
+	answered, err := IsQuizQuestionAnswered(userId, challengeId)
+	if err != nil {
+		return false, 0, err
+	}
+	if answered {
+		return false, 0, apperrors.NewValidationError(constants.QuizQuestionAnsweredError)
+	}
+
+	challenge, err := GetChallengeByID(challengeId)
+	if err != nil {
+		return false, 0, err
+	}
+
+	correct, err := VerifyAnswer(challengeId, answer)
+	if err != nil {
+		return false, 0, err
+	}
+
+	hintCost, err := getHintCostForUser(userId, challengeId)
+	if err != nil {
+		return false, 0, err
+	}
+
+	conn := GetConnection()
+	submission, err := conn.SaveQuizAnswer(NewAttempt(userId, challengeId, answer, correct), func(previousSolvers uint) (uint, error) {
+		return quizPoints(challenge.AwardedPoints(answeredAt, previousSolvers, hintCost),
+			answeredAt.Sub(current.OpensAt), current.Round.questionDuration()), nil
+	})
+	if err != nil || !correct {
+		return false, 0, err
+	}
+	refreshBingoBonuses()
+	return true, submission.Points, nil
+}
+
+// quizPoints awards half of the points for any correct answer and the other half the faster the answer was
+func quizPoints(points uint, elapsed time.Duration, duration time.Duration) uint {
+	remaining := max(duration-elapsed, 0)
+	return points/2 + uint(float64(points-points/2)*float64(remaining)/float64(duration))
+}
+
+// GetQuizResults returns the points every player scored in the round, highest first
+func GetQuizResults(roundId uint) ([]types.QuizResult, error) {
+	if _, _, err := GetQuizRound(roundId); err != nil {
+		return nil, err
+	}
+
+	conn := GetConnection()
+	return conn.GetQuizResults(roundId)
+}
@@ -0,0 +1,266 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"time"
+)
+
+// setupQuizRound adds a round of the challenges 1 to 3 with 30 seconds a question, started the given time ago
+func setupQuizRound(startedAgo *time.Duration) *MockDB {
+	mockDB := SetupMockDb()
+	round := &QuizRound{Model: gorm.Model{ID: 1}, Name: "Round 1", QuestionSeconds: 30}
+	if startedAgo != nil {
+		startedAt := time.Now().Add(-*startedAgo)
+		round.StartedAt = &startedAt
+	}
+	mockDB.Create(round)
+	for position := uint(0); position < 3; position++ {
+		mockDB.Create(&QuizQuestion{RoundID: 1, ChallengeID: position + 1, Position: position})
+	}
+	return mockDB
+}
+
+func startedAgo(duration time.Duration) *time.Duration {
+	return &duration
+}
+
+func TestQuizPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		points  uint
+	}{
+		{"instant", 0, 100},
+		{"half way", 15 * time.Second, 75},
+		{"last moment", 30 * time.Second, 50},
+		{"late", 40 * time.Second, 50},
+	}
+
+	for _, test := range tests {
+		if points := quizPoints(100, test.elapsed, 30*time.Second); points != test.points {
+			t.Errorf("%s: expected %d points but got %d", test.name, test.points, points)
+		}
+	}
+}
+
+func TestQuizRoundStatus(t *testing.T) {
+	startedAt := time.Now().Add(-time.Minute)
+	round := QuizRound{QuestionSeconds: 30}
+
+	if status := round.GetStatus(3, time.Now()); status != types.PendingQuizRound {
+		t.Errorf("expected PENDING but got %s", status)
+	}
+	if round.EndsAt(3) != nil {
+		t.Errorf("expected no end for a pending round but got %v", round.EndsAt(3))
+	}
+
+	round.StartedAt = &startedAt
+	if status := round.GetStatus(3, time.Now()); status != types.RunningQuizRound {
+		t.Errorf("expected RUNNING but got %s", status)
+	}
+	if status := round.GetStatus(2, time.Now()); status != types.FinishedQuizRound {
+		t.Errorf("expected FINISHED but got %s", status)
+	}
+	if endsAt := round.EndsAt(3); !endsAt.Equal(startedAt.Add(90 * time.Second)) {
+		t.Errorf("expected the round to end 90 seconds after it started but got %v", endsAt)
+	}
+}
+
+func TestGetCurrentQuizQuestion(t *testing.T) {
+	setupQuizRound(startedAgo(40 * time.Second))
+
+	current, err := GetCurrentQuizQuestion()
+	if err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	if current.Question.ChallengeID != 2 || current.Question.Position != 1 || current.Total != 3 {
+		t.Errorf("expected the second of 3 questions but got %v", current)
+	}
+	if current.ClosesAt.Sub(current.OpensAt) != 30*time.Second || current.OpensAt.After(time.Now().Add(-10*time.Second)) {
+		t.Errorf("expected the question to have opened 10 seconds ago for 30 seconds but got %v to %v", current.OpensAt, current.ClosesAt)
+	}
+}
+
+func TestGetCurrentQuizQuestionWithoutRunningRound(t *testing.T) {
+	tests := []struct {
+		name       string
+		startedAgo *time.Duration
+	}{
+		{"pending", nil},
+		{"finished", startedAgo(2 * time.Minute)},
+	}
+
+	for _, test := range tests {
+		setupQuizRound(test.startedAgo)
+
+		_, err := GetCurrentQuizQuestion()
+		if err == nil || !apperrors.IsNotFoundError(err) {
+			t.Errorf("%s: expected not found error but got %v", test.name, err)
+		}
+	}
+}
+
+func TestCreateQuizRound(t *testing.T) {
+	mockDB := SetupMockDb()
+	mockDB.Create(&Challenge{ID: 1, Type: types.AnswerQuestionChallenge})
+	mockDB.Create(&Challenge{ID: 2, Type: types.MultipleChoiceChallenge})
+
+	round, questions, err := CreateQuizRound(types.CreateQuizRoundRequest{Name: "Round 1", QuestionSeconds: 20, Challenges: []uint{2, 1}})
+	if err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	if round.Name != "Round 1" || round.QuestionSeconds != 20 || round.StartedAt != nil {
+		t.Errorf("expected a pending round of 20 seconds a question but got %v", round)
+	}
+	if len(questions) != 2 || questions[0].ChallengeID != 2 || questions[1].ChallengeID != 1 || questions[1].Position != 1 {
+		t.Errorf("expected the challenges in the order of the request but got %v", questions)
+	}
+}
+
+func TestCreateQuizRoundWithInvalidChallenges(t *testing.T) {
+	tests := []struct {
+		name       string
+		challenges []uint
+		expected   string
+	}{
+		{"unknown challenge", []uint{4, 5}, "challenges must exist, be different and not be part of another quiz round"},
+		{"in another round", []uint{1, 4}, "challenges must exist, be different and not be part of another quiz round"},
+		{"photo challenge", []uint{4, 6}, "quiz rounds can only ask answer question and multiple choice challenges"},
+	}
+
+	for _, test := range tests {
+		mockDB := setupQuizRound(nil)
+		mockDB.Create(&Challenge{ID: 1, Type: types.AnswerQuestionChallenge})
+		mockDB.Create(&Challenge{ID: 4, Type: types.AnswerQuestionChallenge})
+		mockDB.Create(&Challenge{ID: 6, Type: types.UploadPhotoChallenge})
+
+		_, _, err := CreateQuizRound(types.CreateQuizRoundRequest{Name: "Round 2", QuestionSeconds: 20, Challenges: test.challenges})
+		if err == nil || !apperrors.IsValidationError(err) || err.Error() != test.expected {
+			t.Errorf("%s: expected %q but got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestStartQuizRound(t *testing.T) {
+	setupQuizRound(nil)
+
+	round, questions, err := StartQuizRound(1)
+	if err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	if round.StartedAt == nil || len(questions) != 3 || round.GetStatus(len(questions), time.Now()) != types.RunningQuizRound {
+		t.Errorf("expected a running round of 3 questions but got %v", round)
+	}
+
+	current, err := GetCurrentQuizQuestion()
+	if err != nil || current.Question.ChallengeID != 1 {
+		t.Errorf("expected the first question to be open but got %v, %v", current, err)
+	}
+}
+
+func TestStartQuizRoundAlreadyStarted(t *testing.T) {
+	setupQuizRound(startedAgo(2 * time.Minute))
+
+	_, _, err := StartQuizRound(1)
+	if err == nil || !apperrors.IsValidationError(err) || err.Error() != "quiz round was already started" {
+		t.Errorf("expected already started error but got %v", err)
+	}
+}
+
+func TestStartQuizRoundWhileAnotherIsRunning(t *testing.T) {
+	mockDB := setupQuizRound(startedAgo(10 * time.Second))
+	mockDB.Create(&QuizRound{Model: gorm.Model{ID: 2}, Name: "Round 2", QuestionSeconds: 30})
+	mockDB.Create(&QuizQuestion{RoundID: 2, ChallengeID: 4})
+
+	_, _, err := StartQuizRound(2)
+	if err == nil || !apperrors.IsValidationError(err) || err.Error() != "another quiz round is running" {
+		t.Errorf("expected another round running error but got %v", err)
+	}
+}
+
+func TestStartQuizRoundNotFound(t *testing.T) {
+	setupQuizRound(nil)
+
+	_, _, err := StartQuizRound(2)
+	if err == nil || !apperrors.IsNotFoundError(err) {
+		t.Errorf("expected not found error but got %v", err)
+	}
+}
+
+func TestAnswerQuizQuestionNotOpen(t *testing.T) {
+	setupQuizRound(startedAgo(40 * time.Second))
+
+	_, _, err := AnswerQuizQuestion(1, 1, "answer")
+	if err == nil || !apperrors.IsValidationError(err) || err.Error() != "question is no longer open" {
+		t.Errorf("expected question closed error but got %v", err)
+	}
+}
+
+func TestAnswerQuizQuestionAlreadyAnswered(t *testing.T) {
+	mockDB := setupQuizRound(startedAgo(40 * time.Second))
+	mockDB.Create(&Attempt{UserID: 1, ChallengeID: 2, Value: "wrong", Correct: false})
+
+	_, _, err := AnswerQuizQuestion(1, 2, "answer")
+	if err == nil || !apperrors.IsValidationError(err) || err.Error() != "question was already answered" {
+		t.Errorf("expected question answered error but got %v", err)
+	}
+}
+
+func TestCheckNotInQuizRound(t *testing.T) {
+	setupQuizRound(nil)
+
+	if err := (Challenge{ID: 4}).CheckNotInQuizRound(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+
+	err := Challenge{ID: 2}.CheckNotInQuizRound()
+	if err == nil || !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %v", err)
+	}
+}
+
+func TestCheckNotAskedInQuizRound(t *testing.T) {
+	setupQuizRound(nil)
+
+	if err := (Challenge{ID: 4}).CheckNotAskedInQuizRound(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+
+	err := Challenge{ID: 2}.CheckNotAskedInQuizRound()
+	if err == nil || !apperrors.IsValidationError(err) || err.Error() != "Cannot delete a challenge that is part of a quiz round" {
+		t.Errorf("expected challenge in quiz round error but got %v", err)
+	}
+}
+
+func TestGetQuizResults(t *testing.T) {
+	mockDB := setupQuizRound(startedAgo(2 * time.Minute))
+	for _, submission := range []Submission{
+		{Model: gorm.Model{ID: 1}, UserID: 1, ChallengeID: 1, Points: 90, State: types.ApprovedSubmission},
+		{Model: gorm.Model{ID: 2}, UserID: 2, ChallengeID: 1, Points: 60, State: types.ApprovedSubmission},
+		{Model: gorm.Model{ID: 3}, UserID: 2, ChallengeID: 3, Points: 70, State: types.ApprovedSubmission},
+		{Model: gorm.Model{ID: 4}, UserID: 1, ChallengeID: 5, Points: 100, State: types.ApprovedSubmission},
+	} {
+		_, _ = mockDB.AddSubmission(submission)
+	}
+
+	results, err := GetQuizResults(1)
+	if err != nil {
+		t.Fatalf("expected nil but got %s", err.Error())
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results but got %v", results)
+	}
+	if results[0].UserId != 2 || results[0].Points != 130 || results[0].CorrectAnswers != 2 {
+		t.Errorf("expected user 2 first with 130 points for 2 answers but got %v", results[0])
+	}
+	if results[1].UserId != 1 || results[1].Points != 90 || results[1].CorrectAnswers != 1 {
+		t.Errorf("expected user 1 second with 90 points for 1 answer but got %v", results[1])
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+	"net/url"
+	"strings"
+	"the-wedding-game-api/config"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+const qrCodeSize = 512
+
+func generateSecretCode() string {
+	return strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:12])
+}
+
+// GetScanLink returns the deep link printed on the QR code, opening the challenge in the app with the code filled in
+func (challenge Challenge) GetScanLink() string {
+	return fmt.Sprintf("%s/challenges/%d/scan?code=%s", config.GetAppURL(), challenge.ID, url.QueryEscape(challenge.SecretCode))
+}
+
+// GetQRCode renders the scan link of a scan code challenge as a printable PNG
+func (challenge Challenge) GetQRCode() ([]byte, error) {
+	if challenge.Type != types.ScanCodeChallenge || challenge.SecretCode == "" {
+		return nil, apperrors.NewValidationError(constants.QRCodeNotAvailableError)
+	}
+
+	png, err := qrcode.Encode(challenge.GetScanLink(), qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("error while generating qr code: %w", err)
+	}
+	return png, nil
+}
+
+// verifyAnswerForScanCode accepts either the scanned code or the whole scanned link
+func verifyAnswerForScanCode(challenge Challenge, answer string) bool {
+	if challenge.SecretCode == "" {
+		return false
+	}
+
+	code := extractScannedCode(answer)
+	return subtle.ConstantTimeCompare([]byte(strings.ToUpper(code)), []byte(challenge.SecretCode)) == 1
+}
+
+func extractScannedCode(answer string) string {
+	answer = strings.TrimSpace(answer)
+	if link, err := url.Parse(answer); err == nil && link.Query().Get("code") != "" {
+		return link.Query().Get("code")
+	}
+	return answer
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+)
+
+var testScanCodeChallenge = Challenge{ID: 42, Name: "name", Description: "description", Points: 10,
+	Type: types.ScanCodeChallenge, Status: types.ActiveChallenge, SecretCode: "A1B2C3D4E5F6"}
+
+func TestGenerateSecretCode(t *testing.T) {
+	code := generateSecretCode()
+	if len(code) != 12 {
+		t.Errorf("expected a 12 character code but got %s", code)
+	}
+	if code == generateSecretCode() {
+		t.Errorf("expected different codes for different challenges")
+	}
+}
+
+func TestGetScanLink(t *testing.T) {
+	_ = os.Setenv("APP_URL", "https://game.example.com/")
+	defer func() { _ = os.Unsetenv("APP_URL") }()
+
+	link := testScanCodeChallenge.GetScanLink()
+	if link != "https://game.example.com/challenges/42/scan?code=A1B2C3D4E5F6" {
+		t.Errorf("expected deep link to the challenge but got %s", link)
+	}
+}
+
+func TestGetQRCode(t *testing.T) {
+	png, err := testScanCodeChallenge.GetQRCode()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if !bytes.HasPrefix(png, []byte("\x89PNG")) {
+		t.Errorf("expected a png image")
+	}
+}
+
+func TestGetQRCodeForOtherChallengeType(t *testing.T) {
+	_, err := testChallenge123.GetQRCode()
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+}
+
+func TestVerifyAnswerForScanCode(t *testing.T) {
+	tests := []struct {
+		answer   string
+		expected bool
+	}{
+		{"A1B2C3D4E5F6", true},
+		{" a1b2c3d4e5f6 ", true},
+		{"https://game.example.com/challenges/42/scan?code=A1B2C3D4E5F6", true},
+		{"https://game.example.com/challenges/42/scan?code=WRONG", false},
+		{"A1B2C3", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		if correct := verifyAnswerForScanCode(testScanCodeChallenge, test.answer); correct != test.expected {
+			t.Errorf("expected %v for %q but got %v", test.expected, test.answer, correct)
+		}
+	}
+}
+
+func TestVerifyAnswerForScanCodeWithoutCode(t *testing.T) {
+	challenge := testScanCodeChallenge
+	challenge.SecretCode = ""
+
+	if verifyAnswerForScanCode(challenge, "") {
+		t.Errorf("expected an empty code to never match")
+	}
+}
+
+func TestUpdateChallengeToScanCodeGeneratesCode(t *testing.T) {
+	SetupMockDb()
+
+	updated, err := testChallenge321.Update(types.UpdateChallengeRequest{
+		Name: testChallenge321.Name,
+		Type: types.ScanCodeChallenge,
+	})
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if updated.SecretCode == "" {
+		t.Errorf("expected a secret code to be generated")
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"math"
+	"the-wedding-game-api/types"
+	"time"
+)
+
+func (challenge *Challenge) setScoringRules(scoringRules types.ScoringRules) {
+	challenge.FirstSolverBonus = scoringRules.FirstSolverBonus
+	challenge.FirstSolverCount = scoringRules.FirstSolverCount
+	challenge.PointsDecay = scoringRules.Decay
+	challenge.DecayPoints = scoringRules.DecayPoints
+	challenge.DecayIntervalMinutes = scoringRules.DecayIntervalMinutes
+	challenge.MinimumPoints = scoringRules.MinimumPoints
+}
+
+func (challenge Challenge) GetScoringRules() types.ScoringRules {
+	decay := challenge.PointsDecay
+	if decay == "" {
+		decay = types.NoPointsDecay
+	}
+
+	return types.ScoringRules{
+		FirstSolverBonus:     challenge.FirstSolverBonus,
+		FirstSolverCount:     challenge.FirstSolverCount,
+		Decay:                decay,
+		DecayPoints:          challenge.DecayPoints,
+		DecayIntervalMinutes: challenge.DecayIntervalMinutes,
+		MinimumPoints:        challenge.MinimumPoints,
+	}
+}
+
+// OpenedAt returns the moment points start to decay, the start of the availability window or the creation of the challenge
+func (challenge Challenge) OpenedAt() time.Time {
+	if challenge.AvailableFrom != nil {
+		return *challenge.AvailableFrom
+	}
+	return challenge.CreatedAt
+}
+
+// PointsAt returns the points of the challenge after decay at the given moment, never less than the minimum points
+func (challenge Challenge) PointsAt(moment time.Time) uint {
+	rules := challenge.GetScoringRules()
+	if rules.Decay == types.NoPointsDecay || rules.DecayPoints == 0 || rules.DecayIntervalMinutes == 0 {
+		return challenge.Points
+	}
+
+	elapsed := moment.Sub(challenge.OpenedAt())
+	if elapsed <= 0 {
+		return challenge.Points
+	}
+
+	intervals := elapsed.Minutes() / float64(rules.DecayIntervalMinutes)
+	if rules.Decay == types.StepPointsDecay {
+		intervals = math.Floor(intervals)
+	}
+
+	minimum := min(rules.MinimumPoints, challenge.Points)
+	points := float64(challenge.Points) - intervals*float64(rules.DecayPoints)
+	if points <= float64(minimum) {
+		return minimum
+	}
+	return uint(points)
+}
+
+// AwardedPoints returns the points for solving the challenge at the given moment after the given number of other solvers,
+// including the first solver bonus and minus the cost of the revealed hints
+func (challenge Challenge) AwardedPoints(solvedAt time.Time, previousSolvers uint, hintCost uint) uint {
+	points := challenge.PointsAt(solvedAt)
+	if previousSolvers < challenge.FirstSolverCount {
+		points += challenge.FirstSolverBonus
+	}
+
+	if hintCost >= points {
+		return 0
+	}
+	return points - hintCost
+}
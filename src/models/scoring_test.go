@@ -0,0 +1,161 @@
+package models
+
+import (
+	"testing"
+	"the-wedding-game-api/types"
+	"time"
+)
+
+var scoringOpenedAt = time.Date(2026, 6, 20, 18, 0, 0, 0, time.UTC)
+
+func createScoringChallenge(scoringRules types.ScoringRules) Challenge {
+	challenge := testChallenge123
+	challenge.Points = 100
+	challenge.AvailableFrom = &scoringOpenedAt
+	challenge.setScoringRules(scoringRules)
+	return challenge
+}
+
+func TestGetScoringRulesDefaultsToNoDecay(t *testing.T) {
+	rules := testChallenge123.GetScoringRules()
+	if rules.Decay != types.NoPointsDecay {
+		t.Errorf("expected NONE but got %s", rules.Decay)
+	}
+}
+
+func TestPointsAtWithoutDecay(t *testing.T) {
+	challenge := createScoringChallenge(types.ScoringRules{})
+
+	points := challenge.PointsAt(scoringOpenedAt.Add(5 * time.Hour))
+	if points != 100 {
+		t.Errorf("expected 100 but got %d", points)
+	}
+}
+
+func TestPointsAtWithLinearDecay(t *testing.T) {
+	challenge := createScoringChallenge(types.ScoringRules{
+		Decay:                types.LinearPointsDecay,
+		DecayPoints:          10,
+		DecayIntervalMinutes: 60,
+	})
+
+	tests := []struct {
+		elapsed  time.Duration
+		expected uint
+	}{
+		{-time.Hour, 100},
+		{0, 100},
+		{30 * time.Minute, 95},
+		{90 * time.Minute, 85},
+		{20 * time.Hour, 0},
+	}
+
+	for _, test := range tests {
+		points := challenge.PointsAt(scoringOpenedAt.Add(test.elapsed))
+		if points != test.expected {
+			t.Errorf("expected %d after %s but got %d", test.expected, test.elapsed, points)
+		}
+	}
+}
+
+func TestPointsAtWithStepDecay(t *testing.T) {
+	challenge := createScoringChallenge(types.ScoringRules{
+		Decay:                types.StepPointsDecay,
+		DecayPoints:          10,
+		DecayIntervalMinutes: 60,
+		MinimumPoints:        40,
+	})
+
+	tests := []struct {
+		elapsed  time.Duration
+		expected uint
+	}{
+		{59 * time.Minute, 100},
+		{60 * time.Minute, 90},
+		{150 * time.Minute, 80},
+		{10 * time.Hour, 40},
+	}
+
+	for _, test := range tests {
+		points := challenge.PointsAt(scoringOpenedAt.Add(test.elapsed))
+		if points != test.expected {
+			t.Errorf("expected %d after %s but got %d", test.expected, test.elapsed, points)
+		}
+	}
+}
+
+func TestPointsAtDecaysFromCreationWithoutAvailability(t *testing.T) {
+	challenge := createScoringChallenge(types.ScoringRules{
+		Decay:                types.StepPointsDecay,
+		DecayPoints:          10,
+		DecayIntervalMinutes: 60,
+	})
+	challenge.AvailableFrom = nil
+	challenge.CreatedAt = scoringOpenedAt
+
+	points := challenge.PointsAt(scoringOpenedAt.Add(2 * time.Hour))
+	if points != 80 {
+		t.Errorf("expected 80 but got %d", points)
+	}
+}
+
+func TestAwardedPointsWithFirstSolverBonus(t *testing.T) {
+	challenge := createScoringChallenge(types.ScoringRules{
+		FirstSolverBonus: 25,
+		FirstSolverCount: 2,
+	})
+
+	tests := []struct {
+		previousSolvers uint
+		expected        uint
+	}{
+		{0, 125},
+		{1, 125},
+		{2, 100},
+	}
+
+	for _, test := range tests {
+		points := challenge.AwardedPoints(scoringOpenedAt, test.previousSolvers, 0)
+		if points != test.expected {
+			t.Errorf("expected %d after %d solvers but got %d", test.expected, test.previousSolvers, points)
+		}
+	}
+}
+
+func TestAwardedPointsWithHintCost(t *testing.T) {
+	challenge := createScoringChallenge(types.ScoringRules{})
+
+	if points := challenge.AwardedPoints(scoringOpenedAt, 0, 30); points != 70 {
+		t.Errorf("expected 70 but got %d", points)
+	}
+
+	if points := challenge.AwardedPoints(scoringOpenedAt, 0, 150); points != 0 {
+		t.Errorf("expected 0 but got %d", points)
+	}
+}
+
+func TestCompleteChallengeAwardsPoints(t *testing.T) {
+	SetupMockDb()
+	challenge := createScoringChallenge(types.ScoringRules{
+		FirstSolverBonus: 20,
+		FirstSolverCount: 1,
+	})
+
+	first, err := CompleteChallenge(1, challenge, "answer")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if first.Points != 120 {
+		t.Errorf("expected 120 for the first solver but got %d", first.Points)
+	}
+
+	second, err := CompleteChallenge(2, challenge, "answer")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if second.Points != 100 {
+		t.Errorf("expected 100 for the second solver but got %d", second.Points)
+	}
+}
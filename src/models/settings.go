@@ -0,0 +1,33 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"strconv"
+)
+
+// Setting is a game-wide option admins can change while the game runs
+type Setting struct {
+	gorm.Model
+	Key   string `gorm:"unique;not null"`
+	Value string `gorm:"not null"`
+}
+
+const openRegistrationSetting = "open_registration"
+
+// IsRegistrationOpen returns true if anyone can join without an invite code, as before the guest list existed
+func IsRegistrationOpen() (bool, error) {
+	conn := GetConnection()
+	value, err := conn.GetSetting(openRegistrationSetting)
+	if err != nil {
+		return false, err
+	}
+	if value == nil {
+		return true, nil
+	}
+	return strconv.ParseBool(*value)
+}
+
+func SetRegistrationOpen(open bool) error {
+	conn := GetConnection()
+	return conn.SetSetting(openRegistrationSetting, strconv.FormatBool(open))
+}
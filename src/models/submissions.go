@@ -4,15 +4,19 @@ import (
 	"gorm.io/gorm"
 	apperrors "the-wedding-game-api/errors"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 type Submission struct {
 	gorm.Model
-	UserID      uint   `gorm:"not null;uniqueIndex:idx_user_challenge"`
-	ChallengeID uint   `gorm:"not null;uniqueIndex:idx_user_challenge"`
-	Answer      string `gorm:"not null"`
-	User        User
-	Challenge   Challenge
+	UserID          uint                  `gorm:"not null;uniqueIndex:idx_user_challenge"`
+	ChallengeID     uint                  `gorm:"not null;uniqueIndex:idx_user_challenge"`
+	Answer          string                `gorm:"not null"`
+	Points          uint                  `gorm:"not null;default:0"`
+	State           types.SubmissionState `gorm:"not null;default:'APPROVED';index"`
+	RejectionReason string                `gorm:"not null;default:''"`
+	User            User
+	Challenge       Challenge
 }
 
 func NewSubmission(userId uint, challengeId uint, answer string) Submission {
@@ -20,10 +24,16 @@ func NewSubmission(userId uint, challengeId uint, answer string) Submission {
 		UserID:      userId,
 		ChallengeID: challengeId,
 		Answer:      answer,
+		State:       types.ApprovedSubmission,
 	}
 	return submission
 }
 
+// IsApproved returns true if the submission counts as completing the challenge
+func (s Submission) IsApproved() bool {
+	return s.State == types.ApprovedSubmission
+}
+
 func (s *Submission) Save() (*Submission, error) {
 	conn := GetConnection()
 	if err := conn.Create(s).GetError(); err != nil {
@@ -32,20 +42,78 @@ func (s *Submission) Save() (*Submission, error) {
 	return s, nil
 }
 
+// CompleteChallenge saves the submission with the points awarded right now,
+// so later changes to the scoring rules of the challenge do not change the leaderboard.
+// Photos and videos wait for moderation, a new upload replaces the pending or rejected one of the user.
+// Predictions wait for the outcome without points, a new guess replaces the previous one.
+func CompleteChallenge(userId uint, challenge Challenge, answer string) (Submission, error) {
+	conn := GetConnection()
+	existing, err := conn.GetSubmission(userId, challenge.ID)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	hintCost, err := getHintCostForUser(userId, challenge.ID)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	submission := NewSubmission(userId, challenge.ID, answer)
+	if existing != nil {
+		submission = *existing
+		submission.Answer = answer
+		submission.RejectionReason = ""
+	}
+	if challenge.IsModerated() || challenge.Type == types.PredictionChallenge {
+		submission.State = types.PendingSubmission
+	}
+
+	solvedAt := time.Now()
+	submission, err = conn.SaveRankedSubmission(submission, func(previousSolvers uint) (uint, error) {
+		if challenge.Type == types.PredictionChallenge {
+			return 0, nil
+		}
+		return challenge.orderingPoints(challenge.AwardedPoints(solvedAt, previousSolvers, hintCost), answer)
+	})
+	if err != nil {
+		return Submission{}, err
+	}
+
+	refreshBingoBonuses()
+	return submission, nil
+}
+
+// GetSubmission returns the submission of the user for the challenge, or nil if the user did not submit anything yet
+func GetSubmission(userId uint, challengeId uint) (*Submission, error) {
+	conn := GetConnection()
+	return conn.GetSubmission(userId, challengeId)
+}
+
+// IsChallengeCompleted returns true if the user completed the challenge,
+// or if it is a team challenge and another member of the team completed it
 func IsChallengeCompleted(userId uint, challengeId uint) (bool, error) {
 	conn := GetConnection()
+	teamSubmissions, err := conn.GetTeamSubmissions(userId)
+	if err != nil {
+		return false, err
+	}
+	if IsChallengeInSubmissions(challengeId, teamSubmissions) {
+		return true, nil
+	}
+
 	var submission Submission
-	err := conn.Where("user_id = ? AND challenge_id = ?", userId, challengeId).First(&submission).GetError()
+	err = conn.Where("user_id = ? AND challenge_id = ?", userId, challengeId).First(&submission).GetError()
 	if err != nil {
 		if apperrors.IsRecordNotFoundError(err) {
 			return false, nil
 		}
 		return false, err
 	}
-	return true, nil
+	return submission.IsApproved(), nil
 }
 
-func GetCompletedChallenges(userId uint) ([]Submission, error) {
+// GetSubmissionsForUser returns all submissions of the user, including the ones waiting for or failing moderation
+func GetSubmissionsForUser(userId uint) ([]Submission, error) {
 	conn := GetConnection()
 	var submissions []Submission
 	if err := conn.Where("user_id = ?", userId).Find(&submissions).GetError(); err != nil {
@@ -54,13 +122,31 @@ func GetCompletedChallenges(userId uint) ([]Submission, error) {
 	return submissions, nil
 }
 
-func GetLeaderboard() ([]types.LeaderboardEntry, error) {
+// GetCompletedChallenges returns the approved submissions of the user and the submissions of team challenges by the rest of the team
+func GetCompletedChallenges(userId uint) ([]Submission, error) {
+	submissions, err := GetSubmissionsForUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var completed []Submission
+	for _, submission := range submissions {
+		if submission.IsApproved() {
+			completed = append(completed, submission)
+		}
+	}
+
 	conn := GetConnection()
-	leaderboard, err := conn.GetLeaderboard()
+	teamSubmissions, err := conn.GetTeamSubmissions(userId)
 	if err != nil {
 		return nil, err
 	}
-	return leaderboard, nil
+	return append(completed, teamSubmissions...), nil
+}
+
+func GetLeaderboard() ([]types.LeaderboardEntry, error) {
+	conn := GetConnection()
+	return conn.GetLeaderboard()
 }
 
 func GetSubmissionsForChallenge(challengeId uint) ([]types.SubmissionForChallenge, error) {
@@ -13,11 +13,13 @@ var (
 		UserID:      132,
 		ChallengeID: 3245,
 		Answer:      "test_answer",
+		State:       types.ApprovedSubmission,
 	}
 	testSubmission2 = Submission{
 		UserID:      235,
 		ChallengeID: 9768,
 		Answer:      "test_answer2",
+		State:       types.ApprovedSubmission,
 	}
 )
 
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+)
+
+// Team groups guests, usually by table, team challenges completed by one member count for the whole team
+type Team struct {
+	gorm.Model
+	Name     string `gorm:"unique;not null"`
+	JoinCode string `gorm:"unique;not null"`
+}
+
+func NewTeam(name string, joinCode string) Team {
+	return Team{
+		Name:     name,
+		JoinCode: utils.NormalizeCode(joinCode),
+	}
+}
+
+func generateJoinCode() string {
+	return strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:6])
+}
+
+func CreateNewTeam(createTeamRequest types.CreateTeamRequest) (Team, error) {
+	joinCode := createTeamRequest.JoinCode
+	if joinCode == "" {
+		joinCode = generateJoinCode()
+	}
+
+	team := NewTeam(createTeamRequest.Name, joinCode)
+	if err := team.checkUnique(); err != nil {
+		return Team{}, err
+	}
+
+	return team.Save()
+}
+
+func (team Team) Save() (Team, error) {
+	conn := GetConnection()
+	if err := conn.Create(&team).GetError(); err != nil {
+		return Team{}, err
+	}
+	return team, nil
+}
+
+func (team Team) Update(updateTeamRequest types.UpdateTeamRequest) (Team, error) {
+	team.Name = updateTeamRequest.Name
+	if updateTeamRequest.JoinCode != "" {
+		team.JoinCode = utils.NormalizeCode(updateTeamRequest.JoinCode)
+	}
+
+	if err := team.checkUnique(); err != nil {
+		return Team{}, err
+	}
+
+	conn := GetConnection()
+	return conn.UpdateTeam(team)
+}
+
+// Delete removes the team, its members stay in the game without a team
+func (team Team) Delete() error {
+	conn := GetConnection()
+	if err := conn.DeleteTeam(team.ID); err != nil {
+		return err
+	}
+
+	// Team challenges completed by former teammates no longer count on the bingo cards of the members
+	refreshBingoBonuses()
+	return nil
+}
+
+func (team Team) GetMembers() ([]User, error) {
+	conn := GetConnection()
+	return conn.GetTeamMembers(team.ID)
+}
+
+// checkUnique returns a validation error if another team already uses the name or join code
+func (team Team) checkUnique() error {
+	conn := GetConnection()
+
+	var existing Team
+	err := conn.Where("name = ? AND id <> ?", team.Name, team.ID).First(&existing).GetError()
+	if err == nil {
+		return apperrors.NewValidationError(constants.TeamNameTakenError)
+	}
+	if !apperrors.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	err = conn.Where("join_code = ? AND id <> ?", team.JoinCode, team.ID).First(&existing).GetError()
+	if err == nil {
+		return apperrors.NewValidationError(constants.JoinCodeTakenError)
+	}
+	if !apperrors.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func GetAllTeams() ([]Team, error) {
+	conn := GetConnection()
+	return conn.GetAllTeams()
+}
+
+func GetTeamByID(id uint) (Team, error) {
+	conn := GetConnection()
+	var team Team
+	if err := conn.First(&team, id).GetError(); err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return Team{}, apperrors.NewNotFoundError("Team", strconv.Itoa(int(id)))
+		}
+		return Team{}, err
+	}
+	return team, nil
+}
+
+func GetTeamByJoinCode(joinCode string) (Team, error) {
+	conn := GetConnection()
+	var team Team
+	if err := conn.Where("join_code = ?", utils.NormalizeCode(joinCode)).First(&team).GetError(); err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return Team{}, apperrors.NewValidationError(constants.InvalidJoinCodeError)
+		}
+		return Team{}, err
+	}
+	return team, nil
+}
+
+func GetTeamLeaderboard() ([]types.TeamLeaderboardEntry, error) {
+	conn := GetConnection()
+	return conn.GetTeamLeaderboard()
+}
+
+// GetTeam returns the team of the user, or nil if the user did not join a team
+func (user User) GetTeam() (*Team, error) {
+	if user.TeamID == nil {
+		return nil, nil
+	}
+
+	team, err := GetTeamByID(*user.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// SetTeam moves the user to the team, a nil team removes the user from their team
+func (user User) SetTeam(teamId *uint) (User, error) {
+	conn := GetConnection()
+	if err := conn.SetUserTeam(user.ID, teamId); err != nil {
+		return User{}, err
+	}
+	refreshBingoBonuses()
+
+	user.TeamID = teamId
+	return user, nil
+}
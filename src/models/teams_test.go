@@ -0,0 +1,160 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+)
+
+var (
+	testTeamId = uint(7)
+	testTeam   = Team{Model: gorm.Model{ID: testTeamId}, Name: "Table 7", JoinCode: "TABLE7"}
+)
+
+func createTeamMember(id uint, teamId *uint) *User {
+	database := GetConnection()
+	user := &User{Model: gorm.Model{ID: id}, Username: "user", Role: types.Player, TeamID: teamId}
+	database.Create(user)
+	return user
+}
+
+func TestNewTeamNormalizesJoinCode(t *testing.T) {
+	team := NewTeam("Table 7", " table7 ")
+	if team.Name != "Table 7" || team.JoinCode != "TABLE7" {
+		t.Errorf("expected Table 7 with join code TABLE7 but got %v", team)
+	}
+}
+
+func TestGenerateJoinCode(t *testing.T) {
+	joinCode := generateJoinCode()
+	if len(joinCode) != 6 || joinCode != utils.NormalizeCode(joinCode) {
+		t.Errorf("expected a 6 character upper case join code but got %s", joinCode)
+	}
+}
+
+func TestGetTeamByJoinCode(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+	database.Create(&testTeam)
+
+	team, err := GetTeamByJoinCode("table7")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if team.ID != testTeam.ID {
+		t.Errorf("expected team %d but got %d", testTeam.ID, team.ID)
+	}
+}
+
+func TestGetTeamByJoinCodeNotFound(t *testing.T) {
+	SetupMockDb()
+
+	_, err := GetTeamByJoinCode("unknown")
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "invalid join code" {
+		t.Errorf("expected validation error 'invalid join code' but got %s", err.Error())
+	}
+}
+
+func TestGetTeamWithoutTeam(t *testing.T) {
+	team, err := NewUser("user").GetTeam()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if team != nil {
+		t.Errorf("expected no team but got %v", team)
+	}
+}
+
+func TestSetTeam(t *testing.T) {
+	SetupMockDb()
+	user := createTeamMember(1, nil)
+
+	updated, err := user.SetTeam(&testTeamId)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if updated.TeamID == nil || *updated.TeamID != testTeamId || user.TeamID == nil {
+		t.Errorf("expected user in team %d but got %v", testTeamId, updated.TeamID)
+	}
+
+	members, err := testTeam.GetMembers()
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(members) != 1 || members[0].ID != 1 {
+		t.Errorf("expected user 1 as the only member but got %v", members)
+	}
+}
+
+func TestDeleteTeamRemovesMembers(t *testing.T) {
+	SetupMockDb()
+	user := createTeamMember(1, &testTeamId)
+
+	if err := testTeam.Delete(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if user.TeamID != nil {
+		t.Errorf("expected user without team but got team %d", *user.TeamID)
+	}
+}
+
+func TestIsChallengeCompletedByTeam(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	teamChallenge := testChallenge123
+	teamChallenge.TeamChallenge = true
+	database.Create(&teamChallenge)
+	createTeamMember(1, &testTeamId)
+	createTeamMember(2, &testTeamId)
+	createTeamMember(3, nil)
+	database.Create(&Submission{UserID: 2, ChallengeID: teamChallenge.ID, State: types.ApprovedSubmission})
+
+	completed, err := IsChallengeCompleted(1, teamChallenge.ID)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if !completed {
+		t.Errorf("expected the challenge to be completed by the team")
+	}
+
+	teamSubmissions, err := database.GetTeamSubmissions(3)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(teamSubmissions) != 0 {
+		t.Errorf("expected no team submissions for a user without team but got %v", teamSubmissions)
+	}
+}
+
+func TestIsChallengeCompletedNotCountedForIndividualChallenge(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	database.Create(&testChallenge123)
+	createTeamMember(1, &testTeamId)
+	createTeamMember(2, &testTeamId)
+	database.Create(&Submission{UserID: 2, ChallengeID: testChallenge123.ID, State: types.ApprovedSubmission})
+
+	teamSubmissions, err := database.GetTeamSubmissions(1)
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if len(teamSubmissions) != 0 {
+		t.Errorf("expected no team submissions for an individual challenge but got %v", teamSubmissions)
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"gorm.io/gorm"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+	"time"
+)
+
+// Upload records a file a user stored through the upload endpoints, photo, video and audio answers must reference one
+type Upload struct {
+	gorm.Model
+	UserID          uint            `gorm:"not null;index"`
+	StorageKey      string          `gorm:"unique;not null"`
+	Url             string          `gorm:"unique;not null"`
+	Size            int64           `gorm:"not null"`
+	ContentHash     string          `gorm:"not null;index"`
+	MediaType       types.MediaType `gorm:"not null;default:'IMAGE'"`
+	DurationSeconds float64         `gorm:"not null;default:0"`
+	User            User
+}
+
+func NewUpload(userId uint, uploadedFile utils.UploadedFile) Upload {
+	return Upload{
+		UserID:      userId,
+		StorageKey:  uploadedFile.Key,
+		Url:         uploadedFile.Url,
+		Size:        uploadedFile.Size,
+		ContentHash: uploadedFile.ContentHash,
+		MediaType:   types.ImageMedia,
+	}
+}
+
+func NewVideoUpload(userId uint, uploadedFile utils.UploadedFile, duration time.Duration) Upload {
+	upload := NewUpload(userId, uploadedFile)
+	upload.MediaType = types.VideoMedia
+	upload.DurationSeconds = duration.Seconds()
+	return upload
+}
+
+func NewAudioUpload(userId uint, uploadedFile utils.UploadedFile, duration time.Duration) Upload {
+	upload := NewUpload(userId, uploadedFile)
+	upload.MediaType = types.AudioMedia
+	upload.DurationSeconds = duration.Seconds()
+	return upload
+}
+
+func (upload Upload) Save() (Upload, error) {
+	conn := GetConnection()
+	if err := conn.Create(&upload).GetError(); err != nil {
+		return Upload{}, err
+	}
+	return upload, nil
+}
+
+// CheckMediaUpload returns a validation error if the answer of a photo, video or audio challenge is not an upload of the user
+// of the right media type, if a recording is too long, or if the upload was already used for another submission
+func (challenge Challenge) CheckMediaUpload(userId uint, answer string) error {
+	mediaType, ok := challenge.GetMediaType()
+	if !ok {
+		return nil
+	}
+
+	conn := GetConnection()
+	var upload Upload
+	if err := conn.Where("url = ? AND user_id = ?", answer, userId).First(&upload).GetError(); err != nil {
+		if apperrors.IsRecordNotFoundError(err) {
+			return apperrors.NewValidationError(constants.UploadNotFoundError)
+		}
+		return err
+	}
+
+	if upload.MediaType != mediaType {
+		return apperrors.NewValidationError(constants.WrongMediaTypeError)
+	}
+	if upload.DurationSeconds < 0 {
+		if mediaType == types.AudioMedia {
+			return apperrors.NewValidationError(constants.InvalidAudioError)
+		}
+		return apperrors.NewValidationError(constants.InvalidVideoError)
+	}
+	if challenge.MaxDurationSeconds > 0 && upload.DurationSeconds > float64(challenge.MaxDurationSeconds) {
+		if mediaType == types.AudioMedia {
+			return apperrors.NewValidationError(constants.AudioTooLongError)
+		}
+		return apperrors.NewValidationError(constants.VideoTooLongError)
+	}
+
+	used, err := conn.IsAnswerUsedElsewhere(answer, userId, challenge.ID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return apperrors.NewValidationError(constants.UploadAlreadyUsedError)
+	}
+	return nil
+}
+
+// GetMediaType returns the type of upload a photo, video or audio challenge expects as answer
+func (challenge Challenge) GetMediaType() (types.MediaType, bool) {
+	switch challenge.Type {
+	case types.UploadPhotoChallenge:
+		return types.ImageMedia, true
+	case types.UploadVideoChallenge:
+		return types.VideoMedia, true
+	case types.UploadAudioChallenge:
+		return types.AudioMedia, true
+	default:
+		return "", false
+	}
+}
+
+// IsModerated returns true for photo and video challenges, their uploads are shown in the public gallery once approved.
+// Audio messages are private to the couple and count right away.
+func (challenge Challenge) IsModerated() bool {
+	return challenge.Type == types.UploadPhotoChallenge || challenge.Type == types.UploadVideoChallenge
+}
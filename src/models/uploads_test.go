@@ -0,0 +1,222 @@
+package models
+
+import (
+	"testing"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/types"
+	"the-wedding-game-api/utils"
+	"time"
+)
+
+const testUploadUrl = "https://example.com/photo.jpg"
+const testVideoUrl = "https://example.com/clip.mp4"
+const testAudioUrl = "https://example.com/message.m4a"
+
+var testVideoChallenge = Challenge{
+	ID:                 78,
+	Name:               "Toast",
+	Points:             30,
+	Type:               types.UploadVideoChallenge,
+	MaxDurationSeconds: 30,
+}
+
+var testAudioChallenge = Challenge{
+	ID:                 79,
+	Name:               "Voice message",
+	Points:             20,
+	Type:               types.UploadAudioChallenge,
+	MaxDurationSeconds: 60,
+}
+
+func TestNewUpload(t *testing.T) {
+	upload := NewUpload(1, utils.UploadedFile{Key: "photo.jpg", Url: testUploadUrl, Size: 10, ContentHash: "hash"})
+	if upload.UserID != 1 || upload.StorageKey != "photo.jpg" || upload.Url != testUploadUrl ||
+		upload.Size != 10 || upload.ContentHash != "hash" {
+		t.Errorf("unexpected upload %v", upload)
+	}
+}
+
+func TestCheckMediaUpload(t *testing.T) {
+	SetupMockDb()
+
+	if _, err := NewUpload(1, utils.UploadedFile{Url: testUploadUrl}).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if err := testPhotoChallenge.CheckMediaUpload(1, testUploadUrl); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadNotFound(t *testing.T) {
+	SetupMockDb()
+
+	err := testPhotoChallenge.CheckMediaUpload(1, testUploadUrl)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "answer must be a file you uploaded" {
+		t.Errorf("expected validation error but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadUsedForAnotherChallenge(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	if _, err := NewUpload(1, utils.UploadedFile{Url: testUploadUrl}).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	database.Create(&Submission{UserID: 1, ChallengeID: 99, Answer: testUploadUrl, State: types.PendingSubmission})
+
+	err := testPhotoChallenge.CheckMediaUpload(1, testUploadUrl)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if err.Error() != "file was already used for another submission" {
+		t.Errorf("expected upload already used error but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadReusedForSameChallenge(t *testing.T) {
+	SetupMockDb()
+	database := GetConnection()
+
+	if _, err := NewUpload(1, utils.UploadedFile{Url: testUploadUrl}).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	database.Create(&Submission{UserID: 1, ChallengeID: testPhotoChallenge.ID, Answer: testUploadUrl, State: types.RejectedSubmission})
+
+	if err := testPhotoChallenge.CheckMediaUpload(1, testUploadUrl); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadOtherChallengeType(t *testing.T) {
+	SetupMockDb()
+
+	if err := testChallenge123.CheckMediaUpload(1, "any answer"); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestNewVideoUpload(t *testing.T) {
+	upload := NewVideoUpload(1, utils.UploadedFile{Key: "clip.mp4", Url: testVideoUrl}, 12*time.Second)
+	if upload.MediaType != types.VideoMedia || upload.DurationSeconds != 12 || upload.Url != testVideoUrl {
+		t.Errorf("unexpected upload %v", upload)
+	}
+}
+
+func TestCheckMediaUploadVideo(t *testing.T) {
+	SetupMockDb()
+
+	if _, err := NewVideoUpload(1, utils.UploadedFile{Url: testVideoUrl}, 30*time.Second).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	if err := testVideoChallenge.CheckMediaUpload(1, testVideoUrl); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadVideoTooLong(t *testing.T) {
+	SetupMockDb()
+
+	if _, err := NewVideoUpload(1, utils.UploadedFile{Url: testVideoUrl}, 31*time.Second).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	err := testVideoChallenge.CheckMediaUpload(1, testVideoUrl)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "video is longer than the maximum duration of the challenge" {
+		t.Errorf("expected video too long error but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadNegativeDuration(t *testing.T) {
+	SetupMockDb()
+
+	if _, err := NewVideoUpload(1, utils.UploadedFile{Url: testVideoUrl}, -time.Second).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	err := testVideoChallenge.CheckMediaUpload(1, testVideoUrl)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "could not read the duration of the video" {
+		t.Errorf("expected invalid video error but got %s", err.Error())
+	}
+}
+
+func TestCheckMediaUploadWrongMediaType(t *testing.T) {
+	SetupMockDb()
+
+	if _, err := NewUpload(1, utils.UploadedFile{Url: testUploadUrl}).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	err := testVideoChallenge.CheckMediaUpload(1, testUploadUrl)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "upload does not match the type of the challenge" {
+		t.Errorf("expected wrong media type error but got %s", err.Error())
+	}
+}
+
+func TestGetMediaType(t *testing.T) {
+	if mediaType, ok := testPhotoChallenge.GetMediaType(); !ok || mediaType != types.ImageMedia {
+		t.Errorf("expected image media but got %v", mediaType)
+	}
+	if mediaType, ok := testVideoChallenge.GetMediaType(); !ok || mediaType != types.VideoMedia {
+		t.Errorf("expected video media but got %v", mediaType)
+	}
+	if mediaType, ok := testAudioChallenge.GetMediaType(); !ok || mediaType != types.AudioMedia {
+		t.Errorf("expected audio media but got %v", mediaType)
+	}
+	if _, ok := testChallenge123.GetMediaType(); ok {
+		t.Errorf("expected no media type for an answer question challenge")
+	}
+}
+
+func TestCheckMediaUploadAudioTooLong(t *testing.T) {
+	SetupMockDb()
+
+	if _, err := NewAudioUpload(1, utils.UploadedFile{Url: testAudioUrl}, 61*time.Second).Save(); err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+
+	err := testAudioChallenge.CheckMediaUpload(1, testAudioUrl)
+	if err == nil {
+		t.Errorf("expected error but got nil")
+		return
+	}
+	if !apperrors.IsValidationError(err) || err.Error() != "audio message is longer than the maximum duration of the challenge" {
+		t.Errorf("expected audio too long error but got %s", err.Error())
+	}
+}
+
+func TestIsModerated(t *testing.T) {
+	if !testPhotoChallenge.IsModerated() || !testVideoChallenge.IsModerated() {
+		t.Errorf("expected photo and video challenges to be moderated")
+	}
+	if testAudioChallenge.IsModerated() || testChallenge123.IsModerated() {
+		t.Errorf("expected audio and question challenges not to be moderated")
+	}
+}
@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetAnswers(c *gin.Context) {
+	challengeId, err := validators.ValidateGetAnswersRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	answers, err := models.GetAnswers(challenge.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetAnswersResponse{
+		Answers: make([]types.AnswerResponse, 0, len(answers)),
+	}
+	for _, answer := range answers {
+		response.Answers = append(response.Answers, types.AnswerResponse{
+			Id:    answer.ID,
+			Value: answer.Value,
+		})
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func CreateAnswer(c *gin.Context) {
+	challengeId, request, err := validators.ValidateCreateAnswerRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	answer, err := challenge.AddAnswer(request.Value)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, types.AnswerResponse{
+		Id:    answer.ID,
+		Value: answer.Value,
+	})
+	return
+}
+
+func UpdateAnswer(c *gin.Context) {
+	challengeId, answerId, request, err := validators.ValidateUpdateAnswerRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	answer, err := challenge.UpdateAnswer(answerId, request.Value)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.AnswerResponse{
+		Id:    answer.ID,
+		Value: answer.Value,
+	})
+	return
+}
+
+func DeleteAnswer(c *gin.Context) {
+	challengeId, answerId, err := validators.ValidateDeleteAnswerRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := challenge.DeleteAnswer(answerId); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.DeleteAnswerResponse{
+		Id: answerId,
+	})
+	return
+}
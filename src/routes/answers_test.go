@@ -0,0 +1,341 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createChallengeWithAnswers(values ...string) (models.Challenge, []models.Answer, error) {
+	challenge, err := createAnswerQuestionChallenge()
+	if err != nil {
+		return models.Challenge{}, nil, err
+	}
+
+	var answers []models.Answer
+	for _, value := range values {
+		answer, err := models.NewAnswer(challenge.ID, value).Save()
+		if err != nil {
+			return models.Challenge{}, nil, err
+		}
+		answers = append(answers, answer)
+	}
+
+	return challenge, answers, nil
+}
+
+func answersPath(challengeId uint) string {
+	return "/challenges/" + strconv.Itoa(int(challengeId)) + "/answers"
+}
+
+func TestGetAnswers(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, answers, err := createChallengeWithAnswers("Mount Everest", "Chomolungma")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, responseBody := makeRequestWithToken("GET", answersPath(challenge.ID), nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := types.GetAnswersResponse{
+		Answers: []types.AnswerResponse{
+			{Id: answers[0].ID, Value: "Mount Everest"},
+			{Id: answers[1].ID, Value: "Chomolungma"},
+		},
+	}
+	var response types.GetAnswersResponse
+	err = json.Unmarshal([]byte(responseBody), &response)
+	if err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("Expected response %v, got %v", expectedResponse, response)
+	}
+}
+
+func TestGetAnswersAsPlayer(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, _, err := createChallengeWithAnswers("Mount Everest")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("GET", answersPath(challenge.ID), nil, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestCreateAnswer(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, _, err := createChallengeWithAnswers("Mount Everest")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, responseBody := makeRequestWithToken("POST", answersPath(challenge.ID),
+		types.CreateAnswerRequest{Value: "Chomolungma"}, accessToken.Token)
+	if statusCode != http.StatusCreated {
+		t.Errorf("Expected status code 201, got %v", statusCode)
+		return
+	}
+
+	var response types.AnswerResponse
+	err = json.Unmarshal([]byte(responseBody), &response)
+	if err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Id == 0 || response.Value != "Chomolungma" {
+		t.Errorf("Expected created answer Chomolungma, got %v", response)
+		return
+	}
+
+	verifyRequest := types.VerifyAnswerRequest{Answer: "chomolungma"}
+	_, userAccessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, responseBody = makeRequestWithToken("POST", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/verify",
+		verifyRequest, userAccessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var verifyResponse types.VerifyAnswerResponse
+	err = json.Unmarshal([]byte(responseBody), &verifyResponse)
+	if err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if !verifyResponse.Correct {
+		t.Errorf("Expected the added answer to be accepted")
+	}
+}
+
+func TestCreateAnswerForUploadChallenge(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, responseBody := makeRequestWithToken("POST", answersPath(challenge.ID),
+		types.CreateAnswerRequest{Value: "Chomolungma"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"answers can only be managed for answer question challenges\",\"status\":\"error\"}"
+	if responseBody != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, responseBody)
+	}
+}
+
+func TestUpdateAnswerById(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, answers, err := createChallengeWithAnswers("Mount Everest", "Chomolungma")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := answersPath(challenge.ID) + "/" + strconv.Itoa(int(answers[1].ID))
+	statusCode, responseBody := makeRequestWithToken("PUT", path, types.UpdateAnswerRequest{Value: "Sagarmatha"}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.AnswerResponse
+	err = json.Unmarshal([]byte(responseBody), &response)
+	if err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedResponse := types.AnswerResponse{Id: answers[1].ID, Value: "Sagarmatha"}
+	if response != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, response)
+	}
+}
+
+func TestUpdateAnswerByIdWithSubmissions(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	user, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, answers, err := createChallengeWithAnswers("Mount Everest", "Chomolungma")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	err = createSubmission(challenge.ID, user.ID, "Chomolungma")
+	if err != nil {
+		t.Errorf("Error creating submission: %v", err)
+		return
+	}
+
+	path := answersPath(challenge.ID) + "/" + strconv.Itoa(int(answers[1].ID))
+	statusCode, responseBody := makeRequestWithToken("PUT", path, types.UpdateAnswerRequest{Value: "Sagarmatha"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"Cannot update answer if submissions exist\",\"status\":\"error\"}"
+	if responseBody != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, responseBody)
+	}
+}
+
+func TestUpdateAnswerByIdNotFound(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, answers, err := createChallengeWithAnswers("Mount Everest")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := answersPath(challenge.ID) + "/" + strconv.Itoa(int(answers[0].ID+1000))
+	statusCode, _ := makeRequestWithToken("PUT", path, types.UpdateAnswerRequest{Value: "Sagarmatha"}, accessToken.Token)
+	if statusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404, got %v", statusCode)
+	}
+}
+
+func TestDeleteAnswerById(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, answers, err := createChallengeWithAnswers("Mount Everest", "Chomolungma")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := answersPath(challenge.ID) + "/" + strconv.Itoa(int(answers[1].ID))
+	statusCode, _ := makeRequestWithToken("DELETE", path, nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	exists, err := checkIfAnswerExists(answers[1].ID)
+	if err != nil {
+		t.Errorf("Error checking if answer exists: %v", err)
+		return
+	}
+	if exists {
+		t.Errorf("Expected answer to be deleted")
+	}
+}
+
+func TestDeleteLastAnswer(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, answers, err := createChallengeWithAnswers("Mount Everest")
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := answersPath(challenge.ID) + "/" + strconv.Itoa(int(answers[0].ID))
+	statusCode, responseBody := makeRequestWithToken("DELETE", path, nil, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"cannot delete the last answer of a challenge\",\"status\":\"error\"}"
+	if responseBody != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, responseBody)
+	}
+}
@@ -0,0 +1,231 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createChallengeWithMaxAttempts(maxAttempts uint) (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "limited_challenge",
+		Description: "limited_description",
+		Points:      100,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.AnswerQuestionChallenge,
+		Answer:      "eiffel tower",
+		MaxAttempts: maxAttempts,
+	})
+}
+
+func TestVerifyAnswerReturnsRemainingAttempts(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithMaxAttempts(2)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/verify"
+	statusCode, body := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "big ben"}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.VerifyAnswerResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Correct || response.RemainingAttempts == nil || *response.RemainingAttempts != 1 {
+		t.Errorf("Expected an incorrect answer with 1 remaining attempt, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "eiffel tower"}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"correct\": true\n}" {
+		t.Errorf("Expected a correct answer, got %v", body)
+	}
+}
+
+func TestVerifyAnswerNoAttemptsLeft(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithMaxAttempts(1)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/verify"
+	statusCode, body := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "big ben"}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"correct\": false,\n    \"remaining_attempts\": 0\n}" {
+		t.Errorf("Expected an incorrect answer with 0 remaining attempts, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "eiffel tower"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"no attempts left for this challenge\",\"status\":\"error\"}" {
+		t.Errorf("Expected no attempts left error, got %v", body)
+	}
+}
+
+func TestVerifyAnswerConcurrentlyKeepsAttemptLimit(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithMaxAttempts(2)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	const answers = 10
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/verify"
+	var wg sync.WaitGroup
+	for i := 0; i < answers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "big ben"}, accessToken.Token)
+		}()
+	}
+	wg.Wait()
+
+	remaining, err := challenge.GetRemainingAttempts(user.ID)
+	if err != nil {
+		t.Errorf("Error getting remaining attempts: %v", err)
+		return
+	}
+
+	attempts, err := models.GetAttemptsForChallenge(challenge.ID)
+	if err != nil {
+		t.Errorf("Error getting attempts: %v", err)
+		return
+	}
+	if len(attempts) != 2 || remaining == nil || *remaining != 0 {
+		t.Errorf("Expected exactly 2 attempts to be saved, got %v", attempts)
+	}
+}
+
+func TestGetAttempts(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	_, adminAccessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithMaxAttempts(0)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID))
+	for _, answer := range []string{"big ben", "eiffel tower"} {
+		statusCode, _ := makeRequestWithToken("POST", path+"/verify", types.VerifyAnswerRequest{Answer: answer}, accessToken.Token)
+		if statusCode != http.StatusOK {
+			t.Errorf("Expected status code 200, got %v", statusCode)
+			return
+		}
+	}
+
+	statusCode, body := makeRequestWithToken("GET", path+"/attempts", nil, adminAccessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetAttemptsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Attempts) != 2 {
+		t.Errorf("Expected 2 attempts, got %v", len(response.Attempts))
+		return
+	}
+
+	first, second := response.Attempts[0], response.Attempts[1]
+	if first.Value != "big ben" || first.Correct || first.UserId != user.ID || first.Username != user.Username {
+		t.Errorf("Expected the wrong attempt first, got %v", first)
+	}
+	if second.Value != "eiffel tower" || !second.Correct {
+		t.Errorf("Expected the correct attempt second, got %v", second)
+	}
+}
+
+func TestGetAttemptsAsPlayer(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithMaxAttempts(0)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/attempts"
+	statusCode, _ := makeRequestWithToken("GET", path, nil, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
@@ -2,8 +2,12 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"log"
 	"net/http"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/limiter"
 	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
 	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
 )
@@ -15,45 +19,166 @@ func Login(c *gin.Context) {
 		return
 	}
 
+	usernameKey, ipKey := limiter.UsernameKey(loginRequest.Username), limiter.IPKey(c.ClientIP())
+	loginKeys := []string{usernameKey, ipKey}
+	if err := limiter.ReserveLogin(loginKeys...); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	exists, user, err := models.DoesUserExist(loginRequest.Username)
 	if err != nil {
-		_ = c.Error(err)
+		failLogin(c, err, loginKeys)
 		return
 	}
 
-	if !exists {
+	var guest *models.Guest
+	if !exists || !user.IsStaff() {
+		var existingUser *models.User
+		if exists {
+			existingUser = &user
+		}
+		guest, err = models.GetGuestForLogin(existingUser, loginRequest.InviteCode)
+		if err != nil {
+			failLogin(c, err, loginKeys)
+			return
+		}
+	}
+
+	if !exists && guest != nil {
+		var claimed models.Guest
+		user, claimed, err = guest.Register(models.NewUser(loginRequest.Username))
+		if err != nil {
+			failLogin(c, err, loginKeys)
+			return
+		}
+		guest = &claimed
+	} else if !exists {
 		user = models.NewUser(loginRequest.Username)
 		user, err = user.Save()
 		if err != nil {
-			_ = c.Error(err)
+			failLogin(c, err, loginKeys)
 			return
 		}
 	}
 
-	if user.Role == types.Admin {
-		err := models.ValidatePassword(loginRequest.Password)
+	if user.IsStaff() {
+		err := user.ValidatePassword(loginRequest.Password)
 		if err != nil {
-			_ = c.Error(err)
+			failLogin(c, err, loginKeys)
+			return
+		}
+	}
+
+	if guest != nil {
+		if _, err := guest.Claim(user.ID); err != nil {
+			failLogin(c, err, loginKeys)
 			return
 		}
 	}
 
+	if err := limiter.ClearLockout(usernameKey); err != nil {
+		log.Println("Error clearing failed logins: ", err)
+	}
+	if err := limiter.ReleaseLogin(ipKey); err != nil {
+		log.Println("Error releasing login attempt: ", err)
+	}
+
 	accessToken, err := models.LinkAccessTokenToUser(user.ID)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
+	team, err := user.GetTeam()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	c.IndentedJSON(http.StatusOK, types.LoginResponse{
 		User: types.UserResponse{
 			Username: user.Username,
 			Role:     user.Role,
+			Team:     getTeamResponse(team),
 		},
-		AccessToken: accessToken.Token,
+		AccessToken:  accessToken.Token,
+		RefreshToken: *accessToken.RefreshToken,
+		ExpiresOn:    accessToken.ExpiresOn,
+	})
+	return
+}
+
+// failLogin reports the error of a login, wrong passwords and invite codes keep counting towards a lockout,
+// the attempt reserved for other errors is released
+func failLogin(c *gin.Context, err error, loginKeys []string) {
+	if !apperrors.IsAuthenticationError(err) {
+		if limitErr := limiter.ReleaseLogin(loginKeys...); limitErr != nil {
+			log.Println("Error releasing login attempt: ", limitErr)
+		}
+	}
+	_ = c.Error(err)
+}
+
+func RefreshToken(c *gin.Context) {
+	var refreshTokenRequest types.RefreshTokenRequest
+	if err := c.BindJSON(&refreshTokenRequest); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	accessToken, err := models.RefreshAccessToken(refreshTokenRequest.RefreshToken)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.RefreshTokenResponse{
+		AccessToken:  accessToken.Token,
+		RefreshToken: *accessToken.RefreshToken,
+		ExpiresOn:    accessToken.ExpiresOn,
 	})
 	return
 }
 
+func Logout(c *gin.Context) {
+	accessToken, err := middleware.GetAccessToken(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := models.RevokeAccessToken(accessToken); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+	return
+}
+
+func ChangePassword(c *gin.Context) {
+	changePasswordRequest, err := validators.ValidateChangePasswordRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if _, err := user.ChangePassword(changePasswordRequest.CurrentPassword, changePasswordRequest.NewPassword); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+	return
+}
+
 func GetCurrentUser(c *gin.Context) {
 	user, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -61,9 +186,16 @@ func GetCurrentUser(c *gin.Context) {
 		return
 	}
 
+	team, err := user.GetTeam()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	c.IndentedJSON(http.StatusOK, types.UserResponse{
 		Username: user.Username,
 		Role:     user.Role,
+		Team:     getTeamResponse(team),
 	})
 	return
 }
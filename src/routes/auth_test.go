@@ -9,6 +9,7 @@ import (
 	"testing"
 	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 func TestLogin(t *testing.T) {
@@ -364,3 +365,158 @@ func TestGetCurrentUserWithInvalidAccessToken(t *testing.T) {
 		t.Errorf("Expected body %v, got %v", expectedBody, resp.Body.String())
 	}
 }
+
+func TestRefreshToken(t *testing.T) {
+	statusCode, body := makeRequest("POST", "/auth/login", types.LoginRequest{Username: "test_user_for_refresh"})
+	var loginResponse types.LoginResponse
+	if err := json.Unmarshal([]byte(body), &loginResponse); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error logging in: %v %v", statusCode, body)
+		return
+	}
+	if loginResponse.RefreshToken == "" || loginResponse.ExpiresOn == 0 {
+		t.Errorf("Expected a refresh token and an expiry, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/refresh", types.RefreshTokenRequest{RefreshToken: loginResponse.RefreshToken})
+	var refreshResponse types.RefreshTokenResponse
+	if err := json.Unmarshal([]byte(body), &refreshResponse); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error refreshing token: %v %v", statusCode, body)
+		return
+	}
+	if refreshResponse.AccessToken == loginResponse.AccessToken || refreshResponse.RefreshToken == loginResponse.RefreshToken {
+		t.Errorf("Expected new tokens, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/auth/current-user", nil, loginResponse.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected the old access token to be revoked, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/auth/current-user", nil, refreshResponse.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected the new access token to work, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/refresh", types.RefreshTokenRequest{RefreshToken: loginResponse.RefreshToken})
+	expectedBody := `{"message":"invalid refresh token","status":"error"}`
+	if statusCode != http.StatusUnauthorized || body != expectedBody {
+		t.Errorf("Expected the old refresh token to be rejected, got %v %v", statusCode, body)
+	}
+}
+
+func TestGetCurrentUserWithExpiredAccessToken(t *testing.T) {
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	database, err := getDatabaseConnection()
+	if err != nil {
+		t.Errorf("Error connecting to database: %v", err)
+		return
+	}
+	database.Exec(`UPDATE access_tokens SET expires_on = ? WHERE user_id = ?`, time.Now().Add(-time.Minute).Unix(), user.ID)
+	closeDatabaseConnection(database)
+
+	statusCode, body := makeRequestWithToken("GET", "/auth/current-user", nil, accessToken.Token)
+	expectedBody := `{"code":"ACCESS_TOKEN_EXPIRED","message":"access token expired","status":"error"}`
+	if statusCode != http.StatusUnauthorized || body != expectedBody {
+		t.Errorf("Expected status code 401 with an expired token error, got %v %v", statusCode, body)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("POST", "/auth/logout", nil, accessToken.Token)
+	if statusCode != http.StatusNoContent {
+		t.Errorf("Expected status code 204, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/auth/current-user", nil, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected the access token to be revoked, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/refresh", types.RefreshTokenRequest{RefreshToken: *accessToken.RefreshToken})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected the refresh token to be revoked, got %v %v", statusCode, body)
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	models.ResetConnection()
+	user := models.User{
+		Username: "test_admin_for_change_password",
+		Role:     types.Admin,
+	}
+	user, err := user.Save()
+	if err != nil {
+		t.Errorf("Error creating user")
+		return
+	}
+	if _, err := user.SetPassword("current password"); err != nil {
+		t.Errorf("Error setting password: %v", err)
+		return
+	}
+
+	accessToken, err := models.LinkAccessTokenToUser(user.ID)
+	if err != nil {
+		t.Errorf("Error linking access token to user: %v", err)
+		return
+	}
+
+	request := types.ChangePasswordRequest{CurrentPassword: "wrong password", NewPassword: "new password"}
+	statusCode, body := makeRequestWithToken("POST", "/auth/change-password", request, accessToken.Token)
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", statusCode)
+		return
+	}
+	if body != `{"message":"invalid password","status":"error"}` {
+		t.Errorf("Expected invalid password error, got %v", body)
+		return
+	}
+
+	request.CurrentPassword = "current password"
+	statusCode, _ = makeRequestWithToken("POST", "/auth/change-password", request, accessToken.Token)
+	if statusCode != http.StatusNoContent {
+		t.Errorf("Expected status code 204, got %v", statusCode)
+		return
+	}
+
+	statusCode, _ = makeRequest("POST", "/auth/login", types.LoginRequest{Username: user.Username, Password: "current password"})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", statusCode)
+		return
+	}
+
+	statusCode, _ = makeRequest("POST", "/auth/login", types.LoginRequest{Username: user.Username, Password: "new password"})
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+}
+
+func TestChangePasswordAsPlayer(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	request := types.ChangePasswordRequest{CurrentPassword: "current password", NewPassword: "new password"}
+	statusCode, _ := makeRequestWithToken("POST", "/auth/change-password", request, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
@@ -0,0 +1,110 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetBingoCard(c *gin.Context) {
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	card, err := models.GetBingoCard(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetBingoBoardResponse{
+		Size:           card.Board.Size,
+		Cells:          make([]types.BingoCell, len(card.Cells)),
+		LineBonus:      card.Board.LineBonus,
+		FullCardBonus:  card.Board.FullCardBonus,
+		Shuffled:       card.Board.Shuffle,
+		CompletedLines: card.CompletedLines,
+		FullCard:       card.FullCard,
+		BonusPoints:    card.Bonus,
+	}
+	for i, cell := range card.Cells {
+		challenge := card.Challenges[cell.ChallengeID]
+		response.Cells[i] = types.BingoCell{
+			Row:         uint(i) / card.Board.Size,
+			Column:      uint(i) % card.Board.Size,
+			ChallengeId: cell.ChallengeID,
+			Name:        challenge.Name,
+			Image:       challenge.Image,
+			Completed:   card.Completed[i],
+		}
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func GetBingoBoard(c *gin.Context) {
+	board, cells, err := models.GetBingoBoard()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, getBingoBoardAdminResponse(board, cells))
+	return
+}
+
+func PublishBingoBoard(c *gin.Context) {
+	publishBingoBoardRequest, err := validators.ValidatePublishBingoBoardRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	board, cells, err := models.PublishBingoBoard(publishBingoBoardRequest)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, getBingoBoardAdminResponse(board, cells))
+	return
+}
+
+func DeleteBingoBoard(c *gin.Context) {
+	board, _, err := models.GetBingoBoard()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := models.DeleteBingoBoard(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.DeleteBingoBoardResponse{
+		Id: board.ID,
+	})
+	return
+}
+
+func getBingoBoardAdminResponse(board models.BingoBoard, cells []models.BingoCell) types.BingoBoardAdminResponse {
+	response := types.BingoBoardAdminResponse{
+		Id:            board.ID,
+		Size:          board.Size,
+		Challenges:    make([]uint, len(cells)),
+		LineBonus:     board.LineBonus,
+		FullCardBonus: board.FullCardBonus,
+		Shuffle:       board.Shuffle,
+	}
+	for i, cell := range cells {
+		response.Challenges[i] = cell.ChallengeID
+	}
+	return response
+}
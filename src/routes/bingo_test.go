@@ -0,0 +1,243 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+// publishBingoBoard creates four challenges and publishes them as a 2 × 2 board
+func publishBingoBoard(adminToken string) ([]models.Challenge, int, string) {
+	challenges := make([]models.Challenge, 4)
+	challengeIds := make([]uint, 4)
+	for i := range challenges {
+		challenge, err := createAnswerQuestionChallenge()
+		if err != nil {
+			return nil, 0, err.Error()
+		}
+		challenges[i] = challenge
+		challengeIds[i] = challenge.ID
+	}
+
+	statusCode, body := makeRequestWithToken("PUT", "/admin/bingo", types.PublishBingoBoardRequest{
+		Size:          2,
+		Challenges:    challengeIds,
+		LineBonus:     10,
+		FullCardBonus: 50,
+	}, adminToken)
+	return challenges, statusCode, body
+}
+
+func TestGetBingoCard(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenges, statusCode, body := publishBingoBoard(adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	for _, challenge := range challenges[:2] {
+		if err := completeChallenge(challenge.ID, user.ID); err != nil {
+			t.Errorf("Error completing challenge: %v", err)
+			return
+		}
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/bingo", nil, accessToken.Token)
+	var response types.GetBingoBoardResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting bingo card: %v %v", statusCode, body)
+		return
+	}
+
+	if response.Size != 2 || len(response.Cells) != 4 || response.CompletedLines != 1 || response.BonusPoints != 10 {
+		t.Errorf("Expected one completed row worth 10 points, got %v", body)
+		return
+	}
+	if !response.Cells[0].Completed || !response.Cells[1].Completed || response.Cells[2].Completed || response.Cells[3].Row != 1 {
+		t.Errorf("Expected the first row to be completed, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/points/me", nil, accessToken.Token)
+	var points types.CurrentUserPointsResponse
+	if err := json.Unmarshal([]byte(body), &points); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting points: %v %v", statusCode, body)
+		return
+	}
+	if points.Points != 210 {
+		t.Errorf("Expected 200 points for the challenges and 10 for the row, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/leaderboard", nil, accessToken.Token)
+	var leaderboard types.GetLeaderboardResponse
+	if err := json.Unmarshal([]byte(body), &leaderboard); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting leaderboard: %v %v", statusCode, body)
+		return
+	}
+	if len(leaderboard.Leaderboard) != 1 || leaderboard.Leaderboard[0].Points != 210 {
+		t.Errorf("Expected the bonus on the leaderboard, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("DELETE", "/admin/bingo", nil, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Error deleting bingo board: %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/points/me", nil, accessToken.Token)
+	if err := json.Unmarshal([]byte(body), &points); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting points: %v %v", statusCode, body)
+		return
+	}
+	if points.Points != 200 {
+		t.Errorf("Expected the bonus to be withdrawn with the board, got %v", body)
+	}
+}
+
+func TestGetBingoCardWithInactiveChallenge(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenges, statusCode, body := publishBingoBoard(adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	for _, challenge := range challenges[:2] {
+		if err := completeChallenge(challenge.ID, user.ID); err != nil {
+			t.Errorf("Error completing challenge: %v", err)
+			return
+		}
+	}
+
+	_, err := challenges[1].Update(types.UpdateChallengeRequest{
+		Name:        challenges[1].Name,
+		Description: challenges[1].Description,
+		Points:      challenges[1].Points,
+		Image:       challenges[1].Image,
+		Status:      types.InactiveChallenge,
+		Type:        challenges[1].Type,
+	})
+	if err != nil {
+		t.Errorf("Error deactivating challenge: %v", err)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/bingo", nil, accessToken.Token)
+	var response types.GetBingoBoardResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting bingo card: %v %v", statusCode, body)
+		return
+	}
+	if response.CompletedLines != 0 || response.BonusPoints != 0 || response.Cells[1].Completed {
+		t.Errorf("Expected the inactive challenge not to complete the row, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/points/me", nil, accessToken.Token)
+	var points types.CurrentUserPointsResponse
+	if err := json.Unmarshal([]byte(body), &points); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting points: %v %v", statusCode, body)
+		return
+	}
+	if points.Points != 100 {
+		t.Errorf("Expected only the points of the active challenge, got %v", body)
+	}
+}
+
+func TestGetBingoCardWithoutBoard(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/bingo", nil, accessToken.Token)
+	if statusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404, got %v %v", statusCode, body)
+	}
+}
+
+func TestPublishBingoBoardAsPlayer(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	_, statusCode, body := publishBingoBoard(accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v %v", statusCode, body)
+	}
+}
+
+func TestDeleteChallengeOnBingoBoard(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	challenges, statusCode, body := publishBingoBoard(adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenges[0].ID))
+	if statusCode, body := makeRequestWithToken("DELETE", path, nil, adminToken.Token); statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v %v", statusCode, body)
+		return
+	}
+
+	if statusCode, body := makeRequestWithToken("DELETE", "/admin/bingo", nil, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	if statusCode, body := makeRequestWithToken("DELETE", path, nil, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200 once the board is removed, got %v %v", statusCode, body)
+	}
+}
@@ -3,10 +3,12 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"the-wedding-game-api/config"
 	"the-wedding-game-api/middleware"
 	"the-wedding-game-api/middleware/validators"
 	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 func GetChallengeById(c *gin.Context) {
@@ -28,23 +30,89 @@ func GetChallengeById(c *gin.Context) {
 		return
 	}
 
+	if err := challenge.CheckVisibleTo(user); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	completed, err := models.IsChallengeCompleted(user.ID, challenge.ID)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	response := types.GetChallengeResponse{
-		Id:          challenge.ID,
-		Name:        challenge.Name,
-		Description: challenge.Description,
-		Points:      challenge.Points,
-		Image:       challenge.Image,
-		Status:      challenge.Status,
-		Type:        challenge.Type,
-		Completed:   completed,
+	completedChallenges, err := models.GetCompletedChallenges(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	unmetPrerequisites, err := models.GetUnmetPrerequisites(completedChallenges)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	prerequisiteNames, err := models.GetChallengeNames(unmetPrerequisites[challenge.ID])
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	options, err := getOptionsForChallenge(challenge)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	items, err := getItemsForChallenge(challenge, user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	revealedHints, err := models.GetRevealedHintIds(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	hints, remainingHints, err := getHintsForChallenge(challenge, revealedHints)
+	if err != nil {
+		_ = c.Error(err)
+		return
 	}
 
+	submission, err := models.GetSubmission(user.ID, challenge.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetChallengeResponse{
+		Id:             challenge.ID,
+		Name:           challenge.Name,
+		Description:    challenge.Description,
+		Points:         challenge.Points,
+		Image:          challenge.Image,
+		Status:         challenge.Status,
+		Type:           challenge.Type,
+		Completed:      completed,
+		Options:        options,
+		Items:          items,
+		PartialCredit:  challenge.OrderingPartialCredit,
+		Hints:          hints,
+		RemainingHints: remainingHints,
+		MaxAttempts:    challenge.MaxAttempts,
+		MaxDuration:    challenge.MaxDurationSeconds,
+		Team:           challenge.TeamChallenge,
+		Outcome:        challenge.Outcome,
+		ResolvedAt:     inEventTimezone(challenge.ResolvedAt),
+		Location:       getCheckInLocation(challenge),
+	}
+	setSubmissionState(&response, submission)
+	lockChallengeResponse(&response, unmetPrerequisites[challenge.ID], prerequisiteNames)
+
 	c.IndentedJSON(http.StatusOK, response)
 	return
 }
@@ -63,26 +131,29 @@ func CreateChallenge(c *gin.Context) {
 	}
 
 	response := types.ChallengeCreatedResponse{
-		Id:          createdChallenge.ID,
-		Name:        createdChallenge.Name,
-		Description: createdChallenge.Description,
-		Points:      createdChallenge.Points,
-		Image:       createdChallenge.Image,
-		Status:      createdChallenge.Status,
-		Type:        createdChallenge.Type,
+		Id:             createdChallenge.ID,
+		Name:           createdChallenge.Name,
+		Description:    createdChallenge.Description,
+		Points:         createdChallenge.Points,
+		Image:          createdChallenge.Image,
+		Status:         createdChallenge.Status,
+		Type:           createdChallenge.Type,
+		AvailableFrom:  inEventTimezone(createdChallenge.AvailableFrom),
+		AvailableUntil: inEventTimezone(createdChallenge.AvailableUntil),
+		MaxAttempts:    createdChallenge.MaxAttempts,
 	}
 	c.IndentedJSON(http.StatusCreated, response)
 	return
 }
 
 func GetAllChallenges(c *gin.Context) {
-	challengesArr, err := models.GetAllChallenges(false)
+	user, err := middleware.GetCurrentUser(c)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	user, err := middleware.GetCurrentUser(c)
+	challengesArr, err := models.GetChallengesVisibleTo(user)
 	if err != nil {
 		_ = c.Error(err)
 		return
@@ -94,21 +165,86 @@ func GetAllChallenges(c *gin.Context) {
 		return
 	}
 
+	ownSubmissions, err := models.GetSubmissionsForUser(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	submissionsByChallenge := make(map[uint]*models.Submission)
+	for i := range ownSubmissions {
+		submissionsByChallenge[ownSubmissions[i].ChallengeID] = &ownSubmissions[i]
+	}
+
+	unmetPrerequisites, err := models.GetUnmetPrerequisites(submissions)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var lockingChallengeIds []uint
+	for _, prerequisiteIds := range unmetPrerequisites {
+		lockingChallengeIds = append(lockingChallengeIds, prerequisiteIds...)
+	}
+	prerequisiteNames, err := models.GetChallengeNames(lockingChallengeIds)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	revealedHints, err := models.GetRevealedHintIds(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	var response types.GetChallengesResponse
 	response.Challenges = make([]types.GetChallengeResponse, 0)
 	for _, challenge := range challengesArr {
 		isCompleted := models.IsChallengeInSubmissions(challenge.ID, submissions)
 
-		response.Challenges = append(response.Challenges, types.GetChallengeResponse{
-			Id:          challenge.ID,
-			Name:        challenge.Name,
-			Description: challenge.Description,
-			Points:      challenge.Points,
-			Image:       challenge.Image,
-			Status:      challenge.Status,
-			Type:        challenge.Type,
-			Completed:   isCompleted,
-		})
+		options, err := getOptionsForChallenge(challenge)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		items, err := getItemsForChallenge(challenge, user.ID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		hints, remainingHints, err := getHintsForChallenge(challenge, revealedHints)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		challengeResponse := types.GetChallengeResponse{
+			Id:             challenge.ID,
+			Name:           challenge.Name,
+			Description:    challenge.Description,
+			Points:         challenge.Points,
+			Image:          challenge.Image,
+			Status:         challenge.Status,
+			Type:           challenge.Type,
+			Completed:      isCompleted,
+			Options:        options,
+			Items:          items,
+			PartialCredit:  challenge.OrderingPartialCredit,
+			Hints:          hints,
+			RemainingHints: remainingHints,
+			MaxAttempts:    challenge.MaxAttempts,
+			MaxDuration:    challenge.MaxDurationSeconds,
+			Team:           challenge.TeamChallenge,
+			Outcome:        challenge.Outcome,
+			ResolvedAt:     inEventTimezone(challenge.ResolvedAt),
+			Location:       getCheckInLocation(challenge),
+		}
+		setSubmissionState(&challengeResponse, submissionsByChallenge[challenge.ID])
+		lockChallengeResponse(&challengeResponse, unmetPrerequisites[challenge.ID], prerequisiteNames)
+		response.Challenges = append(response.Challenges, challengeResponse)
 	}
 
 	c.IndentedJSON(http.StatusOK, response)
@@ -122,17 +258,75 @@ func GetAllChallengesAdmin(c *gin.Context) {
 		return
 	}
 
+	prerequisites, err := models.GetAllPrerequisites()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	prerequisitesByChallenge := make(map[uint][]uint)
+	for _, prerequisite := range prerequisites {
+		prerequisitesByChallenge[prerequisite.ChallengeID] = append(prerequisitesByChallenge[prerequisite.ChallengeID], prerequisite.PrerequisiteID)
+	}
+
 	var response types.GetChallengesAdminResponse
 	response.Challenges = make([]types.GetChallengeAdminResponse, len(challengesArr))
 	for i, challenge := range challengesArr {
+		options, err := getOptionsForChallengeAdmin(challenge)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		items, err := getItemsForChallengeAdmin(challenge)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		hints, err := getHintsForChallengeAdmin(challenge)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
 		response.Challenges[i] = types.GetChallengeAdminResponse{
-			Id:          challenge.ID,
-			Name:        challenge.Name,
-			Description: challenge.Description,
-			Points:      challenge.Points,
-			Image:       challenge.Image,
-			Status:      challenge.Status,
-			Type:        challenge.Type,
+			Id:             challenge.ID,
+			Name:           challenge.Name,
+			Description:    challenge.Description,
+			Points:         challenge.Points,
+			Image:          challenge.Image,
+			Status:         challenge.Status,
+			Type:           challenge.Type,
+			Options:        options,
+			Items:          items,
+			PartialCredit:  challenge.OrderingPartialCredit,
+			AvailableFrom:  inEventTimezone(challenge.AvailableFrom),
+			AvailableUntil: inEventTimezone(challenge.AvailableUntil),
+			Prerequisites:  prerequisitesByChallenge[challenge.ID],
+			Hints:          hints,
+			MaxAttempts:    challenge.MaxAttempts,
+			Scoring:        challenge.GetScoringRules(),
+			Team:           challenge.TeamChallenge,
+			MaxDuration:    challenge.MaxDurationSeconds,
+			Location:       getCheckInLocation(challenge),
+		}
+
+		if challenge.Type == types.AnswerQuestionChallenge {
+			answerMatching := challenge.GetAnswerMatching()
+			response.Challenges[i].AnswerMatching = &answerMatching
+		}
+
+		if challenge.Type == types.ScanCodeChallenge {
+			response.Challenges[i].SecretCode = challenge.SecretCode
+			response.Challenges[i].ScanLink = challenge.GetScanLink()
+		}
+
+		if challenge.Type == types.PredictionChallenge {
+			prediction := challenge.GetPredictionRules()
+			response.Challenges[i].Prediction = &prediction
+			response.Challenges[i].Outcome = challenge.Outcome
+			response.Challenges[i].ResolvedAt = inEventTimezone(challenge.ResolvedAt)
 		}
 	}
 
@@ -147,20 +341,24 @@ func VerifyAnswer(c *gin.Context) {
 		return
 	}
 
-	correct, err := models.VerifyAnswer(challengeId, verifyAnswerRequest.Answer)
+	user, err := middleware.GetCurrentUser(c)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
-	if !correct {
-		response := types.VerifyAnswerResponse{Correct: false}
-		c.IndentedJSON(http.StatusOK, response)
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	user, err := middleware.GetCurrentUser(c)
-	if err != nil {
+	if err := models.CheckChallengeUnlocked(challengeId, user.ID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := challenge.CheckNotInQuizRound(); err != nil {
 		_ = c.Error(err)
 		return
 	}
@@ -171,13 +369,54 @@ func VerifyAnswer(c *gin.Context) {
 		return
 	}
 
+	// Answers after completing the challenge are not counted as attempts
 	if !isAlreadyCompleted {
-		submission := models.NewSubmission(user.ID, challengeId, verifyAnswerRequest.Answer)
-		_, err = submission.Save()
+		if err := challenge.CheckAttemptsLeft(user.ID); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	correct, err := models.VerifyAnswer(challengeId, verifyAnswerRequest.Answer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if isAlreadyCompleted {
+		response := types.VerifyAnswerResponse{Correct: correct}
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+
+	if correct {
+		if err := challenge.CheckMediaUpload(user.ID, verifyAnswerRequest.Answer); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
+	attempt := models.NewAttempt(user.ID, challengeId, verifyAnswerRequest.Answer, correct)
+	if _, err := challenge.SaveAttempt(attempt); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if !correct {
+		remainingAttempts, err := challenge.GetRemainingAttempts(user.ID)
 		if err != nil {
 			_ = c.Error(err)
 			return
 		}
+
+		response := types.VerifyAnswerResponse{Correct: false, RemainingAttempts: remainingAttempts}
+		c.IndentedJSON(http.StatusOK, response)
+		return
+	}
+
+	if _, err := models.CompleteChallenge(user.ID, challenge, verifyAnswerRequest.Answer); err != nil {
+		_ = c.Error(err)
+		return
 	}
 
 	response := types.VerifyAnswerResponse{Correct: true}
@@ -205,13 +444,16 @@ func UpdateChallenge(c *gin.Context) {
 	}
 
 	response := types.UpdateChallengeResponse{
-		Id:          challenge.ID,
-		Name:        challenge.Name,
-		Description: challenge.Description,
-		Points:      challenge.Points,
-		Image:       challenge.Image,
-		Status:      challenge.Status,
-		Type:        challenge.Type,
+		Id:             challenge.ID,
+		Name:           challenge.Name,
+		Description:    challenge.Description,
+		Points:         challenge.Points,
+		Image:          challenge.Image,
+		Status:         challenge.Status,
+		Type:           challenge.Type,
+		AvailableFrom:  inEventTimezone(challenge.AvailableFrom),
+		AvailableUntil: inEventTimezone(challenge.AvailableUntil),
+		MaxAttempts:    challenge.MaxAttempts,
 	}
 
 	c.IndentedJSON(http.StatusOK, response)
@@ -231,6 +473,16 @@ func GetSubmissions(c *gin.Context) {
 		return
 	}
 
+	// Check-in submissions show how far from the location the player was
+	if len(submissions) > 0 {
+		challenge, err := models.GetChallengeByID(challengeId)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		challenge.AddCheckInDistances(submissions)
+	}
+
 	var response types.GetSubmissionsResponse
 	response.Submissions = submissions
 
@@ -238,6 +490,59 @@ func GetSubmissions(c *gin.Context) {
 	return
 }
 
+func GetAttempts(c *gin.Context) {
+	challengeId, err := validators.ValidateGetAttemptsRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if _, err := models.GetChallengeByID(challengeId); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	attempts, err := models.GetAttemptsForChallenge(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	for i := range attempts {
+		attempts[i].CreatedAt = *inEventTimezone(&attempts[i].CreatedAt)
+	}
+
+	response := types.GetAttemptsResponse{
+		Attempts: attempts,
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func GetChallengeQRCode(c *gin.Context) {
+	challengeId, err := validators.ValidateGetQRCodeRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	png, err := challenge.GetQRCode()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", png)
+	return
+}
+
 func GetAnswer(c *gin.Context) {
 	challengeId, err := validators.ValidateGetAnswerRequest(c)
 	if err != nil {
@@ -245,14 +550,15 @@ func GetAnswer(c *gin.Context) {
 		return
 	}
 
-	answer, err := models.GetAnswer(challengeId)
+	answers, err := models.GetAnswer(challengeId)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
 	response := types.GetAnswerResponse{
-		Answer: answer,
+		Answer:  answers[0],
+		Answers: answers,
 	}
 
 	c.IndentedJSON(http.StatusOK, response)
@@ -272,6 +578,16 @@ func DeleteChallenge(c *gin.Context) {
 		return
 	}
 
+	if err := challenge.CheckNotOnBingoBoard(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := challenge.CheckNotAskedInQuizRound(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
 	err = challenge.Delete()
 	if err != nil {
 		_ = c.Error(err)
@@ -284,3 +600,183 @@ func DeleteChallenge(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, response)
 	return
 }
+
+// getOptionsForChallenge returns the options of a multiple choice challenge without revealing the correct ones.
+func getOptionsForChallenge(challenge models.Challenge) ([]types.ChallengeOption, error) {
+	if challenge.Type != types.MultipleChoiceChallenge {
+		return nil, nil
+	}
+
+	options, err := models.GetOptionsForChallenge(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]types.ChallengeOption, len(options))
+	for i, option := range options {
+		response[i] = types.ChallengeOption{
+			Id:    option.ID,
+			Value: option.Value,
+		}
+	}
+	return response, nil
+}
+
+func getOptionsForChallengeAdmin(challenge models.Challenge) ([]types.ChallengeOptionAdmin, error) {
+	if challenge.Type != types.MultipleChoiceChallenge {
+		return nil, nil
+	}
+
+	options, err := models.GetOptionsForChallenge(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]types.ChallengeOptionAdmin, len(options))
+	for i, option := range options {
+		response[i] = types.ChallengeOptionAdmin{
+			Id:      option.ID,
+			Value:   option.Value,
+			Correct: option.Correct,
+		}
+	}
+	return response, nil
+}
+
+// getItemsForChallenge returns the items of an ordering challenge shuffled for the user, without revealing their positions.
+func getItemsForChallenge(challenge models.Challenge, userId uint) ([]types.ChallengeItem, error) {
+	if challenge.Type != types.OrderingChallenge {
+		return nil, nil
+	}
+
+	items, err := models.GetItemsForChallenge(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	shuffled := models.ShuffleItems(items, userId, challenge.ID)
+	response := make([]types.ChallengeItem, len(shuffled))
+	for i, item := range shuffled {
+		response[i] = types.ChallengeItem{
+			Id:    item.ID,
+			Value: item.Value,
+		}
+	}
+	return response, nil
+}
+
+func getCheckInLocation(challenge models.Challenge) *types.CheckInLocation {
+	if challenge.Type != types.CheckInChallenge {
+		return nil
+	}
+
+	location := challenge.GetCheckInLocation()
+	return &location
+}
+
+func getItemsForChallengeAdmin(challenge models.Challenge) ([]types.ChallengeItemAdmin, error) {
+	if challenge.Type != types.OrderingChallenge {
+		return nil, nil
+	}
+
+	items, err := models.GetItemsForChallenge(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]types.ChallengeItemAdmin, len(items))
+	for i, item := range items {
+		response[i] = types.ChallengeItemAdmin{
+			Id:       item.ID,
+			Value:    item.Value,
+			Position: item.Position,
+		}
+	}
+	return response, nil
+}
+
+// getHintsForChallenge returns the hints the user already revealed and how many are still hidden.
+func getHintsForChallenge(challenge models.Challenge, revealed map[uint]bool) ([]types.ChallengeHint, int, error) {
+	hints, err := models.GetHintsForChallenge(challenge.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revealedHints, remaining := models.SplitHints(hints, revealed)
+	if len(revealedHints) == 0 {
+		return nil, remaining, nil
+	}
+
+	response := make([]types.ChallengeHint, len(revealedHints))
+	for i, hint := range revealedHints {
+		response[i] = types.ChallengeHint{
+			Position: hint.Position,
+			Text:     hint.Text,
+			Cost:     hint.Cost,
+		}
+	}
+	return response, remaining, nil
+}
+
+func getHintsForChallengeAdmin(challenge models.Challenge) ([]types.ChallengeHintAdmin, error) {
+	hints, err := models.GetHintsForChallenge(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hints) == 0 {
+		return nil, nil
+	}
+
+	response := make([]types.ChallengeHintAdmin, len(hints))
+	for i, hint := range hints {
+		response[i] = types.ChallengeHintAdmin{
+			Id:       hint.ID,
+			Position: hint.Position,
+			Text:     hint.Text,
+			Cost:     hint.Cost,
+		}
+	}
+	return response, nil
+}
+
+// lockChallengeResponse marks the challenge as locked and hides its content until the prerequisites are completed
+func lockChallengeResponse(response *types.GetChallengeResponse, unmetPrerequisites []uint, names map[uint]string) {
+	if len(unmetPrerequisites) == 0 {
+		return
+	}
+
+	response.Locked = true
+	response.Description = ""
+	response.Image = ""
+	response.Options = nil
+	response.Items = nil
+	response.Hints = nil
+	response.Location = nil
+	response.UnmetPrerequisites = make([]types.ChallengePrerequisite, len(unmetPrerequisites))
+	for i, prerequisiteId := range unmetPrerequisites {
+		response.UnmetPrerequisites[i] = types.ChallengePrerequisite{
+			Id:   prerequisiteId,
+			Name: names[prerequisiteId],
+		}
+	}
+}
+
+// setSubmissionState shows the player that their photo is waiting for moderation or why it was rejected
+func setSubmissionState(response *types.GetChallengeResponse, submission *models.Submission) {
+	if submission == nil || submission.IsApproved() {
+		return
+	}
+
+	response.SubmissionState = submission.State
+	response.RejectionReason = submission.RejectionReason
+}
+
+// inEventTimezone presents a timestamp in the timezone of the event, so admins see the local time of the wedding
+func inEventTimezone(timestamp *time.Time) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	local := timestamp.In(config.GetEventLocation())
+	return &local
+}
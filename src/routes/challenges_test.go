@@ -3,12 +3,14 @@ package routes
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
 	"testing"
 	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
+	"time"
 )
 
 func TestGetChallengeByIdUploadPhoto(t *testing.T) {
@@ -996,7 +998,7 @@ func TestGetAllChallenges(t *testing.T) {
 		Completed:   false,
 	}
 
-	if response.Challenges[0] != expectedResponseChallenge1 {
+	if !reflect.DeepEqual(response.Challenges[0], expectedResponseChallenge1) {
 		t.Errorf("Expected challenge 1 to be %v, got %v", expectedResponseChallenge1, response.Challenges[0])
 		return
 	}
@@ -1012,7 +1014,7 @@ func TestGetAllChallenges(t *testing.T) {
 		Completed:   false,
 	}
 
-	if response.Challenges[1] != expectedResponseChallenge2 {
+	if !reflect.DeepEqual(response.Challenges[1], expectedResponseChallenge2) {
 		t.Errorf("Expected challenge 2 to be %v, got %v", expectedResponseChallenge2, response.Challenges[1])
 		return
 	}
@@ -1453,6 +1455,11 @@ func TestVerifyAnswerChallengeUploadPhotoChallenge(t *testing.T) {
 		return
 	}
 
+	if err := createUpload(accessToken.UserID, "https://images.com/test.jpg"); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
 	verifyAnswerRequest := types.VerifyAnswerRequest{
 		Answer: "https://images.com/test.jpg",
 	}
@@ -1475,13 +1482,20 @@ func TestVerifyAnswerChallengeUploadPhotoChallenge(t *testing.T) {
 		t.Errorf("Expected correct true, got %v", response.Correct)
 	}
 
+	// Photos only complete the challenge once they are approved
 	isCompleted, err := models.IsChallengeCompleted(accessToken.UserID, challenge.ID)
 	if err != nil {
 		t.Errorf("Error checking if challenge is completed: %v", err)
 		return
 	}
-	if !isCompleted {
-		t.Errorf("Expected challenge completed, got %v", isCompleted)
+	if isCompleted {
+		t.Errorf("Expected challenge pending moderation, got %v", isCompleted)
+		return
+	}
+
+	submission, err := models.GetSubmission(accessToken.UserID, challenge.ID)
+	if err != nil || submission == nil || submission.State != types.PendingSubmission {
+		t.Errorf("Expected a pending submission, got %v %v", submission, err)
 		return
 	}
 }
@@ -3159,6 +3173,35 @@ func TestGetSubmissionsWithoutToken(t *testing.T) {
 	}
 }
 
+func TestGetSubmissionsAsPlayer(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, responseBody := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/submissions", nil, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"access denied\",\"status\":\"error\"}"
+	if responseBody != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, responseBody)
+		return
+	}
+}
+
 func TestGetAnswer(t *testing.T) {
 	models.ResetConnection()
 	deleteAllChallenges()
@@ -3200,7 +3243,8 @@ func TestGetAnswer(t *testing.T) {
 	}
 
 	var expectedResponse = types.GetAnswerResponse{
-		Answer: answer.Value,
+		Answer:  answer.Value,
+		Answers: []string{answer.Value},
 	}
 	var response types.GetAnswerResponse
 	err = json.Unmarshal([]byte(responseBody), &response)
@@ -3834,3 +3878,632 @@ func TestDeleteChallengeNotAdmin(t *testing.T) {
 		return
 	}
 }
+
+func TestCreateChallengeMultipleChoice(t *testing.T) {
+	models.ResetConnection()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challengeRequest := types.CreateChallengeRequest{
+		Name:        "test_challenge",
+		Description: "test_description",
+		Points:      10,
+		Image:       "https://test_image.com",
+		Type:        types.MultipleChoiceChallenge,
+		Options: []types.ChallengeOptionInput{
+			{Value: "Paris", Correct: true},
+			{Value: "London"},
+		},
+	}
+	statusCode, body := makeRequestWithToken("POST", "/challenges", challengeRequest, accessToken.Token)
+
+	if statusCode != http.StatusCreated {
+		t.Errorf("Expected status code 201, got %v", statusCode)
+	}
+
+	var response types.ChallengeCreatedResponse
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Type != types.MultipleChoiceChallenge {
+		t.Errorf("Expected type MULTIPLE_CHOICE, got %v", response.Type)
+	}
+
+	options, err := models.GetOptionsForChallenge(response.Id)
+	if err != nil {
+		t.Errorf("Error getting options: %v", err)
+		return
+	}
+
+	if len(options) != 2 {
+		t.Errorf("Expected 2 options, got %v", len(options))
+		return
+	}
+
+	if options[0].Value != "Paris" || !options[0].Correct {
+		t.Errorf("Expected Paris to be the correct option, got %v", options[0])
+	}
+
+	if options[1].Value != "London" || options[1].Correct {
+		t.Errorf("Expected London to be an incorrect option, got %v", options[1])
+	}
+}
+
+func TestCreateChallengeMultipleChoiceWithoutCorrectOption(t *testing.T) {
+	models.ResetConnection()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challengeRequest := types.CreateChallengeRequest{
+		Name:        "test_challenge",
+		Description: "test_description",
+		Points:      10,
+		Image:       "https://test_image.com",
+		Type:        types.MultipleChoiceChallenge,
+		Options: []types.ChallengeOptionInput{
+			{Value: "Paris"},
+			{Value: "London"},
+		},
+	}
+	statusCode, body := makeRequestWithToken("POST", "/challenges", challengeRequest, accessToken.Token)
+
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+	}
+
+	expectedBody := `{"message":"at least one option must be marked as correct","status":"error"}`
+	if body != expectedBody {
+		t.Errorf("Expected body %v, got %v", expectedBody, body)
+	}
+}
+
+func TestGetChallengeByIdMultipleChoice(t *testing.T) {
+	models.ResetConnection()
+
+	challenge, options, err := createMultipleChoiceChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+
+	var response types.GetChallengeResponse
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedOptions := []types.ChallengeOption{
+		{Id: options[0].ID, Value: "Paris"},
+		{Id: options[1].ID, Value: "London"},
+		{Id: options[2].ID, Value: "Rome"},
+	}
+	if !reflect.DeepEqual(response.Options, expectedOptions) {
+		t.Errorf("Expected options %v, got %v", expectedOptions, response.Options)
+	}
+}
+
+func TestVerifyAnswerMultipleChoiceChallenge(t *testing.T) {
+	models.ResetConnection()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, options, err := createMultipleChoiceChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	verifyAnswerRequest := types.VerifyAnswerRequest{
+		Answer: strconv.Itoa(int(options[1].ID)),
+	}
+	statusCode, responseBody := makeRequestWithToken("POST", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/verify", verifyAnswerRequest, accessToken.Token)
+
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+
+	var response types.VerifyAnswerResponse
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Correct != false {
+		t.Errorf("Expected correct false, got %v", response.Correct)
+	}
+
+	verifyAnswerRequest = types.VerifyAnswerRequest{
+		Answer: strconv.Itoa(int(options[0].ID)),
+	}
+	statusCode, responseBody = makeRequestWithToken("POST", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/verify", verifyAnswerRequest, accessToken.Token)
+
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+
+	response = types.VerifyAnswerResponse{}
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Correct != true {
+		t.Errorf("Expected correct true, got %v", response.Correct)
+	}
+
+	isCompleted, err := models.IsChallengeCompleted(accessToken.UserID, challenge.ID)
+	if err != nil {
+		t.Errorf("Error checking if challenge is completed: %v", err)
+		return
+	}
+	if !isCompleted {
+		t.Errorf("Expected challenge completed, got %v", isCompleted)
+	}
+}
+
+func TestVerifyAnswerQuestionChallengeNormalizedAnswer(t *testing.T) {
+	models.ResetConnection()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge := models.Challenge{
+		Name:        "test_challenge",
+		Description: "test_description",
+		Points:      10,
+		Image:       "test_image",
+		Type:        types.AnswerQuestionChallenge,
+		Status:      types.ActiveChallenge,
+	}
+	challenge, err = challenge.Save()
+	if err != nil {
+		t.Errorf("Error saving challenge: %v", err)
+		return
+	}
+
+	answer := models.Answer{
+		ChallengeID: challenge.ID,
+		Value:       "Eiffel Tower",
+	}
+	_, err = answer.Save()
+	if err != nil {
+		t.Errorf("Error saving answer: %v", err)
+		return
+	}
+
+	verifyAnswerRequest := types.VerifyAnswerRequest{
+		Answer: "The eiffel  tower ",
+	}
+	statusCode, responseBody := makeRequestWithToken("POST", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/verify", verifyAnswerRequest, accessToken.Token)
+
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+
+	var response types.VerifyAnswerResponse
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Correct != true {
+		t.Errorf("Expected correct true, got %v", response.Correct)
+	}
+}
+
+func TestUpdateChallengeAnswerMatching(t *testing.T) {
+	models.ResetConnection()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createAnswerQuestionChallenge()
+	if err != nil {
+		t.Errorf("Error saving challenge: %v", err)
+		return
+	}
+
+	answer := models.Answer{
+		ChallengeID: challenge.ID,
+		Value:       "42",
+	}
+	_, err = answer.Save()
+	if err != nil {
+		t.Errorf("Error saving answer: %v", err)
+		return
+	}
+
+	updateChallengeRequest := types.UpdateChallengeRequest{
+		Name:        "updated_name",
+		Description: "updated_description",
+		Points:      20,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.AnswerQuestionChallenge,
+		Status:      types.ActiveChallenge,
+		Answer:      "42",
+		AnswerMatching: types.AnswerMatching{
+			Mode:             types.NumericAnswerMatch,
+			NumericTolerance: 2,
+		},
+	}
+	statusCode, _ := makeRequestWithToken("PUT", "/challenges/"+strconv.Itoa(int(challenge.ID)), updateChallengeRequest, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	verify, err := models.VerifyAnswer(challenge.ID, "40,5")
+	if err != nil {
+		t.Errorf("Error verifying answer: %v", err)
+		return
+	}
+	if !verify {
+		t.Errorf("Expected answer within tolerance to be verified")
+	}
+
+	verify, err = models.VerifyAnswer(challenge.ID, "39")
+	if err != nil {
+		t.Errorf("Error verifying answer: %v", err)
+		return
+	}
+	if verify {
+		t.Errorf("Expected answer outside tolerance not to be verified")
+	}
+}
+
+func createScheduledChallenge(availableFrom *time.Time, availableUntil *time.Time) (models.Challenge, error) {
+	challenge := models.Challenge{
+		Name:           "scheduled_challenge",
+		Description:    "scheduled_description",
+		Points:         10,
+		Image:          "test_image",
+		Type:           types.AnswerQuestionChallenge,
+		Status:         types.ActiveChallenge,
+		AvailableFrom:  availableFrom,
+		AvailableUntil: availableUntil,
+	}
+	challenge, err := challenge.Save()
+	if err != nil {
+		return models.Challenge{}, err
+	}
+
+	_, err = models.NewAnswer(challenge.ID, "first dance").Save()
+	if err != nil {
+		return models.Challenge{}, err
+	}
+
+	return challenge, nil
+}
+
+func TestGetAllChallengesHidesScheduledChallenges(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+	if _, err := createScheduledChallenge(&future, nil); err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	if _, err := createScheduledChallenge(nil, &past); err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	open, err := createScheduledChallenge(&past, &future)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetChallengesResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Challenges) != 1 || response.Challenges[0].Id != open.ID {
+		t.Errorf("Expected only challenge %v, got %v", open.ID, response.Challenges)
+	}
+}
+
+func TestGetChallengeByIdBeforeAvailability(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	future := time.Now().Add(time.Hour)
+	challenge, err := createScheduledChallenge(&future, nil)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+	if statusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404, got %v", statusCode)
+	}
+}
+
+func TestVerifyAnswerAfterAvailability(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	past := time.Now().Add(-time.Hour)
+	challenge, err := createScheduledChallenge(nil, &past)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("POST", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/verify",
+		types.VerifyAnswerRequest{Answer: "first dance"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"challenge is no longer available\",\"status\":\"error\"}"
+	if body != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, body)
+	}
+}
+
+func TestGetAllChallengesAdminWithAvailability(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	from := time.Date(2025, 6, 21, 18, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 6, 21, 20, 0, 0, 0, time.UTC)
+	if _, err := createScheduledChallenge(&from, &until); err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/admin/challenges", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetChallengesAdminResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Challenges) != 1 {
+		t.Errorf("Expected 1 challenge, got %v", len(response.Challenges))
+		return
+	}
+	if response.Challenges[0].AvailableFrom == nil || !response.Challenges[0].AvailableFrom.Equal(from) {
+		t.Errorf("Expected available from %v, got %v", from, response.Challenges[0].AvailableFrom)
+	}
+	if response.Challenges[0].AvailableUntil == nil || !response.Challenges[0].AvailableUntil.Equal(until) {
+		t.Errorf("Expected available until %v, got %v", until, response.Challenges[0].AvailableUntil)
+	}
+}
+
+func createChallengeChain() (models.Challenge, models.Challenge, error) {
+	first, err := createScheduledChallenge(nil, nil)
+	if err != nil {
+		return models.Challenge{}, models.Challenge{}, err
+	}
+
+	second, err := createScheduledChallenge(nil, nil)
+	if err != nil {
+		return models.Challenge{}, models.Challenge{}, err
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		return models.Challenge{}, models.Challenge{}, err
+	}
+
+	updateChallengeRequest := types.UpdateChallengeRequest{
+		Name:          second.Name,
+		Description:   second.Description,
+		Points:        second.Points,
+		Image:         "https://example.com/image.jpg",
+		Type:          second.Type,
+		Status:        second.Status,
+		Prerequisites: []uint{first.ID},
+	}
+	statusCode, body := makeRequestWithToken("PUT", "/challenges/"+strconv.Itoa(int(second.ID)), updateChallengeRequest, accessToken.Token)
+	if statusCode != http.StatusOK {
+		return models.Challenge{}, models.Challenge{}, fmt.Errorf("error adding prerequisite: %v", body)
+	}
+
+	return first, second, nil
+}
+
+func TestGetAllChallengesWithLockedChallenge(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	first, second, err := createChallengeChain()
+	if err != nil {
+		t.Errorf("Error creating challenges: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetChallengesResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Challenges) != 2 {
+		t.Errorf("Expected 2 challenges, got %v", len(response.Challenges))
+		return
+	}
+	if response.Challenges[0].Locked {
+		t.Errorf("Expected challenge %v to be unlocked", first.ID)
+	}
+
+	expectedLocked := types.GetChallengeResponse{
+		Id:                 second.ID,
+		Name:               second.Name,
+		Points:             second.Points,
+		Status:             second.Status,
+		Type:               second.Type,
+		Locked:             true,
+		UnmetPrerequisites: []types.ChallengePrerequisite{{Id: first.ID, Name: first.Name}},
+	}
+	if !reflect.DeepEqual(response.Challenges[1], expectedLocked) {
+		t.Errorf("Expected %v, got %v", expectedLocked, response.Challenges[1])
+	}
+}
+
+func TestVerifyAnswerForLockedChallenge(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	first, second, err := createChallengeChain()
+	if err != nil {
+		t.Errorf("Error creating challenges: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(second.ID)) + "/verify"
+	statusCode, body := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "first dance"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"challenge is locked until its prerequisites are completed\",\"status\":\"error\"}"
+	if body != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, body)
+		return
+	}
+
+	if err := createSubmission(first.ID, user.ID, "first dance"); err != nil {
+		t.Errorf("Error creating submission: %v", err)
+		return
+	}
+
+	statusCode, _ = makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "first dance"}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200 once unlocked, got %v", statusCode)
+	}
+}
+
+func TestUpdateChallengePrerequisitesWithCycle(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	first, second, err := createChallengeChain()
+	if err != nil {
+		t.Errorf("Error creating challenges: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	updateChallengeRequest := types.UpdateChallengeRequest{
+		Name:          first.Name,
+		Description:   first.Description,
+		Points:        first.Points,
+		Image:         "https://example.com/image.jpg",
+		Type:          first.Type,
+		Status:        first.Status,
+		Prerequisites: []uint{second.ID},
+	}
+	statusCode, body := makeRequestWithToken("PUT", "/challenges/"+strconv.Itoa(int(first.ID)), updateChallengeRequest, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"prerequisites cannot form a cycle\",\"status\":\"error\"}"
+	if body != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, body)
+	}
+}
@@ -0,0 +1,109 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createCheckInChallenge() (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "ceremony",
+		Description: "Check in at the ceremony",
+		Points:      10,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.CheckInChallenge,
+		Location:    &types.CheckInLocation{Latitude: 52.3791, Longitude: 4.9003, Radius: 100},
+	})
+}
+
+func checkIn(challengeID uint, accessToken string, location string) (int, string) {
+	path := "/challenges/" + strconv.Itoa(int(challengeID)) + "/verify"
+	return makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: location}, accessToken)
+}
+
+func TestCheckIn(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createCheckInChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+	var response types.GetChallengeResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting challenge: %v %v", statusCode, body)
+		return
+	}
+	if response.Location == nil || response.Location.Radius != 100 {
+		t.Errorf("Expected the location of the challenge, got %v", body)
+		return
+	}
+
+	statusCode, body = checkIn(challenge.ID, accessToken.Token, "52.3731,4.8926,10")
+	if statusCode != http.StatusOK || !strings.Contains(body, `"correct": false`) {
+		t.Errorf("Expected a check-in at the photo spot to fail, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = checkIn(challenge.ID, accessToken.Token, "52.3791,4.9003,250")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 for an inaccurate location, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = checkIn(challenge.ID, accessToken.Token, "52.3795,4.9008,10")
+	if statusCode != http.StatusOK || !strings.Contains(body, `"correct": true`) {
+		t.Errorf("Expected a check-in at the ceremony to pass, got %v %v", statusCode, body)
+	}
+}
+
+func TestGetSubmissionsShowsCheckInDistance(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createCheckInChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	_, accessToken, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	if statusCode, body := checkIn(challenge.ID, accessToken.Token, "52.3791,4.9003,10"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID))+"/submissions", nil, adminToken.Token)
+	var response types.GetSubmissionsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting submissions: %v %v", statusCode, body)
+		return
+	}
+	if len(response.Submissions) != 1 || response.Submissions[0].Distance == nil || *response.Submissions[0].Distance != 0 {
+		t.Errorf("Expected one submission made at the location, got %v", body)
+	}
+}
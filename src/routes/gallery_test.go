@@ -53,9 +53,9 @@ func TestGetGallery1(t *testing.T) {
 
 	expectedResponse := types.GalleryResponse{
 		Images: []types.GalleryItem{
-			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username},
+			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
 		},
 	}
 	if !reflect.DeepEqual(response, expectedResponse) {
@@ -110,15 +110,15 @@ func TestGetGallery2(t *testing.T) {
 
 	expectedResponse := types.GalleryResponse{
 		Images: []types.GalleryItem{
-			{Url: "https://example.com/image9.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image8.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image7.jpg", SubmittedBy: user1.Username},
-			{Url: "https://example.com/image6.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image5.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image4.jpg", SubmittedBy: user1.Username},
-			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username},
+			{Url: "https://example.com/image9.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image8.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image7.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image6.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image5.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image4.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
 		},
 	}
 	if !reflect.DeepEqual(response, expectedResponse) {
@@ -173,13 +173,13 @@ func TestGalleryWithInvalidUrls(t *testing.T) {
 
 	expectedResponse := types.GalleryResponse{
 		Images: []types.GalleryItem{
-			{Url: "https://example.com/image8.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image7.jpg", SubmittedBy: user1.Username},
-			{Url: "https://example.com/image6.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image5.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username},
+			{Url: "https://example.com/image8.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image7.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image6.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image5.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
 		},
 	}
 
@@ -235,12 +235,12 @@ func TestGalleryWithAnswerQuestionChallenges(t *testing.T) {
 
 	expectedResponse := types.GalleryResponse{
 		Images: []types.GalleryItem{
-			{Url: "https://example.com/image9.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image8.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image7.jpg", SubmittedBy: user1.Username},
-			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username},
-			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username},
-			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username},
+			{Url: "https://example.com/image9.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image8.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image7.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image3.jpg", SubmittedBy: user3.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image2.jpg", SubmittedBy: user2.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
 		},
 	}
 
@@ -347,8 +347,8 @@ func TestGalleryWithInactiveChallenge(t *testing.T) {
 
 	expectedResponse := types.GalleryResponse{
 		Images: []types.GalleryItem{
-			{Url: "https://example.com/image3.jpg", SubmittedBy: user1.Username},
-			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username},
+			{Url: "https://example.com/image3.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
+			{Url: "https://example.com/image1.jpg", SubmittedBy: user1.Username, MediaType: types.ImageMedia},
 		},
 	}
 	if !reflect.DeepEqual(response, expectedResponse) {
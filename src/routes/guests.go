@@ -0,0 +1,79 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetGuests(c *gin.Context) {
+	guests, err := models.GetGuests()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.GetGuestsResponse{
+		Guests: guests,
+	})
+	return
+}
+
+func ImportGuests(c *gin.Context) {
+	importGuestsRequest, err := validators.ValidateImportGuestsRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	guests, err := models.ImportGuests(importGuestsRequest)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetGuestsResponse{
+		Guests: make([]types.GuestResponse, len(guests)),
+	}
+	for i, guest := range guests {
+		response.Guests[i] = types.GuestResponse{
+			Id:         guest.ID,
+			Name:       guest.Name,
+			InviteCode: guest.InviteCode,
+		}
+	}
+
+	c.IndentedJSON(http.StatusCreated, response)
+	return
+}
+
+func GetRegistrationSettings(c *gin.Context) {
+	open, err := models.IsRegistrationOpen()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.RegistrationSettings{
+		OpenRegistration: &open,
+	})
+	return
+}
+
+func UpdateRegistrationSettings(c *gin.Context) {
+	registrationSettings, err := validators.ValidateRegistrationSettingsRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := models.SetRegistrationOpen(*registrationSettings.OpenRegistration); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, registrationSettings)
+	return
+}
@@ -0,0 +1,201 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func importGuest(name string, inviteCode string) error {
+	_, err := models.ImportGuests(types.ImportGuestsRequest{Guests: []types.GuestRequest{{Name: name, InviteCode: inviteCode}}})
+	return err
+}
+
+func TestImportGuests(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	request := types.ImportGuestsRequest{Guests: []types.GuestRequest{{Name: "Aunt May", InviteCode: "may123"}, {Name: "Uncle Ben"}}}
+	statusCode, body := makeRequestWithToken("POST", "/admin/guests", request, accessToken.Token)
+	if statusCode != http.StatusCreated {
+		t.Errorf("Expected status code 201, got %v", statusCode)
+		return
+	}
+
+	var response types.GetGuestsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Guests) != 2 || response.Guests[0].InviteCode != "MAY123" || response.Guests[1].InviteCode == "" {
+		t.Errorf("Expected guests with invite codes MAY123 and a generated one, got %v", response.Guests)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/admin/guests", request, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"invite code is already taken\",\"status\":\"error\"}" {
+		t.Errorf("Expected invite code taken error, got %v", body)
+	}
+}
+
+func TestImportGuestsAsPlayer(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	request := types.ImportGuestsRequest{Guests: []types.GuestRequest{{Name: "Aunt May"}}}
+	statusCode, _ := makeRequestWithToken("POST", "/admin/guests", request, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestLoginWithInviteCodeClaimsGuest(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	if err := importGuest("Aunt May", "MAY123"); err != nil {
+		t.Errorf("Error importing guest: %v", err)
+		return
+	}
+
+	statusCode, _ := makeRequest("POST", "/auth/login", types.LoginRequest{Username: "may", InviteCode: "may123"})
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/admin/guests", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetGuestsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Guests) != 1 || response.Guests[0].Username == nil || *response.Guests[0].Username != "may" {
+		t.Errorf("Expected guest claimed by may, got %v", response.Guests)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/login", types.LoginRequest{Username: "may"})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"invite code is required\",\"status\":\"error\"}" {
+		t.Errorf("Expected invite code required error, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/login", types.LoginRequest{Username: "impostor", InviteCode: "MAY123"})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"invite code was already used by another guest\",\"status\":\"error\"}" {
+		t.Errorf("Expected invite code used error, got %v", body)
+		return
+	}
+
+	statusCode, _ = makeRequest("POST", "/auth/login", types.LoginRequest{Username: "may", InviteCode: "MAY123"})
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+}
+
+func TestLoginWithClosedRegistration(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	closed := false
+	statusCode, _ := makeRequestWithToken("PUT", "/admin/registration", types.RegistrationSettings{OpenRegistration: &closed}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/admin/registration", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var settings types.RegistrationSettings
+	if err := json.Unmarshal([]byte(body), &settings); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if settings.OpenRegistration == nil || *settings.OpenRegistration {
+		t.Errorf("Expected closed registration, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/login", types.LoginRequest{Username: "stranger"})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"invite code is required\",\"status\":\"error\"}" {
+		t.Errorf("Expected invite code required error, got %v", body)
+		return
+	}
+
+	exists, _, err := models.DoesUserExist("stranger")
+	if err != nil || exists {
+		t.Errorf("Expected no user to be created, got %v %v", exists, err)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/login", types.LoginRequest{Username: "stranger", InviteCode: "NOPE99"})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"invalid invite code\",\"status\":\"error\"}" {
+		t.Errorf("Expected invalid invite code error, got %v", body)
+	}
+}
@@ -58,6 +58,25 @@ func createAdminAndGetAccessToken() (models.User, models.AccessToken, error) {
 	return user, accessToken, nil
 }
 
+func createModeratorAndGetAccessToken() (models.User, models.AccessToken, error) {
+	counter++
+	user := models.User{
+		Username: "test_user_for_challenges_" + strconv.Itoa(counter),
+		Role:     types.Moderator,
+	}
+	user, err := user.Save()
+	if err != nil {
+		return models.User{}, models.AccessToken{}, err
+	}
+
+	accessToken, err := models.LinkAccessTokenToUser(user.ID)
+	if err != nil {
+		return models.User{}, models.AccessToken{}, err
+	}
+
+	return user, accessToken, nil
+}
+
 func deleteAllChallenges() {
 	database, err := getDatabaseConnection()
 	defer closeDatabaseConnection(database)
@@ -75,7 +94,18 @@ func deleteAllChallenges() {
 	}(db)
 
 	database.Exec(`DELETE FROM answers WHERE id > 0`)
+	database.Exec(`DELETE FROM challenge_options WHERE id > 0`)
+	database.Exec(`DELETE FROM challenge_items WHERE id > 0`)
+	database.Exec(`DELETE FROM bingo_cells WHERE id > 0`)
+	database.Exec(`DELETE FROM bingo_boards WHERE id > 0`)
+	database.Exec(`DELETE FROM quiz_questions WHERE id > 0`)
+	database.Exec(`DELETE FROM quiz_rounds WHERE id > 0`)
+	database.Exec(`DELETE FROM challenge_prerequisites WHERE id > 0`)
+	database.Exec(`DELETE FROM hint_reveals WHERE id > 0`)
+	database.Exec(`DELETE FROM challenge_hints WHERE id > 0`)
+	database.Exec(`DELETE FROM attempts WHERE id > 0`)
 	database.Exec(`DELETE FROM submissions WHERE id > 0`)
+	database.Exec(`DELETE FROM points_entries WHERE id > 0`)
 	database.Exec(`DELETE FROM challenges WHERE id > 0`)
 
 	if database.Error != nil {
@@ -299,16 +329,20 @@ func createInactiveChallengeWithPoints(points uint) (models.Challenge, error) {
 }
 
 func completeChallenge(challengeID uint, userID uint) error {
-	submission := models.Submission{
-		ChallengeID: challengeID,
-		UserID:      userID,
+	challenge, err := models.GetChallengeByID(challengeID)
+	if err != nil {
+		return err
 	}
-	_, err := submission.Save()
+
+	submission, err := models.CompleteChallenge(userID, challenge, "")
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if !submission.IsApproved() {
+		_, err = submission.Approve()
+	}
+	return err
 }
 
 func createSubmission(challengeID uint, userID uint, answer string) error {
@@ -325,6 +359,18 @@ func createSubmission(challengeID uint, userID uint, answer string) error {
 	return nil
 }
 
+func createUpload(userID uint, url string) error {
+	upload := models.Upload{
+		UserID:      userID,
+		StorageKey:  url,
+		Url:         url,
+		Size:        1,
+		ContentHash: "hash",
+	}
+	_, err := upload.Save()
+	return err
+}
+
 func getDatabaseConnection() (db *gorm.DB, err error) {
 	dbURI := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s",
 		os.Getenv("DB_HOST"),
@@ -356,7 +402,7 @@ func resetDatabase() error {
 	}
 	defer closeDatabaseConnection(database)
 
-	database.Exec("TRUNCATE TABLE users, access_tokens, challenges, submissions RESTART IDENTITY CASCADE")
+	database.Exec("TRUNCATE TABLE users, access_tokens, challenges, submissions, teams, uploads, bingo_boards, quiz_rounds, points_entries, guests, settings, login_lockouts RESTART IDENTITY CASCADE")
 
 	return nil
 }
@@ -424,3 +470,33 @@ func checkIfSubmissionExistsForChallenge(challengeId uint) (bool, error) {
 
 	return count > 0, nil
 }
+
+func createMultipleChoiceChallenge() (models.Challenge, []models.ChallengeOption, error) {
+	challenge := models.Challenge{
+		Name:        "test_challenge",
+		Description: "test_description",
+		Points:      100,
+		Image:       "test_image",
+		Type:        types.MultipleChoiceChallenge,
+		Status:      types.ActiveChallenge,
+	}
+
+	challenge, err := challenge.Save()
+	if err != nil {
+		return models.Challenge{}, nil, err
+	}
+
+	options := []models.ChallengeOption{
+		models.NewChallengeOption(challenge.ID, "Paris", true),
+		models.NewChallengeOption(challenge.ID, "London", false),
+		models.NewChallengeOption(challenge.ID, "Rome", false),
+	}
+	for i := range options {
+		options[i], err = options[i].Save()
+		if err != nil {
+			return models.Challenge{}, nil, err
+		}
+	}
+
+	return challenge, options, nil
+}
@@ -0,0 +1,61 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func RevealNextHint(c *gin.Context) {
+	challengeId, err := validators.ValidateRevealHintRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(challengeId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := challenge.CheckVisibleTo(user); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := models.CheckChallengeUnlocked(challenge.ID, user.ID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := models.CheckHintsRevealable(user.ID, challenge.ID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	hint, remaining, err := challenge.RevealNextHint(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.RevealHintResponse{
+		Hint: types.ChallengeHint{
+			Position: hint.Position,
+			Text:     hint.Text,
+			Cost:     hint.Cost,
+		},
+		RemainingHints: remaining,
+	})
+	return
+}
@@ -0,0 +1,198 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createChallengeWithHints(points uint, hints []types.ChallengeHintInput) (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "hinted_challenge",
+		Description: "hinted_description",
+		Points:      points,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.AnswerQuestionChallenge,
+		Answer:      "eiffel tower",
+		Hints:       hints,
+	})
+}
+
+func TestRevealNextHint(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithHints(100, []types.ChallengeHintInput{
+		{Text: "it is tall", Cost: 10},
+		{Text: "it is in Paris", Cost: 30},
+	})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/hints/next"
+	statusCode, body := makeRequestWithToken("POST", path, nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.RevealHintResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedResponse := types.RevealHintResponse{
+		Hint:           types.ChallengeHint{Position: 1, Text: "it is tall", Cost: 10},
+		RemainingHints: 1,
+	}
+	if response != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, response)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var challengeResponse types.GetChallengeResponse
+	if err := json.Unmarshal([]byte(body), &challengeResponse); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedHints := []types.ChallengeHint{{Position: 1, Text: "it is tall", Cost: 10}}
+	if !reflect.DeepEqual(challengeResponse.Hints, expectedHints) || challengeResponse.RemainingHints != 1 {
+		t.Errorf("Expected hints %v with 1 remaining, got %v with %v remaining", expectedHints,
+			challengeResponse.Hints, challengeResponse.RemainingHints)
+	}
+}
+
+func TestRevealNextHintNoHintsLeft(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithHints(100, nil)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/hints/next"
+	statusCode, body := makeRequestWithToken("POST", path, nil, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"no hints left for this challenge\",\"status\":\"error\"}"
+	if body != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, body)
+	}
+}
+
+func TestRevealNextHintForCompletedChallenge(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithHints(100, []types.ChallengeHintInput{{Text: "it is tall", Cost: 10}})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := completeChallenge(challenge.ID, user.ID); err != nil {
+		t.Errorf("Error completing challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/hints/next"
+	statusCode, body := makeRequestWithToken("POST", path, nil, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	expectedResponse := "{\"message\":\"hints cannot be revealed for a completed challenge\",\"status\":\"error\"}"
+	if body != expectedResponse {
+		t.Errorf("Expected response %v, got %v", expectedResponse, body)
+	}
+}
+
+func TestRevealedHintsReducePoints(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallengeWithHints(100, []types.ChallengeHintInput{
+		{Text: "it is tall", Cost: 10},
+		{Text: "it is in Paris", Cost: 30},
+	})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID))
+	for i := 0; i < 2; i++ {
+		if statusCode, _ := makeRequestWithToken("POST", path+"/hints/next", nil, accessToken.Token); statusCode != http.StatusOK {
+			t.Errorf("Expected status code 200, got %v", statusCode)
+			return
+		}
+	}
+
+	statusCode, _ := makeRequestWithToken("POST", path+"/verify", types.VerifyAnswerRequest{Answer: "Eiffel Tower"}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/points/me", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.CurrentUserPointsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Points != 60 {
+		t.Errorf("Expected 60 points after revealing hints worth 40, got %v", response.Points)
+	}
+}
@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/config"
+	"the-wedding-game-api/limiter"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/types"
+	"time"
+)
+
+func GetLockouts(c *gin.Context) {
+	lockouts, err := limiter.GetLockouts()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	now := time.Now()
+	response := types.GetLockoutsResponse{
+		Lockouts: make([]types.LockoutResponse, len(lockouts)),
+	}
+	for i, lockout := range lockouts {
+		response.Lockouts[i] = types.LockoutResponse{
+			Key:           lockout.Key,
+			Failures:      lockout.Failures,
+			LastFailureAt: lockout.LastFailureAt.In(config.GetEventLocation()),
+		}
+		if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+			lockedUntil := lockout.LockedUntil.In(config.GetEventLocation())
+			response.Lockouts[i].LockedUntil = &lockedUntil
+		}
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func ClearLockout(c *gin.Context) {
+	key, err := validators.ValidateClearLockoutRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := limiter.ClearLockout(key); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+	return
+}
@@ -0,0 +1,275 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"the-wedding-game-api/limiter"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func clearLockouts(accessToken string) {
+	_, body := makeRequestWithToken("GET", "/admin/lockouts", nil, accessToken)
+
+	var response types.GetLockoutsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return
+	}
+	for _, lockout := range response.Lockouts {
+		makeRequestWithToken("DELETE", "/admin/lockouts?key="+url.QueryEscape(lockout.Key), nil, accessToken)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	admin, err := models.SetAdminPassword("test_admin_for_lockout", "correct password")
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+	defer clearLockouts(accessToken.Token)
+
+	for i := 0; i < 5; i++ {
+		statusCode, _ := makeRequest("POST", "/auth/login", types.LoginRequest{Username: admin.Username, Password: "wrong password"})
+		if statusCode != http.StatusUnauthorized {
+			t.Errorf("Expected status code 401, got %v", statusCode)
+			return
+		}
+	}
+
+	request := types.LoginRequest{Username: admin.Username, Password: "correct password"}
+	statusCode, body := makeRequest("POST", "/auth/login", request)
+	if statusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status code 429, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"too many failed login attempts, try again later\",\"status\":\"error\"}" {
+		t.Errorf("Expected too many login attempts error, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/admin/lockouts", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetLockoutsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	usernameKey := "username:" + admin.Username
+	locked := false
+	for _, lockout := range response.Lockouts {
+		if lockout.Key == usernameKey {
+			locked = lockout.Failures == 5 && lockout.LockedUntil != nil
+		}
+	}
+	if !locked {
+		t.Errorf("Expected %s to be locked out after 5 failures, got %v", usernameKey, response.Lockouts)
+		return
+	}
+
+	statusCode, _ = makeRequestWithToken("DELETE", "/admin/lockouts?key="+url.QueryEscape(usernameKey), nil, accessToken.Token)
+	if statusCode != http.StatusNoContent {
+		t.Errorf("Expected status code 204, got %v", statusCode)
+		return
+	}
+
+	statusCode, _ = makeRequest("POST", "/auth/login", request)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+}
+
+func TestLoginLockoutRetryAfter(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	admin, err := models.SetAdminPassword("test_admin_for_retry_after", "correct password")
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+	defer clearLockouts(accessToken.Token)
+
+	for i := 0; i < 5; i++ {
+		makeRequest("POST", "/auth/login", types.LoginRequest{Username: admin.Username, Password: "wrong password"})
+	}
+
+	body, _ := json.Marshal(types.LoginRequest{Username: admin.Username, Password: "correct password"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status code 429, got %v", resp.Code)
+		return
+	}
+
+	if retryAfter := resp.Header().Get("Retry-After"); retryAfter != "30" && retryAfter != "29" {
+		t.Errorf("Expected to retry after 30 seconds, got %v", retryAfter)
+	}
+}
+
+func TestLoginLockoutIgnoresForwardedFor(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	admin, err := models.SetAdminPassword("test_admin_for_forwarded_for", "correct password")
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+	defer clearLockouts(accessToken.Token)
+
+	body, _ := json.Marshal(types.LoginRequest{Username: admin.Username, Password: "wrong password"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	req.RemoteAddr = "192.0.2.1:1234"
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401, got %v", resp.Code)
+		return
+	}
+
+	statusCode, responseBody := makeRequestWithToken("GET", "/admin/lockouts", nil, accessToken.Token)
+	var response types.GetLockoutsResponse
+	if err := json.Unmarshal([]byte(responseBody), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting lockouts: %v %v", statusCode, responseBody)
+		return
+	}
+
+	// Without trusted proxies the failure counts against the address the request came from
+	recorded := false
+	for _, lockout := range response.Lockouts {
+		if lockout.Key == "ip:203.0.113.7" {
+			t.Errorf("Expected the forwarded address to be ignored, got %v", response.Lockouts)
+			return
+		}
+		recorded = recorded || lockout.Key == "ip:192.0.2.1"
+	}
+	if !recorded {
+		t.Errorf("Expected the failure to count against ip:192.0.2.1, got %v", response.Lockouts)
+	}
+}
+
+func TestLoginLockoutWithConcurrentAttempts(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	databaseLimiter := limiter.NewDatabaseLoginLimiter()
+	previousLimiter := limiter.GetLoginLimiter
+	limiter.GetLoginLimiter = func() limiter.LoginLimiterInterface { return databaseLimiter }
+	defer func() { limiter.GetLoginLimiter = previousLimiter }()
+
+	admin, err := models.SetAdminPassword("test_admin_for_concurrent_lockout", "correct password")
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+	defer clearLockouts(accessToken.Token)
+
+	const attempts = 12
+	statusCodes := make([]int, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statusCodes[i], _ = makeRequest("POST", "/auth/login", types.LoginRequest{Username: admin.Username, Password: "wrong password"})
+		}(i)
+	}
+	wg.Wait()
+
+	unauthorized := 0
+	for _, statusCode := range statusCodes {
+		switch statusCode {
+		case http.StatusUnauthorized:
+			unauthorized++
+		case http.StatusTooManyRequests:
+		default:
+			t.Errorf("Expected status code 401 or 429, got %v", statusCode)
+			return
+		}
+	}
+	if unauthorized != 5 {
+		t.Errorf("Expected exactly 5 passwords to be checked, got %v", unauthorized)
+		return
+	}
+
+	lockout, err := models.GetLoginLockout("username:" + admin.Username)
+	if err != nil || lockout == nil || lockout.Failures != 5 || lockout.LockedUntil == nil {
+		t.Errorf("Expected the username to be locked out after 5 failures, got %v %v", lockout, err)
+	}
+}
+
+func TestClearLockoutWithoutKey(t *testing.T) {
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("DELETE", "/admin/lockouts", nil, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+	}
+}
+
+func TestGetLockoutsAsPlayer(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("GET", "/admin/lockouts", nil, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetAudioMessages(c *gin.Context) {
+	messages, err := models.GetAudioMessages()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.GetMessagesResponse{
+		Messages: messages,
+	})
+	return
+}
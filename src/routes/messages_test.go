@@ -0,0 +1,201 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createAudioChallenge(maxDuration uint) (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "voice_message",
+		Description: "record a message for the couple",
+		Points:      20,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.UploadAudioChallenge,
+		MaxDuration: maxDuration,
+	})
+}
+
+func createAudioUpload(userID uint, url string, durationSeconds float64) error {
+	upload := models.Upload{
+		UserID:          userID,
+		StorageKey:      url,
+		Url:             url,
+		Size:            1,
+		ContentHash:     "hash",
+		MediaType:       types.AudioMedia,
+		DurationSeconds: durationSeconds,
+	}
+	_, err := upload.Save()
+	return err
+}
+
+func TestUploadAudio(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithFile("POST", "/upload/audio", "audio", "../_tests/assets/test_upload_audio.m4a", accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.UploadAudioResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Url == "" || response.DurationSeconds != 5 {
+		t.Errorf("Expected the url of a 5 second audio message, got %v", body)
+	}
+}
+
+func TestUploadAudioWithVideo(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithFile("POST", "/upload/audio", "audio", "../_tests/assets/test_upload_video.mp4", accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"file must be an m4a, mp3, ogg or webm audio message\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestAudioMessageIsListedForAdmins(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	challenge, err := createAudioChallenge(60)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := createAudioUpload(user.ID, "https://example.com/message.m4a", 42); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	if statusCode, body := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/message.m4a"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	submission, err := models.GetSubmission(user.ID, challenge.ID)
+	if err != nil || submission == nil || submission.State != types.ApprovedSubmission {
+		t.Errorf("Expected an approved submission, got %v %v", submission, err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/admin/messages", nil, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetMessagesResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Messages) != 1 {
+		t.Errorf("Expected 1 message, got %v", body)
+		return
+	}
+	message := response.Messages[0]
+	if message.Url != "https://example.com/message.m4a" || message.DurationSeconds != 42 ||
+		message.ChallengeId != challenge.ID || message.Username != user.Username {
+		t.Errorf("Unexpected message: %v", message)
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/gallery", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var gallery types.GalleryResponse
+	if err := json.Unmarshal([]byte(body), &gallery); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+	if len(gallery.Images) != 0 {
+		t.Errorf("Expected audio messages to stay out of the gallery, got %v", body)
+	}
+}
+
+func TestGetAudioMessagesAsPlayer(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("GET", "/admin/messages", nil, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestVerifyAudioTooLong(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createAudioChallenge(30)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := createAudioUpload(user.ID, "https://example.com/long.m4a", 95); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	statusCode, body := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/long.m4a")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"audio message is longer than the maximum duration of the challenge\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
@@ -0,0 +1,155 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createOrderingChallenge(partialCredit bool) (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "milestones",
+		Description: "Put our milestones in order",
+		Points:      40,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.OrderingChallenge,
+		Items: []types.ChallengeItemInput{
+			{Value: "first date"}, {Value: "moved in"}, {Value: "engagement"}, {Value: "wedding"},
+		},
+		PartialCredit: partialCredit,
+	})
+}
+
+func getOrderingItemIds(challengeID uint) []string {
+	items, _ := models.GetItemsForChallenge(challengeID)
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = strconv.Itoa(int(item.ID))
+	}
+	return ids
+}
+
+func TestGetOrderingChallengeHidesPositions(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createOrderingChallenge(false)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+	if strings.Contains(body, "position") {
+		t.Errorf("Expected the items without their positions, got %v", body)
+		return
+	}
+
+	var response types.GetChallengeResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+	if len(response.Items) != 4 {
+		t.Errorf("Expected 4 items, got %v", response.Items)
+		return
+	}
+
+	_, againBody := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+	if againBody != body {
+		t.Errorf("Expected the same order for the same player, got %v and %v", body, againBody)
+	}
+}
+
+func TestVerifyOrderingChallenge(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createOrderingChallenge(false)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	ids := getOrderingItemIds(challenge.ID)
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/verify"
+	wrongOrder := strings.Join([]string{ids[1], ids[0], ids[2], ids[3]}, ",")
+	statusCode, body := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: wrongOrder}, accessToken.Token)
+	if statusCode != http.StatusOK || !strings.Contains(body, `"correct": false`) {
+		t.Errorf("Expected a wrong order to be rejected, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: ids[0] + "," + ids[1]}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 for an incomplete order, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: strings.Join(ids, ",")}, accessToken.Token)
+	if statusCode != http.StatusOK || !strings.Contains(body, `"correct": true`) {
+		t.Errorf("Expected the correct order to be accepted, got %v %v", statusCode, body)
+	}
+}
+
+func TestVerifyOrderingChallengeWithPartialCredit(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createOrderingChallenge(true)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	ids := getOrderingItemIds(challenge.ID)
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/verify"
+	partlyCorrect := strings.Join([]string{ids[0], ids[1], ids[3], ids[2]}, ",")
+	statusCode, body := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: partlyCorrect}, accessToken.Token)
+	if statusCode != http.StatusOK || !strings.Contains(body, `"correct": true`) {
+		t.Errorf("Expected a partly correct order to be accepted, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/leaderboard", nil, accessToken.Token)
+	var leaderboard types.GetLeaderboardResponse
+	if err := json.Unmarshal([]byte(body), &leaderboard); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting leaderboard: %v %v", statusCode, err)
+		return
+	}
+	if len(leaderboard.Leaderboard) != 1 || leaderboard.Leaderboard[0].Points != 20 {
+		t.Errorf("Expected half of the points for two of four items in place, got %v", body)
+	}
+}
@@ -3,7 +3,9 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"the-wedding-game-api/config"
 	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
 	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
 )
@@ -39,3 +41,83 @@ func GetLeaderboard(c *gin.Context) {
 	})
 	return
 }
+
+func GetPointsLedger(c *gin.Context) {
+	userId, err := validators.ValidateGetPointsLedgerRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := models.GetUserByID(userId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	entries, err := models.GetPointsLedger(user.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	points, err := user.GetPoints()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetPointsLedgerResponse{
+		UserId:  user.ID,
+		Points:  points,
+		Entries: make([]types.PointsEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Entries[i] = getPointsEntryResponse(entry)
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func CreatePointsAdjustment(c *gin.Context) {
+	userId, createPointsAdjustmentRequest, err := validators.ValidateCreatePointsAdjustmentRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	admin, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := models.GetUserByID(userId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	entry, err := models.NewPointsAdjustment(user.ID, admin.ID, createPointsAdjustmentRequest).Save()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, getPointsEntryResponse(entry))
+	return
+}
+
+func getPointsEntryResponse(entry models.PointsEntry) types.PointsEntryResponse {
+	return types.PointsEntryResponse{
+		Id:           entry.ID,
+		Type:         entry.Type,
+		Points:       entry.Points,
+		Reason:       entry.Reason,
+		ChallengeId:  entry.ChallengeID,
+		SubmissionId: entry.SubmissionID,
+		ActorId:      entry.ActorID,
+		CreatedAt:    entry.CreatedAt.In(config.GetEventLocation()),
+	}
+}
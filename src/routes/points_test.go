@@ -3,8 +3,12 @@ package routes
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
+	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
 )
 
@@ -413,3 +417,288 @@ func TestGetLeaderboardInvalidAccessToken(t *testing.T) {
 		t.Errorf("Expected body: %v, got: %v", expectedBody, body)
 	}
 }
+
+func TestGetLeaderboardWithFirstSolverBonus(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "bonus_challenge",
+		Description: "bonus_description",
+		Points:      100,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.UploadPhotoChallenge,
+		Scoring:     types.ScoringRules{FirstSolverBonus: 50, FirstSolverCount: 1},
+	})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	user1, _, err1 := createUserAndGetAccessToken()
+	user2, accessToken, err2 := createUserAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	err1 = completeChallenge(challenge.ID, user1.ID)
+	err2 = completeChallenge(challenge.ID, user2.ID)
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error completing challenges")
+		return
+	}
+
+	// Changing the rules afterwards does not change the points already awarded
+	_, err = challenge.Update(types.UpdateChallengeRequest{
+		Name:        challenge.Name,
+		Description: challenge.Description,
+		Points:      10,
+		Image:       challenge.Image,
+		Status:      challenge.Status,
+		Type:        challenge.Type,
+		Scoring:     &types.ScoringRules{},
+	})
+	if err != nil {
+		t.Errorf("Error updating challenge: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/leaderboard", nil, accessToken.Token)
+	if statusCode != 200 {
+		t.Errorf("Invalid status code: %v", statusCode)
+	}
+
+	var response types.GetLeaderboardResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedResponse := types.GetLeaderboardResponse{
+		Leaderboard: []types.LeaderboardEntry{
+			{Username: user1.Username, Points: 150},
+			{Username: user2.Username, Points: 100},
+		},
+	}
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("Expected response: %v, got: %v", expectedResponse, response)
+	}
+}
+
+func TestFirstSolverBonusWithConcurrentSolvers(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "bonus_challenge",
+		Description: "bonus_description",
+		Points:      100,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.AnswerQuestionChallenge,
+		Answer:      "answer",
+		Scoring:     types.ScoringRules{FirstSolverBonus: 50, FirstSolverCount: 1},
+	})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	users := make([]models.User, 5)
+	for i := range users {
+		if users[i], _, err = createUserAndGetAccessToken(); err != nil {
+			t.Errorf("Error creating user: %v", err)
+			return
+		}
+	}
+
+	var wg sync.WaitGroup
+	points := make([]uint, len(users))
+	errs := make([]error, len(users))
+	for i, user := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			submission, err := models.CompleteChallenge(user.ID, challenge, "answer")
+			points[i], errs[i] = submission.Points, err
+		}()
+	}
+	wg.Wait()
+
+	firstSolvers := 0
+	for i := range users {
+		if errs[i] != nil {
+			t.Errorf("Error completing challenge: %v", errs[i])
+			return
+		}
+		if points[i] == 150 {
+			firstSolvers++
+		}
+	}
+	if firstSolvers != 1 {
+		t.Errorf("Expected exactly one first solver bonus, got %v", points)
+	}
+}
+
+func TestCreatePointsAdjustment(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err1 := createUserAndGetAccessToken()
+	admin, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenge, err := createChallengeWithPoints(100)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	if err := completeChallenge(challenge.ID, user.ID); err != nil {
+		t.Errorf("Error completing challenge: %v", err)
+		return
+	}
+
+	path := "/users/" + strconv.Itoa(int(user.ID)) + "/points"
+	statusCode, body := makeRequestWithToken("POST", path, types.CreatePointsAdjustmentRequest{
+		Type:   types.BonusPointsEntry,
+		Points: 50,
+		Reason: "best dancer",
+	}, adminToken.Token)
+	var entry types.PointsEntryResponse
+	if err := json.Unmarshal([]byte(body), &entry); err != nil || statusCode != http.StatusCreated {
+		t.Errorf("Error creating bonus: %v %v", statusCode, body)
+		return
+	}
+	if entry.Points != 50 || entry.Type != types.BonusPointsEntry || entry.ActorId == nil || *entry.ActorId != admin.ID {
+		t.Errorf("Expected a bonus of 50 points by the admin, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, types.CreatePointsAdjustmentRequest{
+		Type:   types.PenaltyPointsEntry,
+		Points: 30,
+		Reason: "ate the cake",
+	}, adminToken.Token)
+	if statusCode != http.StatusCreated {
+		t.Errorf("Error creating penalty: %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/points/me", nil, accessToken.Token)
+	var points types.CurrentUserPointsResponse
+	if err := json.Unmarshal([]byte(body), &points); err != nil || statusCode != http.StatusOK || points.Points != 120 {
+		t.Errorf("Expected 120 points, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/leaderboard", nil, accessToken.Token)
+	var leaderboard types.GetLeaderboardResponse
+	if err := json.Unmarshal([]byte(body), &leaderboard); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting leaderboard: %v %v", statusCode, body)
+		return
+	}
+	if len(leaderboard.Leaderboard) != 1 || leaderboard.Leaderboard[0].Points != 120 {
+		t.Errorf("Expected the adjustments on the leaderboard, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", path, nil, adminToken.Token)
+	var ledger types.GetPointsLedgerResponse
+	if err := json.Unmarshal([]byte(body), &ledger); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting ledger: %v %v", statusCode, body)
+		return
+	}
+	if ledger.Points != 120 || len(ledger.Entries) != 3 {
+		t.Errorf("Expected 3 entries adding up to 120 points, got %v", body)
+		return
+	}
+	if ledger.Entries[0].Type != types.SubmissionPointsEntry || ledger.Entries[0].Points != 100 || *ledger.Entries[0].ChallengeId != challenge.ID ||
+		ledger.Entries[1].Reason != "best dancer" || ledger.Entries[2].Points != -30 {
+		t.Errorf("Expected the submission, the bonus and the penalty, got %v", body)
+	}
+}
+
+func TestPointsLedgerAfterRejection(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, _, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	if err := completeChallenge(challenge.ID, user.ID); err != nil {
+		t.Errorf("Error completing challenge: %v", err)
+		return
+	}
+
+	submission, err := models.GetSubmission(user.ID, challenge.ID)
+	if err != nil || submission == nil {
+		t.Errorf("Error getting submission: %v", err)
+		return
+	}
+
+	path := "/admin/submissions/" + strconv.Itoa(int(submission.ID)) + "/reject"
+	if statusCode, body := makeRequestWithToken("POST", path, types.RejectSubmissionRequest{Reason: "blurry"}, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Error rejecting submission: %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/users/"+strconv.Itoa(int(user.ID))+"/points", nil, adminToken.Token)
+	var ledger types.GetPointsLedgerResponse
+	if err := json.Unmarshal([]byte(body), &ledger); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting ledger: %v %v", statusCode, body)
+		return
+	}
+	if ledger.Points != 0 || len(ledger.Entries) != 2 || ledger.Entries[1].Type != types.ReversalPointsEntry || ledger.Entries[1].Points != -100 {
+		t.Errorf("Expected the approval to be reversed, got %v", body)
+	}
+}
+
+func TestCreatePointsAdjustmentAsUser(t *testing.T) {
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("POST", "/users/"+strconv.Itoa(int(user.ID))+"/points", types.CreatePointsAdjustmentRequest{
+		Type:   types.BonusPointsEntry,
+		Points: 1000,
+		Reason: "self promotion",
+	}, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v %v", statusCode, body)
+	}
+}
+
+func TestGetPointsLedgerUnknownUser(t *testing.T) {
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/users/999999/points", nil, adminToken.Token)
+	if statusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404, got %v %v", statusCode, body)
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func ResolvePrediction(c *gin.Context) {
+	id, resolvePredictionRequest, err := validators.ValidateResolvePredictionRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	resolved, results, err := challenge.Resolve(resolvePredictionRequest.Outcome)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.ResolvePredictionResponse{
+		ChallengeId: resolved.ID,
+		Outcome:     resolved.Outcome,
+		ResolvedAt:  *inEventTimezone(resolved.ResolvedAt),
+		Results:     results,
+	})
+	return
+}
@@ -0,0 +1,237 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createPredictionChallenge(rules types.PredictionRules) (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "speech_prediction",
+		Description: "How long will the best-man speech last?",
+		Points:      40,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.PredictionChallenge,
+		Prediction:  rules,
+	})
+}
+
+func predict(challengeID uint, accessToken string, guess string) (int, string) {
+	path := "/challenges/" + strconv.Itoa(int(challengeID)) + "/verify"
+	return makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: guess}, accessToken)
+}
+
+func resolvePrediction(challengeID uint, accessToken string, outcome string) (int, string) {
+	path := "/challenges/" + strconv.Itoa(int(challengeID)) + "/resolve"
+	return makeRequestWithToken("POST", path, types.ResolvePredictionRequest{Outcome: outcome}, accessToken)
+}
+
+func TestResolvePrediction(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createPredictionChallenge(types.PredictionRules{Mode: types.ClosestPrediction, Closest: 1})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	user1, accessToken1, err1 := createUserAndGetAccessToken()
+	_, accessToken2, err2 := createUserAndGetAccessToken()
+	_, adminToken, err3 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil || err3 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	if statusCode, body := predict(challenge.ID, accessToken1.Token, "12"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+	if statusCode, body := predict(challenge.ID, accessToken2.Token, "20"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/leaderboard", nil, accessToken1.Token)
+	var leaderboard types.GetLeaderboardResponse
+	if err := json.Unmarshal([]byte(body), &leaderboard); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting leaderboard: %v %v", statusCode, err)
+		return
+	}
+	if len(leaderboard.Leaderboard) != 0 {
+		t.Errorf("Expected an empty leaderboard before the outcome is posted, got %v", body)
+		return
+	}
+
+	statusCode, body = resolvePrediction(challenge.ID, adminToken.Token, "11")
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	var response types.ResolvePredictionResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+	if response.Outcome != "11" || len(response.Results) != 2 {
+		t.Errorf("Unexpected response: %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/leaderboard", nil, accessToken1.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	leaderboard = types.GetLeaderboardResponse{}
+	if err := json.Unmarshal([]byte(body), &leaderboard); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expected := []types.LeaderboardEntry{{Username: user1.Username, Points: 40}}
+	if len(leaderboard.Leaderboard) == 0 || !reflect.DeepEqual(leaderboard.Leaderboard[:1], expected) {
+		t.Errorf("Expected the closest guess to lead the leaderboard, got %v", body)
+	}
+
+	statusCode, body = predict(challenge.ID, accessToken2.Token, "11")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 after the outcome was posted, got %v", statusCode)
+		return
+	}
+	if body != "{\n    \"message\": \"the outcome of this prediction was already posted\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestResolvePredictionWrongGuessDoesNotUnlockChallenges(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	prediction, err := createPredictionChallenge(types.PredictionRules{Mode: types.ExactPrediction})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	dependent, err := createAnswerQuestionChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	if _, err := models.NewAnswer(dependent.ID, "first dance").Save(); err != nil {
+		t.Errorf("Error creating answer: %v", err)
+		return
+	}
+
+	_, accessToken1, err1 := createUserAndGetAccessToken()
+	_, accessToken2, err2 := createUserAndGetAccessToken()
+	_, adminToken, err3 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil || err3 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	dependentPath := "/challenges/" + strconv.Itoa(int(dependent.ID))
+	statusCode, body := makeRequestWithToken("PUT", dependentPath, types.UpdateChallengeRequest{
+		Name:          dependent.Name,
+		Description:   dependent.Description,
+		Points:        dependent.Points,
+		Image:         "https://example.com/image.jpg",
+		Type:          dependent.Type,
+		Status:        dependent.Status,
+		Answers:       []string{"first dance"},
+		Prerequisites: []uint{prediction.ID},
+	}, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Error adding prerequisite: %v %v", statusCode, body)
+		return
+	}
+
+	if statusCode, body := predict(prediction.ID, accessToken1.Token, "rain"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+	if statusCode, body := predict(prediction.ID, accessToken2.Token, "sunshine"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+	if statusCode, body := resolvePrediction(prediction.ID, adminToken.Token, "rain"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	verifyPath := dependentPath + "/verify"
+	statusCode, body = makeRequestWithToken("POST", verifyPath, types.VerifyAnswerRequest{Answer: "first dance"}, accessToken2.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 after a wrong guess, got %v %v", statusCode, body)
+	}
+
+	statusCode, body = makeRequestWithToken("POST", verifyPath, types.VerifyAnswerRequest{Answer: "first dance"}, accessToken1.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200 after a winning guess, got %v %v", statusCode, body)
+	}
+}
+
+func TestResolvePredictionAsPlayer(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createPredictionChallenge(types.PredictionRules{Mode: types.ExactPrediction})
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, _ := resolvePrediction(challenge.ID, accessToken.Token, "11")
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestResolveNonPredictionChallenge(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	challenge, err := createAnswerQuestionChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, body := resolvePrediction(challenge.ID, adminToken.Token, "11")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+	if body != "{\n    \"message\": \"only prediction challenges can be resolved\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
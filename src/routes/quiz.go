@@ -0,0 +1,181 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/config"
+	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+	"time"
+)
+
+func GetQuizRounds(c *gin.Context) {
+	rounds, err := models.GetQuizRounds()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetQuizRoundsResponse{
+		Rounds: make([]types.QuizRoundResponse, len(rounds)),
+	}
+	for i, round := range rounds {
+		_, questions, err := models.GetQuizRound(round.ID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		response.Rounds[i] = getQuizRoundResponse(round, questions)
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func CreateQuizRound(c *gin.Context) {
+	createQuizRoundRequest, err := validators.ValidateCreateQuizRoundRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	round, questions, err := models.CreateQuizRound(createQuizRoundRequest)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, getQuizRoundResponse(round, questions))
+	return
+}
+
+func StartQuizRound(c *gin.Context) {
+	roundId, err := validators.ValidateQuizRoundIdRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	round, questions, err := models.StartQuizRound(roundId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, getQuizRoundResponse(round, questions))
+	return
+}
+
+func GetCurrentQuizQuestion(c *gin.Context) {
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	current, err := models.GetCurrentQuizQuestion()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	challenge, err := models.GetChallengeByID(current.Question.ChallengeID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	options, err := getOptionsForChallenge(challenge)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	answered, err := models.IsQuizQuestionAnswered(user.ID, challenge.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.CurrentQuizQuestionResponse{
+		RoundId:     current.Round.ID,
+		RoundName:   current.Round.Name,
+		Number:      current.Question.Position + 1,
+		Total:       current.Total,
+		ChallengeId: challenge.ID,
+		Name:        challenge.Name,
+		Description: challenge.Description,
+		Image:       challenge.Image,
+		Type:        challenge.Type,
+		Points:      challenge.Points,
+		Options:     options,
+		OpensAt:     current.OpensAt.In(config.GetEventLocation()),
+		ClosesAt:    current.ClosesAt.In(config.GetEventLocation()),
+		Answered:    answered,
+	})
+	return
+}
+
+func AnswerQuizQuestion(c *gin.Context) {
+	answerQuizQuestionRequest, err := validators.ValidateAnswerQuizQuestionRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	correct, points, err := models.AnswerQuizQuestion(user.ID, answerQuizQuestionRequest.ChallengeId, answerQuizQuestionRequest.Answer)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.AnswerQuizQuestionResponse{
+		Correct: correct,
+		Points:  points,
+	})
+	return
+}
+
+func GetQuizResults(c *gin.Context) {
+	roundId, err := validators.ValidateQuizRoundIdRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	results, err := models.GetQuizResults(roundId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.GetQuizResultsResponse{
+		RoundId: roundId,
+		Results: results,
+	})
+	return
+}
+
+func getQuizRoundResponse(round models.QuizRound, questions []models.QuizQuestion) types.QuizRoundResponse {
+	response := types.QuizRoundResponse{
+		Id:              round.ID,
+		Name:            round.Name,
+		QuestionSeconds: round.QuestionSeconds,
+		Challenges:      make([]uint, len(questions)),
+		Status:          round.GetStatus(len(questions), time.Now()),
+		StartedAt:       inEventTimezone(round.StartedAt),
+		EndsAt:          inEventTimezone(round.EndsAt(len(questions))),
+	}
+	for i, question := range questions {
+		response.Challenges[i] = question.ChallengeID
+	}
+	return response
+}
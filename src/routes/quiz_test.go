@@ -0,0 +1,334 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+// createQuizRound creates two answer question challenges answered by "answer" and a round asking them
+func createQuizRound(adminToken string) ([]models.Challenge, int, string) {
+	challenges := make([]models.Challenge, 2)
+	challengeIds := make([]uint, 2)
+	for i := range challenges {
+		challenge, err := createAnswerQuestionChallenge()
+		if err != nil {
+			return nil, 0, err.Error()
+		}
+		if _, err := models.NewAnswer(challenge.ID, "answer").Save(); err != nil {
+			return nil, 0, err.Error()
+		}
+		challenges[i] = challenge
+		challengeIds[i] = challenge.ID
+	}
+
+	statusCode, body := makeRequestWithToken("POST", "/admin/quiz/rounds", types.CreateQuizRoundRequest{
+		Name:            "Round 1",
+		QuestionSeconds: 60,
+		Challenges:      challengeIds,
+	}, adminToken)
+	return challenges, statusCode, body
+}
+
+func TestQuizRound(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenges, statusCode, body := createQuizRound(adminToken.Token)
+	var round types.QuizRoundResponse
+	if err := json.Unmarshal([]byte(body), &round); err != nil || statusCode != http.StatusCreated {
+		t.Errorf("Error creating quiz round: %v %v", statusCode, body)
+		return
+	}
+	if round.Status != types.PendingQuizRound || len(round.Challenges) != 2 {
+		t.Errorf("Expected a pending round of 2 questions, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/quiz/current", nil, accessToken.Token)
+	if statusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404 before the round starts, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/challenges/"+strconv.Itoa(int(challenges[0].ID))+"/verify",
+		types.VerifyAnswerRequest{Answer: "answer"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 when answering a quiz challenge outside the round, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/admin/quiz/rounds/"+strconv.Itoa(int(round.Id))+"/start", nil, adminToken.Token)
+	if err := json.Unmarshal([]byte(body), &round); err != nil || statusCode != http.StatusOK || round.Status != types.RunningQuizRound {
+		t.Errorf("Error starting quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/quiz/current", nil, accessToken.Token)
+	var question types.CurrentQuizQuestionResponse
+	if err := json.Unmarshal([]byte(body), &question); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting current question: %v %v", statusCode, body)
+		return
+	}
+	if question.ChallengeId != challenges[0].ID || question.Number != 1 || question.Total != 2 || question.Answered {
+		t.Errorf("Expected the first question to be open, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/quiz/current/answer", types.AnswerQuizQuestionRequest{
+		ChallengeId: challenges[1].ID,
+		Answer:      "answer",
+	}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 when answering a question that is not open, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/quiz/current/answer", types.AnswerQuizQuestionRequest{
+		ChallengeId: challenges[0].ID,
+		Answer:      "answer",
+	}, accessToken.Token)
+	var answer types.AnswerQuizQuestionResponse
+	if err := json.Unmarshal([]byte(body), &answer); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error answering question: %v %v", statusCode, body)
+		return
+	}
+	if !answer.Correct || answer.Points < 90 || answer.Points > 100 {
+		t.Errorf("Expected a fast correct answer to score close to 100 points, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/quiz/current/answer", types.AnswerQuizQuestionRequest{
+		ChallengeId: challenges[0].ID,
+		Answer:      "answer",
+	}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400 when answering twice, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/quiz/rounds/"+strconv.Itoa(int(round.Id))+"/results", nil, accessToken.Token)
+	var results types.GetQuizResultsResponse
+	if err := json.Unmarshal([]byte(body), &results); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting quiz results: %v %v", statusCode, body)
+		return
+	}
+	if len(results.Results) != 1 || results.Results[0].Points != answer.Points || results.Results[0].CorrectAnswers != 1 {
+		t.Errorf("Expected one player with the points of the answer, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/points/me", nil, accessToken.Token)
+	var points types.CurrentUserPointsResponse
+	if err := json.Unmarshal([]byte(body), &points); err != nil || statusCode != http.StatusOK || points.Points != answer.Points {
+		t.Errorf("Expected the quiz points to count for the player, got %v %v", statusCode, body)
+	}
+}
+
+func TestStartQuizRoundTwice(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	_, statusCode, body := createQuizRound(adminToken.Token)
+	var round types.QuizRoundResponse
+	if err := json.Unmarshal([]byte(body), &round); err != nil || statusCode != http.StatusCreated {
+		t.Errorf("Error creating quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	path := "/admin/quiz/rounds/" + strconv.Itoa(int(round.Id)) + "/start"
+	if statusCode, body = makeRequestWithToken("POST", path, nil, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Error starting quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, nil, adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v %v", statusCode, body)
+	}
+}
+
+func TestDeleteChallengeInQuizRound(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin: %v", err)
+		return
+	}
+
+	challenges, statusCode, body := createQuizRound(adminToken.Token)
+	if statusCode != http.StatusCreated {
+		t.Errorf("Error creating quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenges[0].ID))
+	if statusCode, body := makeRequestWithToken("DELETE", path, nil, adminToken.Token); statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v %v", statusCode, body)
+		return
+	}
+
+	if _, err := models.GetChallengeByID(challenges[0].ID); err != nil {
+		t.Errorf("Expected the challenge to still exist, got %v", err)
+	}
+}
+
+func TestCreateQuizRoundAsUser(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user: %v", err)
+		return
+	}
+
+	_, statusCode, body := createQuizRound(accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v %v", statusCode, body)
+	}
+}
+
+func TestQuizChallengesHiddenFromPlayers(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenges, statusCode, body := createQuizRound(adminToken.Token)
+	if statusCode != http.StatusCreated {
+		t.Errorf("Error creating quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/challenges", nil, accessToken.Token)
+	var response types.GetChallengesResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting challenges: %v %v", statusCode, body)
+		return
+	}
+	if len(response.Challenges) != 0 {
+		t.Errorf("Expected the quiz challenges to be hidden from players, got %v", body)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenges[0].ID))
+	statusCode, body = makeRequestWithToken("GET", path, nil, accessToken.Token)
+	if statusCode != http.StatusNotFound {
+		t.Errorf("Expected status code 404, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/challenges", nil, adminToken.Token)
+	if err := json.Unmarshal([]byte(body), &response); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting challenges: %v %v", statusCode, body)
+		return
+	}
+	if len(response.Challenges) != 2 {
+		t.Errorf("Expected the admin to see the quiz challenges, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", path, nil, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+	}
+}
+
+func TestAnswerQuizQuestionConcurrently(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err1 := createUserAndGetAccessToken()
+	_, adminToken, err2 := createAdminAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	challenges, statusCode, body := createQuizRound(adminToken.Token)
+	var round types.QuizRoundResponse
+	if err := json.Unmarshal([]byte(body), &round); err != nil || statusCode != http.StatusCreated {
+		t.Errorf("Error creating quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	path := "/admin/quiz/rounds/" + strconv.Itoa(int(round.Id)) + "/start"
+	if statusCode, body = makeRequestWithToken("POST", path, nil, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Error starting quiz round: %v %v", statusCode, body)
+		return
+	}
+
+	// A wrong guess and several right ones sent at once, only one of them may count
+	answers := []string{"wrong", "answer", "answer", "answer"}
+	statusCodes := make([]int, len(answers))
+	var wg sync.WaitGroup
+	for i, answer := range answers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statusCodes[i], _ = makeRequestWithToken("POST", "/quiz/current/answer", types.AnswerQuizQuestionRequest{
+				ChallengeId: challenges[0].ID,
+				Answer:      answer,
+			}, accessToken.Token)
+		}()
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, statusCode := range statusCodes {
+		if statusCode == http.StatusOK {
+			accepted++
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("Expected exactly one answer to be accepted, got %v", statusCodes)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenges[0].ID))+"/attempts", nil, adminToken.Token)
+	var attempts types.GetAttemptsResponse
+	if err := json.Unmarshal([]byte(body), &attempts); err != nil || statusCode != http.StatusOK {
+		t.Errorf("Error getting attempts: %v %v", statusCode, body)
+		return
+	}
+	if len(attempts.Attempts) != 1 {
+		t.Errorf("Expected a single recorded answer, got %v", body)
+	}
+}
@@ -2,11 +2,17 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"log"
+	"the-wedding-game-api/config"
 	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/types"
 )
 
 func GetRouter() *gin.Engine {
 	router := gin.Default()
+	if err := router.SetTrustedProxies(config.GetTrustedProxies()); err != nil {
+		log.Println("Error setting trusted proxies: ", err)
+	}
 	router.Use(middleware.CORSMiddleware())
 	router.Use(middleware.ErrorHandler)
 
@@ -17,25 +23,72 @@ func GetRouter() *gin.Engine {
 	})
 
 	router.GET("/challenges/:id", middleware.IsLoggedIn, GetChallengeById)
-	router.POST("/challenges", middleware.IsAdmin, CreateChallenge)
+	router.POST("/challenges", middleware.RequirePermission(types.ManageChallengesPermission), CreateChallenge)
 	router.GET("/challenges", middleware.IsLoggedIn, GetAllChallenges)
 	router.POST("/challenges/:id/verify", middleware.IsLoggedIn, VerifyAnswer)
-	router.GET("/challenges/:id/submissions", middleware.IsLoggedIn, GetSubmissions)
-	router.PUT("/challenges/:id", middleware.IsAdmin, UpdateChallenge)
-	router.GET("/challenges/:id/answer", middleware.IsAdmin, GetAnswer)
-	router.DELETE("/challenges/:id", middleware.IsAdmin, DeleteChallenge)
+	router.GET("/challenges/:id/submissions", middleware.RequirePermission(types.ViewSubmissionsPermission), GetSubmissions)
+	router.GET("/challenges/:id/attempts", middleware.RequirePermission(types.ViewAnswersPermission), GetAttempts)
+	router.GET("/challenges/:id/qr.png", middleware.RequirePermission(types.ManageChallengesPermission), GetChallengeQRCode)
+	router.POST("/challenges/:id/resolve", middleware.RequirePermission(types.ManageScoresPermission), ResolvePrediction)
+	router.POST("/challenges/:id/hints/next", middleware.IsLoggedIn, RevealNextHint)
+	router.PUT("/challenges/:id", middleware.RequirePermission(types.ManageChallengesPermission), UpdateChallenge)
+	router.GET("/challenges/:id/answer", middleware.RequirePermission(types.ViewAnswersPermission), GetAnswer)
+	router.GET("/challenges/:id/answers", middleware.RequirePermission(types.ViewAnswersPermission), GetAnswers)
+	router.POST("/challenges/:id/answers", middleware.RequirePermission(types.ManageChallengesPermission), CreateAnswer)
+	router.PUT("/challenges/:id/answers/:answerId", middleware.RequirePermission(types.ManageChallengesPermission), UpdateAnswer)
+	router.DELETE("/challenges/:id/answers/:answerId", middleware.RequirePermission(types.ManageChallengesPermission), DeleteAnswer)
+	router.DELETE("/challenges/:id", middleware.RequirePermission(types.ManageChallengesPermission), DeleteChallenge)
 
 	router.POST("/auth/login", Login)
 	router.GET("/auth/current-user", GetCurrentUser)
+	router.POST("/auth/refresh", RefreshToken)
+	router.POST("/auth/logout", middleware.IsLoggedIn, Logout)
+	router.POST("/auth/change-password", middleware.IsLoggedIn, ChangePassword)
 
 	router.GET("/points/me", middleware.IsLoggedIn, GetCurrentUserPoints)
 	router.GET("/leaderboard", middleware.IsLoggedIn, GetLeaderboard)
+	router.GET("/leaderboard/teams", middleware.IsLoggedIn, GetTeamLeaderboard)
+
+	router.GET("/teams", middleware.RequirePermission(types.ManageTeamsPermission), GetTeams)
+	router.POST("/teams", middleware.RequirePermission(types.ManageTeamsPermission), CreateTeam)
+	router.POST("/teams/join", middleware.IsLoggedIn, JoinTeam)
+	router.PUT("/teams/:id", middleware.RequirePermission(types.ManageTeamsPermission), UpdateTeam)
+	router.DELETE("/teams/:id", middleware.RequirePermission(types.ManageTeamsPermission), DeleteTeam)
+	router.PUT("/users/:id/team", middleware.RequirePermission(types.ManageTeamsPermission), AssignUserTeam)
+	router.GET("/users/:id/points", middleware.RequirePermission(types.ManageScoresPermission), GetPointsLedger)
+	router.POST("/users/:id/points", middleware.RequirePermission(types.ManageScoresPermission), CreatePointsAdjustment)
 
 	router.GET("/gallery", middleware.IsLoggedIn, GetGallery)
 
+	router.GET("/bingo", middleware.IsLoggedIn, GetBingoCard)
+
+	router.GET("/quiz/current", middleware.IsLoggedIn, GetCurrentQuizQuestion)
+	router.POST("/quiz/current/answer", middleware.IsLoggedIn, AnswerQuizQuestion)
+	router.GET("/quiz/rounds/:id/results", middleware.IsLoggedIn, GetQuizResults)
+
 	router.POST("/upload", middleware.IsLoggedIn, HandleImageUpload)
+	router.POST("/upload/video", middleware.IsLoggedIn, HandleVideoUpload)
+	router.POST("/upload/audio", middleware.IsLoggedIn, HandleAudioUpload)
 
-	router.GET("/admin/challenges", middleware.IsAdmin, GetAllChallengesAdmin)
+	router.GET("/admin/challenges", middleware.RequirePermission(types.ManageChallengesPermission), GetAllChallengesAdmin)
+	router.GET("/admin/submissions", middleware.RequirePermission(types.ViewSubmissionsPermission), GetModerationQueue)
+	router.POST("/admin/submissions/:id/approve", middleware.RequirePermission(types.ModerateSubmissionsPermission), ApproveSubmission)
+	router.POST("/admin/submissions/:id/reject", middleware.RequirePermission(types.ModerateSubmissionsPermission), RejectSubmission)
+	router.GET("/admin/messages", middleware.RequirePermission(types.ViewSubmissionsPermission), GetAudioMessages)
+	router.GET("/admin/bingo", middleware.RequirePermission(types.ManageGamePermission), GetBingoBoard)
+	router.PUT("/admin/bingo", middleware.RequirePermission(types.ManageGamePermission), PublishBingoBoard)
+	router.DELETE("/admin/bingo", middleware.RequirePermission(types.ManageGamePermission), DeleteBingoBoard)
+	router.GET("/admin/quiz/rounds", middleware.RequirePermission(types.ManageGamePermission), GetQuizRounds)
+	router.POST("/admin/quiz/rounds", middleware.RequirePermission(types.ManageGamePermission), CreateQuizRound)
+	router.POST("/admin/quiz/rounds/:id/start", middleware.RequirePermission(types.ManageGamePermission), StartQuizRound)
+	router.GET("/admin/guests", middleware.RequirePermission(types.ManageUsersPermission), GetGuests)
+	router.POST("/admin/guests", middleware.RequirePermission(types.ManageUsersPermission), ImportGuests)
+	router.GET("/admin/registration", middleware.RequirePermission(types.ManageUsersPermission), GetRegistrationSettings)
+	router.PUT("/admin/registration", middleware.RequirePermission(types.ManageUsersPermission), UpdateRegistrationSettings)
+	router.GET("/admin/lockouts", middleware.RequirePermission(types.ManageUsersPermission), GetLockouts)
+	router.DELETE("/admin/lockouts", middleware.RequirePermission(types.ManageUsersPermission), ClearLockout)
+	router.GET("/admin/users", middleware.RequirePermission(types.ManageUsersPermission), GetUsers)
+	router.PUT("/admin/users/:id/role", middleware.RequirePermission(types.ManageUsersPermission), UpdateUserRole)
 
 	return router
 }
@@ -0,0 +1,163 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createScanCodeChallenge() (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "scan_challenge",
+		Description: "scan_description",
+		Points:      100,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.ScanCodeChallenge,
+	})
+}
+
+func TestVerifyAnswerWithScannedCode(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createScanCodeChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/verify"
+	statusCode, body := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: "WRONGCODE"}, accessToken.Token)
+	if statusCode != http.StatusOK || body != "{\n    \"correct\": false\n}" {
+		t.Errorf("Expected an incorrect answer, got %v %v", statusCode, body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: challenge.GetScanLink()}, accessToken.Token)
+	if statusCode != http.StatusOK || body != "{\n    \"correct\": true\n}" {
+		t.Errorf("Expected a correct answer, got %v %v", statusCode, body)
+	}
+}
+
+func TestGetChallengesAsAdminIncludesScanLink(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	challenge, err := createScanCodeChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/admin/challenges", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetChallengesAdminResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if len(response.Challenges) != 1 || response.Challenges[0].SecretCode != challenge.SecretCode ||
+		response.Challenges[0].ScanLink != challenge.GetScanLink() {
+		t.Errorf("Expected the secret code and scan link of the challenge, got %v", body)
+	}
+}
+
+func TestGetChallengeQRCode(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	challenge, err := createScanCodeChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/qr.png"
+	statusCode, body := makeRequestWithoutFile("GET", path, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	if !strings.HasPrefix(body, "\x89PNG") {
+		t.Errorf("Expected a png image")
+	}
+}
+
+func TestGetChallengeQRCodeAsPlayer(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createScanCodeChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/qr.png"
+	statusCode, _ := makeRequestWithoutFile("GET", path, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestGetChallengeQRCodeForOtherChallengeType(t *testing.T) {
+	models.ResetConnection()
+	deleteAllChallenges()
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	challenge, err := createAnswerQuestionChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID)) + "/qr.png"
+	statusCode, body := makeRequestWithoutFile("GET", path, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"qr codes are only available for scan code challenges\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
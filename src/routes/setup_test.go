@@ -66,13 +66,13 @@ func setupTestDb() {
 		if err == nil {
 			ready = true
 			log.Println("Database is ready!")
-			err := db.Migrator().DropTable(&models.User{}, &models.AccessToken{}, &models.Challenge{}, &models.Answer{}, &models.Submission{})
+			err := db.Migrator().DropTable(&models.User{}, &models.AccessToken{}, &models.Challenge{}, &models.Answer{}, &models.Submission{}, &models.ChallengeOption{}, &models.ChallengeItem{}, &models.ChallengePrerequisite{}, &models.ChallengeHint{}, &models.HintReveal{}, &models.Attempt{}, &models.Team{}, &models.Upload{}, &models.BingoBoard{}, &models.BingoCell{}, &models.QuizRound{}, &models.QuizQuestion{}, &models.PointsEntry{}, &models.Guest{}, &models.Setting{}, &models.LoginLockout{})
 			if err != nil {
 				panic(err)
 			}
 
 			log.Println("Migrating schema...")
-			err = db.AutoMigrate(&models.User{}, &models.AccessToken{}, &models.Challenge{}, &models.Answer{}, &models.Submission{})
+			err = db.AutoMigrate(&models.User{}, &models.AccessToken{}, &models.Challenge{}, &models.Answer{}, &models.Submission{}, &models.ChallengeOption{}, &models.ChallengeItem{}, &models.ChallengePrerequisite{}, &models.ChallengeHint{}, &models.HintReveal{}, &models.Attempt{}, &models.Team{}, &models.Upload{}, &models.BingoBoard{}, &models.BingoCell{}, &models.QuizRound{}, &models.QuizQuestion{}, &models.PointsEntry{}, &models.Guest{}, &models.Setting{}, &models.LoginLockout{})
 			if err != nil {
 				panic(err)
 				return
@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetModerationQueue(c *gin.Context) {
+	state, err := validators.ValidateGetModerationQueueRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	submissions, err := models.GetModerationQueue(state)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.GetModerationQueueResponse{
+		Submissions: submissions,
+	})
+	return
+}
+
+func ApproveSubmission(c *gin.Context) {
+	id, err := validators.ValidateApproveSubmissionRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	submission, err := models.GetSubmissionByID(id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	approved, err := submission.Approve()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.ModerateSubmissionResponse{
+		Id:    approved.ID,
+		State: approved.State,
+	})
+	return
+}
+
+func RejectSubmission(c *gin.Context) {
+	id, rejectSubmissionRequest, err := validators.ValidateRejectSubmissionRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	submission, err := models.GetSubmissionByID(id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rejected, err := submission.Reject(rejectSubmissionRequest.Reason)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.ModerateSubmissionResponse{
+		Id:              rejected.ID,
+		State:           rejected.State,
+		RejectionReason: rejected.RejectionReason,
+	})
+	return
+}
@@ -0,0 +1,257 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func uploadPhoto(challengeId uint, accessToken models.AccessToken, url string) int {
+	if err := createUpload(accessToken.UserID, url); err != nil {
+		return http.StatusInternalServerError
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challengeId)) + "/verify"
+	statusCode, _ := makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: url}, accessToken.Token)
+	return statusCode
+}
+
+func getModerationQueue(state string, accessToken string) (int, types.GetModerationQueueResponse) {
+	statusCode, body := makeRequestWithToken("GET", "/admin/submissions?state="+state, nil, accessToken)
+
+	var response types.GetModerationQueueResponse
+	_ = json.Unmarshal([]byte(body), &response)
+	return statusCode, response
+}
+
+func TestApprovePhotoSubmission(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if statusCode := uploadPhoto(challenge.ID, accessToken, "https://example.com/photo.jpg"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	points, err := user.GetPoints()
+	if err != nil || points != 0 {
+		t.Errorf("Expected no points before moderation, got %v %v", points, err)
+		return
+	}
+
+	statusCode, queue := getModerationQueue("pending", adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+	if len(queue.Submissions) != 1 || queue.Submissions[0].Answer != "https://example.com/photo.jpg" ||
+		queue.Submissions[0].Username != user.Username {
+		t.Errorf("Expected the uploaded photo in the queue, got %v", queue)
+		return
+	}
+
+	path := "/admin/submissions/" + strconv.Itoa(int(queue.Submissions[0].Id)) + "/approve"
+	statusCode, body := makeRequestWithToken("POST", path, nil, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+	if body != "{\n    \"id\": "+strconv.Itoa(int(queue.Submissions[0].Id))+",\n    \"state\": \"APPROVED\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+		return
+	}
+
+	points, err = user.GetPoints()
+	if err != nil || points != 100 {
+		t.Errorf("Expected 100 points after approval, got %v %v", points, err)
+		return
+	}
+
+	statusCode, queue = getModerationQueue("pending", adminToken.Token)
+	if statusCode != http.StatusOK || len(queue.Submissions) != 0 {
+		t.Errorf("Expected an empty queue, got %v %v", statusCode, queue)
+	}
+}
+
+func TestRejectPhotoSubmission(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if statusCode := uploadPhoto(challenge.ID, accessToken, "https://example.com/photo.jpg"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	submission, err := models.GetSubmission(user.ID, challenge.ID)
+	if err != nil || submission == nil {
+		t.Errorf("Expected a submission, got %v", err)
+		return
+	}
+
+	path := "/admin/submissions/" + strconv.Itoa(int(submission.ID)) + "/reject"
+	statusCode, _ := makeRequestWithToken("POST", path, types.RejectSubmissionRequest{Reason: "the cake is not visible"}, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/challenges/"+strconv.Itoa(int(challenge.ID)), nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetChallengeResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+	if response.Completed || response.SubmissionState != types.RejectedSubmission || response.RejectionReason != "the cake is not visible" {
+		t.Errorf("Expected the rejected state with its reason, got %v", body)
+		return
+	}
+
+	if statusCode := uploadPhoto(challenge.ID, accessToken, "https://example.com/cake.jpg"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	_, queue := getModerationQueue("pending", adminToken.Token)
+	if len(queue.Submissions) != 1 || queue.Submissions[0].Id != submission.ID || queue.Submissions[0].Answer != "https://example.com/cake.jpg" {
+		t.Errorf("Expected the new photo to be pending again, got %v", queue)
+	}
+}
+
+func TestRejectSubmissionWithoutReason(t *testing.T) {
+	models.ResetConnection()
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("POST", "/admin/submissions/1/reject", map[string]string{}, adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+	}
+}
+
+func TestModerateAnswerQuestionSubmission(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	user, _, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createAnswerQuestionChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := completeChallenge(challenge.ID, user.ID); err != nil {
+		t.Errorf("Error completing challenge: %v", err)
+		return
+	}
+
+	submission, err := models.GetSubmission(user.ID, challenge.ID)
+	if err != nil || submission == nil {
+		t.Errorf("Expected a submission, got %v", err)
+		return
+	}
+
+	path := "/admin/submissions/" + strconv.Itoa(int(submission.ID)) + "/reject"
+	statusCode, body := makeRequestWithToken("POST", path, types.RejectSubmissionRequest{Reason: "wrong"}, adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+	if body != "{\n    \"message\": \"only photo and video submissions can be moderated\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestGetModerationQueueAsPlayer(t *testing.T) {
+	models.ResetConnection()
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, _ := getModerationQueue("pending", accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestGetModerationQueueInvalidState(t *testing.T) {
+	models.ResetConnection()
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, _ := getModerationQueue("deleted", adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+	}
+}
@@ -0,0 +1,223 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetTeams(c *gin.Context) {
+	teams, err := models.GetAllTeams()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetTeamsResponse{
+		Teams: make([]types.TeamAdminResponse, 0, len(teams)),
+	}
+	for _, team := range teams {
+		teamResponse, err := getTeamAdminResponse(team)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		response.Teams = append(response.Teams, teamResponse)
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func CreateTeam(c *gin.Context) {
+	createTeamRequest, err := validators.ValidateCreateTeamRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	team, err := models.CreateNewTeam(createTeamRequest)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response, err := getTeamAdminResponse(team)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, response)
+	return
+}
+
+func UpdateTeam(c *gin.Context) {
+	teamId, updateTeamRequest, err := validators.ValidateUpdateTeamRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	team, err := models.GetTeamByID(teamId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	team, err = team.Update(updateTeamRequest)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response, err := getTeamAdminResponse(team)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func DeleteTeam(c *gin.Context) {
+	teamId, err := validators.ValidateDeleteTeamRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	team, err := models.GetTeamByID(teamId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if err := team.Delete(); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.DeleteTeamResponse{
+		Id: team.ID,
+	})
+	return
+}
+
+func JoinTeam(c *gin.Context) {
+	joinTeamRequest, err := validators.ValidateJoinTeamRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	team, err := models.GetTeamByJoinCode(joinTeamRequest.JoinCode)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err = user.SetTeam(&team.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.UserResponse{
+		Username: user.Username,
+		Role:     user.Role,
+		Team:     getTeamResponse(&team),
+	})
+	return
+}
+
+func AssignUserTeam(c *gin.Context) {
+	userId, assignTeamRequest, err := validators.ValidateAssignTeamRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := models.GetUserByID(userId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var team *models.Team
+	if assignTeamRequest.TeamId != nil {
+		existingTeam, err := models.GetTeamByID(*assignTeamRequest.TeamId)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		team = &existingTeam
+	}
+
+	user, err = user.SetTeam(assignTeamRequest.TeamId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.UserResponse{
+		Username: user.Username,
+		Role:     user.Role,
+		Team:     getTeamResponse(team),
+	})
+	return
+}
+
+func GetTeamLeaderboard(c *gin.Context) {
+	leaderboard, err := models.GetTeamLeaderboard()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, types.GetTeamLeaderboardResponse{
+		Leaderboard: leaderboard,
+	})
+	return
+}
+
+func getTeamResponse(team *models.Team) *types.TeamResponse {
+	if team == nil {
+		return nil
+	}
+	return &types.TeamResponse{
+		Id:   team.ID,
+		Name: team.Name,
+	}
+}
+
+func getTeamAdminResponse(team models.Team) (types.TeamAdminResponse, error) {
+	members, err := team.GetMembers()
+	if err != nil {
+		return types.TeamAdminResponse{}, err
+	}
+
+	response := types.TeamAdminResponse{
+		Id:       team.ID,
+		Name:     team.Name,
+		JoinCode: team.JoinCode,
+		Members:  make([]types.TeamMember, 0, len(members)),
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, types.TeamMember{
+			Id:       member.ID,
+			Username: member.Username,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,286 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createTeam(name string) (models.Team, error) {
+	return models.CreateNewTeam(types.CreateTeamRequest{Name: name})
+}
+
+func createTeamChallenge() (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "team_challenge",
+		Description: "team_description",
+		Points:      100,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.AnswerQuestionChallenge,
+		Answer:      "eiffel tower",
+		Team:        true,
+	})
+}
+
+func TestCreateTeam(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	request := types.CreateTeamRequest{Name: "Table 1", JoinCode: "table1"}
+	statusCode, body := makeRequestWithToken("POST", "/teams", request, accessToken.Token)
+	if statusCode != http.StatusCreated {
+		t.Errorf("Expected status code 201, got %v", statusCode)
+		return
+	}
+
+	var response types.TeamAdminResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedResponse := types.TeamAdminResponse{Id: response.Id, Name: "Table 1", JoinCode: "TABLE1", Members: []types.TeamMember{}}
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("Expected response %v, got %v", expectedResponse, response)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("POST", "/teams", request, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"team name is already taken\",\"status\":\"error\"}" {
+		t.Errorf("Expected team name taken error, got %v", body)
+	}
+}
+
+func TestCreateTeamAsPlayer(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("POST", "/teams", types.CreateTeamRequest{Name: "Table 1"}, accessToken.Token)
+	if statusCode != http.StatusForbidden {
+		t.Errorf("Expected status code 403, got %v", statusCode)
+	}
+}
+
+func TestJoinTeam(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	team, err := createTeam("Table 2")
+	if err != nil {
+		t.Errorf("Error creating team: %v", err)
+		return
+	}
+
+	statusCode, _ := makeRequestWithToken("POST", "/teams/join", types.JoinTeamRequest{JoinCode: team.JoinCode}, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/auth/current-user", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.UserResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedResponse := types.UserResponse{
+		Username: user.Username,
+		Role:     types.Player,
+		Team:     &types.TeamResponse{Id: team.ID, Name: "Table 2"},
+	}
+	if !reflect.DeepEqual(response, expectedResponse) {
+		t.Errorf("Expected response %v, got %v", expectedResponse, response)
+	}
+}
+
+func TestJoinTeamInvalidJoinCode(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("POST", "/teams/join", types.JoinTeamRequest{JoinCode: "NOPE"}, accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"invalid join code\",\"status\":\"error\"}" {
+		t.Errorf("Expected invalid join code error, got %v", body)
+	}
+}
+
+func TestAssignUserTeamAndDeleteTeam(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, _, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	_, adminAccessToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	team, err := createTeam("Table 3")
+	if err != nil {
+		t.Errorf("Error creating team: %v", err)
+		return
+	}
+
+	path := "/users/" + strconv.Itoa(int(user.ID)) + "/team"
+	statusCode, _ := makeRequestWithToken("PUT", path, types.AssignTeamRequest{TeamId: &team.ID}, adminAccessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/teams", nil, adminAccessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetTeamsResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedMembers := []types.TeamMember{{Id: user.ID, Username: user.Username}}
+	if len(response.Teams) != 1 || !reflect.DeepEqual(response.Teams[0].Members, expectedMembers) {
+		t.Errorf("Expected one team with members %v, got %v", expectedMembers, response.Teams)
+		return
+	}
+
+	statusCode, _ = makeRequestWithToken("DELETE", "/teams/"+strconv.Itoa(int(team.ID)), nil, adminAccessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	updatedUser, err := models.GetUserByID(user.ID)
+	if err != nil {
+		t.Errorf("Error getting user: %v", err)
+		return
+	}
+
+	if updatedUser.TeamID != nil {
+		t.Errorf("Expected user without team after deleting the team, got team %v", *updatedUser.TeamID)
+	}
+}
+
+func TestTeamChallengeCompletedForWholeTeam(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user1, accessToken1, err1 := createUserAndGetAccessToken()
+	user2, accessToken2, err2 := createUserAndGetAccessToken()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating users")
+		return
+	}
+
+	team, err := createTeam("Table 4")
+	if err != nil {
+		t.Errorf("Error creating team: %v", err)
+		return
+	}
+
+	_, err1 = user1.SetTeam(&team.ID)
+	_, err2 = user2.SetTeam(&team.ID)
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error joining team")
+		return
+	}
+
+	challenge, err := createTeamChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	path := "/challenges/" + strconv.Itoa(int(challenge.ID))
+	statusCode, _ := makeRequestWithToken("POST", path+"/verify", types.VerifyAnswerRequest{Answer: "eiffel tower"}, accessToken1.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", path, nil, accessToken2.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var challengeResponse types.GetChallengeResponse
+	if err := json.Unmarshal([]byte(body), &challengeResponse); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if !challengeResponse.Completed || !challengeResponse.Team {
+		t.Errorf("Expected the team challenge to be completed for the teammate, got %v", body)
+		return
+	}
+
+	statusCode, body = makeRequestWithToken("GET", "/leaderboard/teams", nil, accessToken2.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var leaderboardResponse types.GetTeamLeaderboardResponse
+	if err := json.Unmarshal([]byte(body), &leaderboardResponse); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expectedLeaderboard := []types.TeamLeaderboardEntry{{Name: "Table 4", Points: 100}}
+	if !reflect.DeepEqual(leaderboardResponse.Leaderboard, expectedLeaderboard) {
+		t.Errorf("Expected leaderboard %v, got %v", expectedLeaderboard, leaderboardResponse.Leaderboard)
+	}
+}
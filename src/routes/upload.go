@@ -3,7 +3,9 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"the-wedding-game-api/middleware"
 	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
 	"the-wedding-game-api/types"
 	"the-wedding-game-api/utils"
 )
@@ -15,14 +17,90 @@ func HandleImageUpload(c *gin.Context) {
 		return
 	}
 
-	url, err := utils.UploadFile(file)
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	uploadedFile, err := utils.UploadFile(file)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	upload, err := models.NewUpload(user.ID, uploadedFile).Save()
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
 
 	response := types.UploadResponse{
-		Url: url,
+		Url: upload.Url,
+	}
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func HandleVideoUpload(c *gin.Context) {
+	file, duration, err := validators.ValidateUploadVideoRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	uploadedFile, err := utils.UploadFile(file)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	upload, err := models.NewVideoUpload(user.ID, uploadedFile, duration).Save()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.UploadVideoResponse{
+		Url:             upload.Url,
+		DurationSeconds: upload.DurationSeconds,
+	}
+	c.IndentedJSON(http.StatusOK, response)
+}
+
+func HandleAudioUpload(c *gin.Context) {
+	file, duration, err := validators.ValidateUploadAudioRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	uploadedFile, err := utils.UploadFile(file)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	upload, err := models.NewAudioUpload(user.ID, uploadedFile, duration).Save()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.UploadAudioResponse{
+		Url:             upload.Url,
+		DurationSeconds: upload.DurationSeconds,
 	}
 	c.IndentedJSON(http.StatusOK, response)
 }
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"the-wedding-game-api/models"
 	"the-wedding-game-api/storage"
 	"the-wedding-game-api/types"
 	"the-wedding-game-api/utils"
@@ -210,3 +212,150 @@ func TestUploadImageWithTooLargeFile(t *testing.T) {
 		t.Errorf("Expected body to be %v, got %v", expectedBody, body)
 	}
 }
+
+func TestUploadImageIsRecorded(t *testing.T) {
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithFile("POST", "/upload", "image", "../_tests/assets/test_upload_image.jpg", accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.UploadResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	database, err := getDatabaseConnection()
+	if err != nil {
+		t.Errorf("Error getting database connection: %v", err)
+		return
+	}
+	defer closeDatabaseConnection(database)
+
+	var upload models.Upload
+	if err := database.Where("url = ?", response.Url).First(&upload).Error; err != nil {
+		t.Errorf("Expected the upload to be recorded: %v", err)
+		return
+	}
+
+	if upload.UserID != user.ID || upload.Size == 0 || len(upload.ContentHash) != 64 || upload.StorageKey == "" {
+		t.Errorf("Unexpected upload: %v", upload)
+	}
+}
+
+func verifyPhoto(challengeId uint, accessToken string, url string) (int, string) {
+	path := "/challenges/" + strconv.Itoa(int(challengeId)) + "/verify"
+	return makeRequestWithToken("POST", path, types.VerifyAnswerRequest{Answer: url}, accessToken)
+}
+
+func TestVerifyPhotoNotUploaded(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	statusCode, body := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/someone-elses-photo.jpg")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"answer must be a file you uploaded\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestVerifyPhotoUploadedByOtherUser(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	otherUser, _, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := createUpload(otherUser.ID, "https://example.com/other.jpg"); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	statusCode, _ := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/other.jpg")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+	}
+}
+
+func TestVerifyPhotoUsedForAnotherChallenge(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge1, err1 := createChallenge()
+	challenge2, err2 := createChallenge()
+	if err1 != nil || err2 != nil {
+		t.Errorf("Error creating challenges")
+		return
+	}
+
+	if err := createUpload(user.ID, "https://example.com/photo.jpg"); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	statusCode, _ := verifyPhoto(challenge1.ID, accessToken.Token, "https://example.com/photo.jpg")
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, body := verifyPhoto(challenge2.ID, accessToken.Token, "https://example.com/photo.jpg")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"file was already used for another submission\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"the-wedding-game-api/constants"
+	apperrors "the-wedding-game-api/errors"
+	"the-wedding-game-api/middleware"
+	"the-wedding-game-api/middleware/validators"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func GetUsers(c *gin.Context) {
+	users, err := models.GetAllUsers()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	response := types.GetUsersResponse{
+		Users: make([]types.UserAdminResponse, len(users)),
+	}
+	for i, user := range users {
+		response.Users[i] = getUserAdminResponse(user)
+	}
+
+	c.IndentedJSON(http.StatusOK, response)
+	return
+}
+
+func UpdateUserRole(c *gin.Context) {
+	userId, updateUserRoleRequest, err := validators.ValidateUpdateUserRoleRequest(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Admins could otherwise lock themselves, and possibly everyone, out of the admin endpoints
+	if currentUser.ID == userId {
+		_ = c.Error(apperrors.NewValidationError(constants.ChangeOwnRoleError))
+		return
+	}
+
+	user, err := models.GetUserByID(userId)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	user, err = user.SetRole(updateUserRoleRequest.Role, updateUserRoleRequest.Password)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, getUserAdminResponse(user))
+	return
+}
+
+func getUserAdminResponse(user models.User) types.UserAdminResponse {
+	return types.UserAdminResponse{
+		Id:       user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+	}
+}
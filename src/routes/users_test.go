@@ -0,0 +1,249 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"the-wedding-game-api/types"
+)
+
+func TestModeratorCanModerateSubmissions(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, moderatorToken, err := createModeratorAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating moderator and getting access token")
+		return
+	}
+
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if statusCode := uploadPhoto(challenge.ID, accessToken, "https://example.com/photo.jpg"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	statusCode, queue := getModerationQueue("pending", moderatorToken.Token)
+	if statusCode != http.StatusOK || len(queue.Submissions) != 1 {
+		t.Errorf("Expected the uploaded photo in the queue, got %v %v", statusCode, queue)
+		return
+	}
+
+	path := "/admin/submissions/" + strconv.Itoa(int(queue.Submissions[0].Id)) + "/approve"
+	statusCode, _ = makeRequestWithToken("POST", path, nil, moderatorToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+	}
+}
+
+func TestModeratorCannotManageChallengesOrScores(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, moderatorToken, err := createModeratorAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating moderator and getting access token")
+		return
+	}
+
+	challenge, err := createAnswerQuestionChallenge()
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+	challengePath := "/challenges/" + strconv.Itoa(int(challenge.ID))
+	pointsRequest := types.CreatePointsAdjustmentRequest{Type: types.BonusPointsEntry, Points: 50, Reason: "Best dancer"}
+
+	requests := []struct {
+		method string
+		path   string
+		body   interface{}
+	}{
+		{method: "GET", path: challengePath + "/answer"},
+		{method: "GET", path: challengePath + "/answers"},
+		{method: "PUT", path: challengePath, body: types.UpdateChallengeRequest{Name: "renamed"}},
+		{method: "DELETE", path: challengePath},
+		{method: "POST", path: "/users/" + strconv.Itoa(int(user.ID)) + "/points", body: pointsRequest},
+		{method: "GET", path: "/admin/users"},
+	}
+
+	for _, request := range requests {
+		statusCode, _ := makeRequestWithToken(request.method, request.path, request.body, moderatorToken.Token)
+		if statusCode != http.StatusForbidden {
+			t.Errorf("Expected status code 403 for %s %s, got %v", request.method, request.path, statusCode)
+		}
+	}
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	user, _, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	path := "/admin/users/" + strconv.Itoa(int(user.ID)) + "/role"
+	statusCode, body := makeRequestWithToken("PUT", path, types.UpdateUserRoleRequest{Role: types.Moderator}, adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"a password is required for admins and moderators\",\"status\":\"error\"}" {
+		t.Errorf("Expected password required error, got %v", body)
+		return
+	}
+
+	request := types.UpdateUserRoleRequest{Role: types.Moderator, Password: "bridesmaid password"}
+	statusCode, body = makeRequestWithToken("PUT", path, request, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.UserAdminResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Id != user.ID || response.Role != types.Moderator {
+		t.Errorf("Expected user %d to be a moderator, got %v", user.ID, response)
+		return
+	}
+
+	statusCode, _ = makeRequest("POST", "/auth/login", types.LoginRequest{Username: user.Username})
+	if statusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401 without a password, got %v", statusCode)
+		return
+	}
+
+	statusCode, body = makeRequest("POST", "/auth/login", types.LoginRequest{Username: user.Username, Password: "bridesmaid password"})
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var loginResponse types.LoginResponse
+	if err := json.Unmarshal([]byte(body), &loginResponse); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if loginResponse.User.Role != types.Moderator {
+		t.Errorf("Expected role MODERATOR, got %v", loginResponse.User.Role)
+	}
+}
+
+func TestDemotedUserNeedsNewPassword(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	_, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	user, _, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	path := "/admin/users/" + strconv.Itoa(int(user.ID)) + "/role"
+	request := types.UpdateUserRoleRequest{Role: types.Moderator, Password: "bridesmaid password"}
+	if statusCode, body := makeRequestWithToken("PUT", path, request, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	if statusCode, body := makeRequestWithToken("PUT", path, types.UpdateUserRoleRequest{Role: types.Player}, adminToken.Token); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	// The old password was removed with the demotion, promoting the user again needs a new one
+	statusCode, body := makeRequestWithToken("PUT", path, types.UpdateUserRoleRequest{Role: types.Moderator}, adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v %v", statusCode, body)
+	}
+}
+
+func TestUpdateOwnRole(t *testing.T) {
+	admin, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	path := "/admin/users/" + strconv.Itoa(int(admin.ID)) + "/role"
+	statusCode, body := makeRequestWithToken("PUT", path, types.UpdateUserRoleRequest{Role: types.Player}, adminToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\"message\":\"you cannot change your own role\",\"status\":\"error\"}" {
+		t.Errorf("Expected own role error, got %v", body)
+	}
+}
+
+func TestGetUsers(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	admin, adminToken, err := createAdminAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating admin and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/admin/users", nil, adminToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GetUsersResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expected := types.UserAdminResponse{Id: admin.ID, Username: admin.Username, Role: types.Admin}
+	if len(response.Users) != 1 || response.Users[0] != expected {
+		t.Errorf("Expected only %v, got %v", expected, response.Users)
+	}
+}
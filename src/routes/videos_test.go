@@ -0,0 +1,201 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"the-wedding-game-api/models"
+	"the-wedding-game-api/types"
+)
+
+func createVideoChallenge(maxDuration uint) (models.Challenge, error) {
+	return models.CreateNewChallenge(types.CreateChallengeRequest{
+		Name:        "toast_challenge",
+		Description: "film a toast to the couple",
+		Points:      50,
+		Image:       "https://example.com/image.jpg",
+		Type:        types.UploadVideoChallenge,
+		MaxDuration: maxDuration,
+	})
+}
+
+func createVideoUpload(userID uint, url string, durationSeconds float64) error {
+	upload := models.Upload{
+		UserID:          userID,
+		StorageKey:      url,
+		Url:             url,
+		Size:            1,
+		ContentHash:     "hash",
+		MediaType:       types.VideoMedia,
+		DurationSeconds: durationSeconds,
+	}
+	_, err := upload.Save()
+	return err
+}
+
+func TestUploadVideo(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithFile("POST", "/upload/video", "video", "../_tests/assets/test_upload_video.mp4", accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.UploadVideoResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	if response.Url == "" || response.DurationSeconds != 10 {
+		t.Errorf("Expected the url of a 10 second video, got %v", body)
+	}
+}
+
+func TestUploadVideoWithImage(t *testing.T) {
+	_, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	statusCode, body := makeRequestWithFile("POST", "/upload/video", "video", "../_tests/assets/test_upload_image.jpg", accessToken.Token)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"file must be an mp4, mov or webm video\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestVerifyVideoTooLong(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createVideoChallenge(30)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := createVideoUpload(user.ID, "https://example.com/long.mp4", 45); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	statusCode, body := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/long.mp4")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"video is longer than the maximum duration of the challenge\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestVerifyVideoWithPhoto(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createVideoChallenge(0)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := createUpload(user.ID, "https://example.com/photo.jpg"); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	statusCode, body := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/photo.jpg")
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code 400, got %v", statusCode)
+		return
+	}
+
+	if body != "{\n    \"message\": \"upload does not match the type of the challenge\",\n    \"status\": \"error\"\n}" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+}
+
+func TestApprovedVideoInGallery(t *testing.T) {
+	if err := resetDatabase(); err != nil {
+		t.Errorf("Error resetting database: %v", err)
+		return
+	}
+
+	user, accessToken, err := createUserAndGetAccessToken()
+	if err != nil {
+		t.Errorf("Error creating user and getting access token")
+		return
+	}
+
+	challenge, err := createVideoChallenge(30)
+	if err != nil {
+		t.Errorf("Error creating challenge: %v", err)
+		return
+	}
+
+	if err := createVideoUpload(user.ID, "https://example.com/toast.mp4", 20); err != nil {
+		t.Errorf("Error creating upload: %v", err)
+		return
+	}
+
+	if statusCode, body := verifyPhoto(challenge.ID, accessToken.Token, "https://example.com/toast.mp4"); statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v %v", statusCode, body)
+		return
+	}
+
+	submission, err := models.GetSubmission(user.ID, challenge.ID)
+	if err != nil || submission == nil || submission.State != types.PendingSubmission {
+		t.Errorf("Expected a pending submission, got %v %v", submission, err)
+		return
+	}
+
+	if _, err := submission.Approve(); err != nil {
+		t.Errorf("Error approving submission: %v", err)
+		return
+	}
+
+	statusCode, body := makeRequestWithToken("GET", "/gallery", nil, accessToken.Token)
+	if statusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", statusCode)
+		return
+	}
+
+	var response types.GalleryResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Errorf("Error unmarshalling response: %v", err)
+		return
+	}
+
+	expected := types.GalleryItem{Url: "https://example.com/toast.mp4", SubmittedBy: user.Username, MediaType: types.VideoMedia}
+	if len(response.Images) != 1 || response.Images[0] != expected {
+		t.Errorf("Expected the video in the gallery, got %v", body)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"the-wedding-game-api/models"
+	"time"
+)
+
+// sweepExpiredAccessTokens deletes the access tokens that can neither be used nor refreshed anymore, every interval
+func sweepExpiredAccessTokens(interval time.Duration) {
+	for range time.Tick(interval) {
+		deleted, err := models.DeleteExpiredAccessTokens()
+		if err != nil {
+			log.Println("Error deleting expired access tokens: ", err)
+			continue
+		}
+
+		if deleted > 0 {
+			log.Printf("Deleted %d expired access tokens\n", deleted)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package types
+
+type AnswerResponse struct {
+	Id    uint   `json:"id"`
+	Value string `json:"value"`
+}
+
+type GetAnswersResponse struct {
+	Answers []AnswerResponse `json:"answers"`
+}
+
+type CreateAnswerRequest struct {
+	Value string `json:"value" binding:"required" validate:"required"`
+}
+
+type UpdateAnswerRequest struct {
+	Value string `json:"value" binding:"required" validate:"required"`
+}
+
+type DeleteAnswerResponse struct {
+	Id uint `json:"id"`
+}
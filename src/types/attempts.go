@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+type AttemptForChallenge struct {
+	Id        uint      `json:"id"`
+	Value     string    `json:"value"`
+	Correct   bool      `json:"correct"`
+	UserId    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetAttemptsResponse struct {
+	Attempts []AttemptForChallenge `json:"attempts"`
+}
@@ -3,21 +3,56 @@ package types
 type UserRole string
 
 const (
-	Admin  UserRole = "ADMIN"
-	Player UserRole = "PLAYER"
+	Admin     UserRole = "ADMIN"
+	Moderator UserRole = "MODERATOR"
+	Player    UserRole = "PLAYER"
 )
 
 type LoginRequest struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password"`
+	Username   string `json:"username" binding:"required"`
+	Password   string `json:"password"`
+	InviteCode string `json:"invite_code"` // required unless registration is open, and always for guests who claimed an invite
 }
 
 type UserResponse struct {
+	Username string        `json:"username"`
+	Role     UserRole      `json:"role"`
+	Team     *TeamResponse `json:"team,omitempty"`
+}
+
+type LoginResponse struct {
+	User         UserResponse `json:"user"`
+	AccessToken  string       `json:"access_token"`
+	RefreshToken string       `json:"refresh_token"`
+	ExpiresOn    int64        `json:"expires_on"` // unix time the access token expires, refresh it before then
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type RefreshTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresOn    int64  `json:"expires_on"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" validate:"required"`
+	NewPassword     string `json:"new_password" binding:"required" validate:"required,min=8,max=72"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role     UserRole `json:"role" binding:"required" validate:"required,oneof=ADMIN MODERATOR PLAYER"`
+	Password string   `json:"password" validate:"omitempty,min=8,max=72"` // required to make a user without a password an admin or moderator
+}
+
+type UserAdminResponse struct {
+	Id       uint     `json:"id"`
 	Username string   `json:"username"`
 	Role     UserRole `json:"role"`
 }
 
-type LoginResponse struct {
-	User        UserResponse `json:"user"`
-	AccessToken string       `json:"access_token"`
+type GetUsersResponse struct {
+	Users []UserAdminResponse `json:"users"`
 }
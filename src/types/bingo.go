@@ -0,0 +1,48 @@
+package types
+
+type PublishBingoBoardRequest struct {
+	Size          uint   `json:"size" binding:"required" validate:"required,gte=2,lte=7"`
+	Challenges    []uint `json:"challenges" binding:"required" validate:"required"` // row by row, size × size challenge ids
+	LineBonus     uint   `json:"line_bonus"`                                        // for every completed row, column or diagonal
+	FullCardBonus uint   `json:"full_card_bonus"`
+	Shuffle       bool   `json:"shuffle"` // every player gets the cells in their own order
+}
+
+type BingoCell struct {
+	Row         uint   `json:"row"`
+	Column      uint   `json:"column"`
+	ChallengeId uint   `json:"challenge_id"`
+	Name        string `json:"name"`
+	Image       string `json:"image"`
+	Completed   bool   `json:"completed"`
+}
+
+type GetBingoBoardResponse struct {
+	Size           uint        `json:"size"`
+	Cells          []BingoCell `json:"cells"`
+	LineBonus      uint        `json:"line_bonus"`
+	FullCardBonus  uint        `json:"full_card_bonus"`
+	Shuffled       bool        `json:"shuffled"`
+	CompletedLines uint        `json:"completed_lines"`
+	FullCard       bool        `json:"full_card"`
+	BonusPoints    uint        `json:"bonus_points"`
+}
+
+type BingoBoardAdminResponse struct {
+	Id            uint   `json:"id"`
+	Size          uint   `json:"size"`
+	Challenges    []uint `json:"challenges"`
+	LineBonus     uint   `json:"line_bonus"`
+	FullCardBonus uint   `json:"full_card_bonus"`
+	Shuffle       bool   `json:"shuffle"`
+}
+
+type DeleteBingoBoardResponse struct {
+	Id uint `json:"id"`
+}
+
+// BingoCompletion is a challenge completed by a user, by themselves or, for team challenges, by a team member
+type BingoCompletion struct {
+	UserId      uint
+	ChallengeId uint
+}
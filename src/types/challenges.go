@@ -1,10 +1,19 @@
 package types
 
+import "time"
+
 type ChallengeType string
 
 const (
 	UploadPhotoChallenge    ChallengeType = "UPLOAD_PHOTO"
 	AnswerQuestionChallenge ChallengeType = "ANSWER_QUESTION"
+	MultipleChoiceChallenge ChallengeType = "MULTIPLE_CHOICE"
+	ScanCodeChallenge       ChallengeType = "SCAN_CODE"
+	UploadVideoChallenge    ChallengeType = "UPLOAD_VIDEO"
+	UploadAudioChallenge    ChallengeType = "UPLOAD_AUDIO"
+	PredictionChallenge     ChallengeType = "PREDICTION"
+	OrderingChallenge       ChallengeType = "ORDERING"
+	CheckInChallenge        ChallengeType = "CHECK_IN"
 )
 
 type ChallengeStatus string
@@ -14,46 +23,173 @@ const (
 	InactiveChallenge ChallengeStatus = "INACTIVE"
 )
 
+type AnswerMatchMode string
+
+const (
+	ExactAnswerMatch      AnswerMatchMode = "EXACT"
+	NormalizedAnswerMatch AnswerMatchMode = "NORMALIZED"
+	RegexAnswerMatch      AnswerMatchMode = "REGEX"
+	NumericAnswerMatch    AnswerMatchMode = "NUMERIC"
+)
+
+type PointsDecay string
+
+const (
+	NoPointsDecay     PointsDecay = "NONE"
+	LinearPointsDecay PointsDecay = "LINEAR"
+	StepPointsDecay   PointsDecay = "STEP"
+)
+
+type ScoringRules struct {
+	FirstSolverBonus     uint        `json:"first_solver_bonus"`
+	FirstSolverCount     uint        `json:"first_solver_count"`
+	Decay                PointsDecay `json:"decay" validate:"omitempty,oneof=NONE LINEAR STEP"`
+	DecayPoints          uint        `json:"decay_points"`
+	DecayIntervalMinutes uint        `json:"decay_interval_minutes"`
+	MinimumPoints        uint        `json:"minimum_points"`
+}
+
+type AnswerMatching struct {
+	Mode             AnswerMatchMode `json:"mode" validate:"omitempty,oneof=EXACT NORMALIZED REGEX NUMERIC"`
+	MaxDistance      uint            `json:"max_distance" validate:"lte=10"`
+	NumericTolerance float64         `json:"numeric_tolerance" validate:"gte=0"`
+}
+
 type CreateChallengeRequest struct {
-	Name        string        `json:"name" binding:"required" validate:"required"`
-	Description string        `json:"description" binding:"required" validate:"required"`
-	Points      uint          `json:"points" binding:"required" validate:"required,gte=0"`
-	Image       string        `json:"image" binding:"required" validate:"required,url"`
-	Type        ChallengeType `json:"type" binding:"required" validate:"required"`
-	Answer      string        `json:"answer"`
+	Name           string                 `json:"name" binding:"required" validate:"required"`
+	Description    string                 `json:"description" binding:"required" validate:"required"`
+	Points         uint                   `json:"points" binding:"required" validate:"required,gte=0"`
+	Image          string                 `json:"image" binding:"required" validate:"required,url"`
+	Type           ChallengeType          `json:"type" binding:"required" validate:"required"`
+	Answer         string                 `json:"answer"`
+	Answers        []string               `json:"answers" validate:"dive,required"`
+	AnswerMatching AnswerMatching         `json:"answer_matching"`
+	Options        []ChallengeOptionInput `json:"options" validate:"dive"`
+	AvailableFrom  string                 `json:"available_from"`
+	AvailableUntil string                 `json:"available_until"`
+	Prerequisites  []uint                 `json:"prerequisites"`
+	Hints          []ChallengeHintInput   `json:"hints" validate:"dive"`
+	MaxAttempts    uint                   `json:"max_attempts"`
+	Scoring        ScoringRules           `json:"scoring"`
+	Team           bool                   `json:"team"`
+	MaxDuration    uint                   `json:"max_duration_seconds"`
+	Prediction     PredictionRules        `json:"prediction"`
+	Items          []ChallengeItemInput   `json:"items" validate:"dive"` // in the correct order
+	PartialCredit  bool                   `json:"partial_credit"`
+	Location       *CheckInLocation       `json:"location"`
+}
+
+type ChallengeOptionInput struct {
+	Value   string `json:"value" validate:"required"`
+	Correct bool   `json:"correct"`
+}
+
+type ChallengeItemInput struct {
+	Value string `json:"value" validate:"required"`
+}
+
+type ChallengeItem struct {
+	Id    uint   `json:"id"`
+	Value string `json:"value"`
+}
+
+type ChallengeItemAdmin struct {
+	Id       uint   `json:"id"`
+	Value    string `json:"value"`
+	Position uint   `json:"position"`
+}
+
+type ChallengeHintInput struct {
+	Text string `json:"text" validate:"required"`
+	Cost uint   `json:"cost"`
+}
+
+type ChallengeHint struct {
+	Position uint   `json:"position"`
+	Text     string `json:"text"`
+	Cost     uint   `json:"cost"`
+}
+
+type ChallengeHintAdmin struct {
+	Id       uint   `json:"id"`
+	Position uint   `json:"position"`
+	Text     string `json:"text"`
+	Cost     uint   `json:"cost"`
+}
+
+type ChallengeOption struct {
+	Id    uint   `json:"id"`
+	Value string `json:"value"`
+}
+
+type ChallengeOptionAdmin struct {
+	Id      uint   `json:"id"`
+	Value   string `json:"value"`
+	Correct bool   `json:"correct"`
 }
 
 type ChallengeCreatedResponse struct {
-	Id          uint            `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Points      uint            `json:"points"`
-	Image       string          `json:"image"`
-	Status      ChallengeStatus `json:"status"`
-	Type        ChallengeType   `json:"type"`
+	Id             uint            `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Points         uint            `json:"points"`
+	Image          string          `json:"image"`
+	Status         ChallengeStatus `json:"status"`
+	Type           ChallengeType   `json:"type"`
+	AvailableFrom  *time.Time      `json:"available_from,omitempty"`
+	AvailableUntil *time.Time      `json:"available_until,omitempty"`
+	MaxAttempts    uint            `json:"max_attempts"`
+}
+
+type ChallengePrerequisite struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
 }
 
 type GetChallengeResponse struct {
-	Id          uint            `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Points      uint            `json:"points"`
-	Image       string          `json:"image"`
-	Status      ChallengeStatus `json:"status"`
-	Type        ChallengeType   `json:"type"`
-	Completed   bool            `json:"completed"`
+	Id                 uint                    `json:"id"`
+	Name               string                  `json:"name"`
+	Description        string                  `json:"description"`
+	Points             uint                    `json:"points"`
+	Image              string                  `json:"image"`
+	Status             ChallengeStatus         `json:"status"`
+	Type               ChallengeType           `json:"type"`
+	Completed          bool                    `json:"completed"`
+	Options            []ChallengeOption       `json:"options,omitempty"`
+	Items              []ChallengeItem         `json:"items,omitempty"`
+	PartialCredit      bool                    `json:"partial_credit,omitempty"`
+	Locked             bool                    `json:"locked"`
+	UnmetPrerequisites []ChallengePrerequisite `json:"unmet_prerequisites,omitempty"`
+	Hints              []ChallengeHint         `json:"hints,omitempty"`
+	RemainingHints     int                     `json:"remaining_hints"`
+	MaxAttempts        uint                    `json:"max_attempts,omitempty"`
+	MaxDuration        uint                    `json:"max_duration_seconds,omitempty"`
+	Team               bool                    `json:"team"`
+	SubmissionState    SubmissionState         `json:"submission_state,omitempty"`
+	RejectionReason    string                  `json:"rejection_reason,omitempty"`
+	Outcome            string                  `json:"outcome,omitempty"`
+	ResolvedAt         *time.Time              `json:"resolved_at,omitempty"`
+	Location           *CheckInLocation        `json:"location,omitempty"`
 }
 
 type GetChallengesResponse struct {
 	Challenges []GetChallengeResponse `json:"challenges"`
 }
 
+// VerifyAnswerRequest carries the answer of the player. Check-in challenges expect the location of the device
+// as "latitude,longitude,accuracy", with the accuracy in meters.
 type VerifyAnswerRequest struct {
 	Answer string `json:"answer" binding:"required" validate:"required"`
 }
 
 type VerifyAnswerResponse struct {
-	Correct bool `json:"correct"`
+	Correct           bool  `json:"correct"`
+	RemainingAttempts *uint `json:"remaining_attempts,omitempty"`
+}
+
+type RevealHintResponse struct {
+	Hint           ChallengeHint `json:"hint"`
+	RemainingHints int           `json:"remaining_hints"`
 }
 
 type GetChallengesAdminResponse struct {
@@ -61,42 +197,79 @@ type GetChallengesAdminResponse struct {
 }
 
 type GetChallengeAdminResponse struct {
-	Id          uint            `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Points      uint            `json:"points"`
-	Image       string          `json:"image"`
-	Status      ChallengeStatus `json:"status"`
-	Type        ChallengeType   `json:"type"`
+	Id             uint                   `json:"id"`
+	Name           string                 `json:"name"`
+	Description    string                 `json:"description"`
+	Points         uint                   `json:"points"`
+	Image          string                 `json:"image"`
+	Status         ChallengeStatus        `json:"status"`
+	Type           ChallengeType          `json:"type"`
+	AnswerMatching *AnswerMatching        `json:"answer_matching,omitempty"`
+	Options        []ChallengeOptionAdmin `json:"options,omitempty"`
+	Items          []ChallengeItemAdmin   `json:"items,omitempty"`
+	PartialCredit  bool                   `json:"partial_credit"`
+	AvailableFrom  *time.Time             `json:"available_from,omitempty"`
+	AvailableUntil *time.Time             `json:"available_until,omitempty"`
+	Prerequisites  []uint                 `json:"prerequisites,omitempty"`
+	Hints          []ChallengeHintAdmin   `json:"hints,omitempty"`
+	MaxAttempts    uint                   `json:"max_attempts"`
+	Scoring        ScoringRules           `json:"scoring"`
+	Team           bool                   `json:"team"`
+	MaxDuration    uint                   `json:"max_duration_seconds"`
+	SecretCode     string                 `json:"secret_code,omitempty"`
+	ScanLink       string                 `json:"scan_link,omitempty"`
+	Prediction     *PredictionRules       `json:"prediction,omitempty"`
+	Outcome        string                 `json:"outcome,omitempty"`
+	ResolvedAt     *time.Time             `json:"resolved_at,omitempty"`
+	Location       *CheckInLocation       `json:"location,omitempty"`
 }
 
 type UpdateChallengeRequest struct {
-	Name        string          `json:"name" validate:"required,min=4"`
-	Description string          `json:"description" validate:"required,min=9"`
-	Points      uint            `json:"points" validate:"required,gte=0"`
-	Image       string          `json:"image" validate:"required,url"`
-	Status      ChallengeStatus `json:"status" validate:"required,oneof=ACTIVE INACTIVE"`
-	Type        ChallengeType   `json:"type" validate:"required,oneof=UPLOAD_PHOTO ANSWER_QUESTION"`
-	Answer      string          `json:"answer"`
+	Name           string                 `json:"name" validate:"required,min=4"`
+	Description    string                 `json:"description" validate:"required,min=9"`
+	Points         uint                   `json:"points" validate:"required,gte=0"`
+	Image          string                 `json:"image" validate:"required,url"`
+	Status         ChallengeStatus        `json:"status" validate:"required,oneof=ACTIVE INACTIVE"`
+	Type           ChallengeType          `json:"type" validate:"required,oneof=UPLOAD_PHOTO ANSWER_QUESTION MULTIPLE_CHOICE SCAN_CODE UPLOAD_VIDEO UPLOAD_AUDIO PREDICTION ORDERING CHECK_IN"`
+	Answer         string                 `json:"answer"`
+	Answers        []string               `json:"answers" validate:"dive,required"`
+	AnswerMatching AnswerMatching         `json:"answer_matching"`
+	Options        []ChallengeOptionInput `json:"options" validate:"dive"`
+	AvailableFrom  *string                `json:"available_from"`        // kept if omitted, an empty string removes it
+	AvailableUntil *string                `json:"available_until"`       // kept if omitted, an empty string removes it
+	Prerequisites  []uint                 `json:"prerequisites"`         // kept if omitted, an empty list removes them
+	Hints          []ChallengeHintInput   `json:"hints" validate:"dive"` // kept if omitted, an empty list removes them
+	MaxAttempts    *uint                  `json:"max_attempts"`          // kept if omitted, 0 removes the limit
+	Scoring        *ScoringRules          `json:"scoring"`               // kept if omitted
+	Team           *bool                  `json:"team"`                  // kept if omitted
+	MaxDuration    *uint                  `json:"max_duration_seconds"`  // kept if omitted, 0 removes the limit
+	Prediction     *PredictionRules       `json:"prediction"`            // kept if omitted
+	Items          []ChallengeItemInput   `json:"items" validate:"dive"` // kept if omitted
+	PartialCredit  *bool                  `json:"partial_credit"`        // kept if omitted
+	Location       *CheckInLocation       `json:"location"`              // kept if omitted
 }
 
 type UpdateChallengeResponse struct {
-	Id          uint            `json:"id"`
-	Name        string          `json:"name"`
-	Description string          `json:"description"`
-	Points      uint            `json:"points"`
-	Image       string          `json:"image"`
-	Status      ChallengeStatus `json:"status"`
-	Type        ChallengeType   `json:"type"`
+	Id             uint            `json:"id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Points         uint            `json:"points"`
+	Image          string          `json:"image"`
+	Status         ChallengeStatus `json:"status"`
+	Type           ChallengeType   `json:"type"`
+	AvailableFrom  *time.Time      `json:"available_from,omitempty"`
+	AvailableUntil *time.Time      `json:"available_until,omitempty"`
+	MaxAttempts    uint            `json:"max_attempts"`
 }
 
 type SubmissionForChallenge struct {
-	Id            uint   `json:"id"`
-	Answer        string `json:"answer"`
-	ChallengeId   uint   `json:"challenge_id"`
-	ChallengeName string `json:"challenge_name"`
-	UserId        uint   `json:"user_id"`
-	Username      string `json:"username"`
+	Id            uint     `json:"id"`
+	Answer        string   `json:"answer"`
+	ChallengeId   uint     `json:"challenge_id"`
+	ChallengeName string   `json:"challenge_name"`
+	UserId        uint     `json:"user_id"`
+	Username      string   `json:"username"`
+	Distance      *float64 `json:"distance_meters,omitempty"` // from the location of a check-in challenge
 }
 
 type GetSubmissionsResponse struct {
@@ -104,7 +277,8 @@ type GetSubmissionsResponse struct {
 }
 
 type GetAnswerResponse struct {
-	Answer string `json:"answer"`
+	Answer  string   `json:"answer"`
+	Answers []string `json:"answers"`
 }
 
 type DeleteChallengeResponse struct {
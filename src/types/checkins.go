@@ -0,0 +1,7 @@
+package types
+
+type CheckInLocation struct {
+	Latitude  float64 `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude float64 `json:"longitude" validate:"gte=-180,lte=180"`
+	Radius    float64 `json:"radius_meters" validate:"gte=0"`
+}
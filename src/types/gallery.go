@@ -1,8 +1,17 @@
 package types
 
+type MediaType string
+
+const (
+	ImageMedia MediaType = "IMAGE"
+	VideoMedia MediaType = "VIDEO"
+	AudioMedia MediaType = "AUDIO"
+)
+
 type GalleryItem struct {
-	Url         string `json:"url"`
-	SubmittedBy string `json:"submitted_by"`
+	Url         string    `json:"url"`
+	SubmittedBy string    `json:"submitted_by"`
+	MediaType   MediaType `json:"media_type"`
 }
 type GalleryResponse struct {
 	Images []GalleryItem `json:"images"`
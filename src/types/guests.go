@@ -0,0 +1,25 @@
+package types
+
+type GuestRequest struct {
+	Name       string `json:"name" binding:"required" validate:"required"`
+	InviteCode string `json:"invite_code" validate:"omitempty,alphanum,min=4,max=16"` // generated if omitted
+}
+
+type ImportGuestsRequest struct {
+	Guests []GuestRequest `json:"guests" binding:"required" validate:"required,min=1,dive"`
+}
+
+type GuestResponse struct {
+	Id         uint    `json:"id"`
+	Name       string  `json:"name"`
+	InviteCode string  `json:"invite_code"`
+	Username   *string `json:"username,omitempty"` // the user who claimed the invite, empty until then
+}
+
+type GetGuestsResponse struct {
+	Guests []GuestResponse `json:"guests"`
+}
+
+type RegistrationSettings struct {
+	OpenRegistration *bool `json:"open_registration" binding:"required" validate:"required"` // anyone can join without an invite code
+}
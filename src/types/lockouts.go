@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+type LockoutResponse struct {
+	Key           string     `json:"key"` // "username:<username>" or "ip:<address>"
+	Failures      uint       `json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"` // only set while the key is locked out
+}
+
+type GetLockoutsResponse struct {
+	Lockouts []LockoutResponse `json:"lockouts"`
+}
@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+type AudioMessage struct {
+	Id              uint      `json:"id"`
+	Url             string    `json:"url"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	ChallengeId     uint      `json:"challenge_id"`
+	ChallengeName   string    `json:"challenge_name"`
+	UserId          uint      `json:"user_id"`
+	Username        string    `json:"username"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type GetMessagesResponse struct {
+	Messages []AudioMessage `json:"messages"`
+}
@@ -0,0 +1,33 @@
+package types
+
+// Permission is something a role may do beyond playing the game
+type Permission string
+
+const (
+	ManageChallengesPermission    Permission = "MANAGE_CHALLENGES"    // create, edit and delete challenges and their answers
+	ViewAnswersPermission         Permission = "VIEW_ANSWERS"         // see the answers of challenges and the guesses of players
+	ViewSubmissionsPermission     Permission = "VIEW_SUBMISSIONS"     // see the moderation queue and the audio messages
+	ModerateSubmissionsPermission Permission = "MODERATE_SUBMISSIONS" // approve and reject submissions
+	ManageScoresPermission        Permission = "MANAGE_SCORES"        // adjust points and resolve predictions
+	ManageTeamsPermission         Permission = "MANAGE_TEAMS"         // create teams and assign players to them
+	ManageGamePermission          Permission = "MANAGE_GAME"          // bingo boards and quiz rounds
+	ManageUsersPermission         Permission = "MANAGE_USERS"         // guests, roles, registration and login lockouts
+)
+
+// RolePermissions lists what every role may do, players have no permissions
+var RolePermissions = map[UserRole][]Permission{
+	Admin: {
+		ManageChallengesPermission,
+		ViewAnswersPermission,
+		ViewSubmissionsPermission,
+		ModerateSubmissionsPermission,
+		ManageScoresPermission,
+		ManageTeamsPermission,
+		ManageGamePermission,
+		ManageUsersPermission,
+	},
+	Moderator: {
+		ViewSubmissionsPermission,
+		ModerateSubmissionsPermission,
+	},
+}
@@ -1,5 +1,17 @@
 package types
 
+import "time"
+
+type PointsEntryType string
+
+const (
+	SubmissionPointsEntry PointsEntryType = "SUBMISSION"
+	BonusPointsEntry      PointsEntryType = "BONUS"
+	PenaltyPointsEntry    PointsEntryType = "PENALTY"
+	ReversalPointsEntry   PointsEntryType = "REVERSAL"
+	BingoPointsEntry      PointsEntryType = "BINGO" // lines and full card of the bingo board, withdrawn with a negative entry
+)
+
 type CurrentUserPointsResponse struct {
 	Points uint `json:"points"`
 }
@@ -12,3 +24,26 @@ type LeaderboardEntry struct {
 type GetLeaderboardResponse struct {
 	Leaderboard []LeaderboardEntry `json:"leaderboard"`
 }
+
+type CreatePointsAdjustmentRequest struct {
+	Type   PointsEntryType `json:"type" binding:"required" validate:"required,oneof=BONUS PENALTY"`
+	Points uint            `json:"points" binding:"required" validate:"required,gt=0"` // taken off for a penalty
+	Reason string          `json:"reason" binding:"required" validate:"required"`
+}
+
+type PointsEntryResponse struct {
+	Id           uint            `json:"id"`
+	Type         PointsEntryType `json:"type"`
+	Points       int             `json:"points"` // negative for penalties and reversals
+	Reason       string          `json:"reason"`
+	ChallengeId  *uint           `json:"challenge_id,omitempty"`
+	SubmissionId *uint           `json:"submission_id,omitempty"`
+	ActorId      *uint           `json:"actor_id,omitempty"` // the admin who made the adjustment, empty for entries of the game itself
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type GetPointsLedgerResponse struct {
+	UserId  uint                  `json:"user_id"`
+	Points  uint                  `json:"points"` // as counted on the leaderboard, without inactive challenges and with the bingo bonus
+	Entries []PointsEntryResponse `json:"entries"`
+}
@@ -0,0 +1,36 @@
+package types
+
+import "time"
+
+type PredictionMode string
+
+const (
+	ExactPrediction     PredictionMode = "EXACT"
+	ClosestPrediction   PredictionMode = "CLOSEST"
+	TolerancePrediction PredictionMode = "TOLERANCE"
+)
+
+type PredictionRules struct {
+	Mode      PredictionMode `json:"mode" validate:"omitempty,oneof=EXACT CLOSEST TOLERANCE"`
+	Closest   uint           `json:"closest"` // number of winners for CLOSEST, ties share the last place
+	Tolerance float64        `json:"tolerance" validate:"gte=0"`
+}
+
+type ResolvePredictionRequest struct {
+	Outcome string `json:"outcome" binding:"required" validate:"required"`
+}
+
+type PredictionResult struct {
+	SubmissionId uint   `json:"submission_id"`
+	UserId       uint   `json:"user_id"`
+	Guess        string `json:"guess"`
+	Winner       bool   `json:"winner"`
+	Points       uint   `json:"points"`
+}
+
+type ResolvePredictionResponse struct {
+	ChallengeId uint               `json:"challenge_id"`
+	Outcome     string             `json:"outcome"`
+	ResolvedAt  time.Time          `json:"resolved_at"`
+	Results     []PredictionResult `json:"results"`
+}
@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+type QuizRoundStatus string
+
+const (
+	PendingQuizRound  QuizRoundStatus = "PENDING"
+	RunningQuizRound  QuizRoundStatus = "RUNNING"
+	FinishedQuizRound QuizRoundStatus = "FINISHED"
+)
+
+type CreateQuizRoundRequest struct {
+	Name            string `json:"name" binding:"required" validate:"required"`
+	QuestionSeconds uint   `json:"question_seconds" binding:"required" validate:"required,gte=5,lte=600"`
+	Challenges      []uint `json:"challenges" binding:"required" validate:"required,min=1"` // in the order they are asked
+}
+
+type QuizRoundResponse struct {
+	Id              uint            `json:"id"`
+	Name            string          `json:"name"`
+	QuestionSeconds uint            `json:"question_seconds"`
+	Challenges      []uint          `json:"challenges"`
+	Status          QuizRoundStatus `json:"status"`
+	StartedAt       *time.Time      `json:"started_at,omitempty"`
+	EndsAt          *time.Time      `json:"ends_at,omitempty"`
+}
+
+type GetQuizRoundsResponse struct {
+	Rounds []QuizRoundResponse `json:"rounds"`
+}
+
+type CurrentQuizQuestionResponse struct {
+	RoundId     uint              `json:"round_id"`
+	RoundName   string            `json:"round_name"`
+	Number      uint              `json:"number"` // starting at 1
+	Total       uint              `json:"total"`
+	ChallengeId uint              `json:"challenge_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Image       string            `json:"image"`
+	Type        ChallengeType     `json:"type"`
+	Points      uint              `json:"points"`
+	Options     []ChallengeOption `json:"options,omitempty"`
+	OpensAt     time.Time         `json:"opens_at"`
+	ClosesAt    time.Time         `json:"closes_at"`
+	Answered    bool              `json:"answered"`
+}
+
+type AnswerQuizQuestionRequest struct {
+	ChallengeId uint   `json:"challenge_id" binding:"required" validate:"required"` // the question the player is answering
+	Answer      string `json:"answer" binding:"required" validate:"required"`
+}
+
+type AnswerQuizQuestionResponse struct {
+	Correct bool `json:"correct"`
+	Points  uint `json:"points"`
+}
+
+type QuizResult struct {
+	UserId         uint   `json:"user_id"`
+	Username       string `json:"username"`
+	Points         uint   `json:"points"`
+	CorrectAnswers uint   `json:"correct_answers"`
+}
+
+type GetQuizResultsResponse struct {
+	RoundId uint         `json:"round_id"`
+	Results []QuizResult `json:"results"`
+}
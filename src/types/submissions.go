@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+type SubmissionState string
+
+const (
+	PendingSubmission  SubmissionState = "PENDING"
+	ApprovedSubmission SubmissionState = "APPROVED"
+	RejectedSubmission SubmissionState = "REJECTED"
+)
+
+type ModerationSubmission struct {
+	Id              uint            `json:"id"`
+	Answer          string          `json:"answer"`
+	ChallengeId     uint            `json:"challenge_id"`
+	ChallengeName   string          `json:"challenge_name"`
+	UserId          uint            `json:"user_id"`
+	Username        string          `json:"username"`
+	Points          uint            `json:"points"`
+	State           SubmissionState `json:"state"`
+	RejectionReason string          `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+type GetModerationQueueResponse struct {
+	Submissions []ModerationSubmission `json:"submissions"`
+}
+
+type RejectSubmissionRequest struct {
+	Reason string `json:"reason" binding:"required" validate:"required"`
+}
+
+type ModerateSubmissionResponse struct {
+	Id              uint            `json:"id"`
+	State           SubmissionState `json:"state"`
+	RejectionReason string          `json:"rejection_reason,omitempty"`
+}
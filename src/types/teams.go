@@ -0,0 +1,53 @@
+package types
+
+type CreateTeamRequest struct {
+	Name     string `json:"name" binding:"required" validate:"required"`
+	JoinCode string `json:"join_code" validate:"omitempty,alphanum,min=4,max=16"` // generated if omitted
+}
+
+type UpdateTeamRequest struct {
+	Name     string `json:"name" binding:"required" validate:"required"`
+	JoinCode string `json:"join_code" validate:"omitempty,alphanum,min=4,max=16"` // kept if omitted
+}
+
+type JoinTeamRequest struct {
+	JoinCode string `json:"join_code" binding:"required" validate:"required"`
+}
+
+type AssignTeamRequest struct {
+	TeamId *uint `json:"team_id"` // null removes the user from their team
+}
+
+type TeamResponse struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type TeamMember struct {
+	Id       uint   `json:"id"`
+	Username string `json:"username"`
+}
+
+type TeamAdminResponse struct {
+	Id       uint         `json:"id"`
+	Name     string       `json:"name"`
+	JoinCode string       `json:"join_code"`
+	Members  []TeamMember `json:"members"`
+}
+
+type GetTeamsResponse struct {
+	Teams []TeamAdminResponse `json:"teams"`
+}
+
+type DeleteTeamResponse struct {
+	Id uint `json:"id"`
+}
+
+type TeamLeaderboardEntry struct {
+	Name   string `json:"name"`
+	Points uint   `json:"points"`
+}
+
+type GetTeamLeaderboardResponse struct {
+	Leaderboard []TeamLeaderboardEntry `json:"leaderboard"`
+}
@@ -3,3 +3,13 @@ package types
 type UploadResponse struct {
 	Url string `json:"url"`
 }
+
+type UploadVideoResponse struct {
+	Url             string  `json:"url"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+type UploadAudioResponse struct {
+	Url             string  `json:"url"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
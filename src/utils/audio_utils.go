@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	M4AAudio  = "m4a"
+	MP3Audio  = "mp3"
+	OggAudio  = "ogg"
+	WebMAudio = "webm"
+
+	oggPageHeaderSize = 27
+	oggLastPageWindow = 64 * 1024
+)
+
+var errInvalidAudio = errors.New("invalid audio file")
+
+var mp3Version1Bitrates = []int64{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320}
+var mp3Version2Bitrates = []int64{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160}
+var mp3SampleRates = map[byte][]int64{
+	3: {44100, 48000, 32000}, // MPEG 1
+	2: {22050, 24000, 16000}, // MPEG 2
+	0: {11025, 12000, 8000},  // MPEG 2.5
+}
+
+// DetectAudioFormat sniffs the format of an audio file from its first bytes, the file name is not trusted
+func DetectAudioFormat(file io.ReadSeeker) (string, error) {
+	header := make([]byte, 12)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", errInvalidAudio
+	}
+	header = header[:n]
+
+	switch {
+	case len(header) >= 12 && string(header[4:8]) == "ftyp":
+		return M4AAudio, nil
+	case bytes.HasPrefix(header, []byte("OggS")):
+		return OggAudio, nil
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return WebMAudio, nil
+	case bytes.HasPrefix(header, []byte("ID3")), len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0:
+		return MP3Audio, nil
+	default:
+		return "", errInvalidAudio
+	}
+}
+
+// GetAudioDuration reads the duration of an audio file of the given format, as returned by DetectAudioFormat.
+// Durations that are not finite or longer than any real recording are rejected like those of videos
+func GetAudioDuration(file io.ReadSeeker, format string) (time.Duration, error) {
+	switch format {
+	case M4AAudio:
+		return getMP4Duration(file)
+	case WebMAudio:
+		return getWebMDuration(file)
+	case OggAudio:
+		return getOggDuration(file)
+	case MP3Audio:
+		return getMP3Duration(file)
+	default:
+		return 0, errInvalidAudio
+	}
+}
+
+// getOggDuration divides the granule position of the last page by the sample rate of the vorbis or opus stream
+func getOggDuration(file io.ReadSeeker) (time.Duration, error) {
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	firstPage := make([]byte, min(end, oggPageHeaderSize+255+64))
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err := io.ReadFull(file, firstPage); err != nil || len(firstPage) < oggPageHeaderSize {
+		return 0, errInvalidAudio
+	}
+	packetStart := oggPageHeaderSize + int(firstPage[26])
+	if packetStart > len(firstPage) {
+		return 0, errInvalidAudio
+	}
+	packet := firstPage[packetStart:]
+
+	var sampleRate, preSkip int64
+	switch {
+	case len(packet) >= 16 && bytes.HasPrefix(packet, []byte("\x01vorbis")):
+		sampleRate = int64(binary.LittleEndian.Uint32(packet[12:16]))
+	case len(packet) >= 12 && bytes.HasPrefix(packet, []byte("OpusHead")):
+		sampleRate = 48000
+		preSkip = int64(binary.LittleEndian.Uint16(packet[10:12]))
+	default:
+		return 0, errInvalidAudio
+	}
+
+	start := max(0, end-oggLastPageWindow)
+	tail := make([]byte, end-start)
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err := io.ReadFull(file, tail); err != nil {
+		return 0, errInvalidAudio
+	}
+	lastPage := bytes.LastIndex(tail, []byte("OggS"))
+	if lastPage < 0 || lastPage+oggPageHeaderSize > len(tail) {
+		return 0, errInvalidAudio
+	}
+
+	granule := int64(binary.LittleEndian.Uint64(tail[lastPage+6 : lastPage+14]))
+	if sampleRate == 0 || granule < preSkip {
+		return 0, errInvalidAudio
+	}
+	return secondsToDuration(float64(granule-preSkip) / float64(sampleRate))
+}
+
+// getMP3Duration uses the frame count of a Xing, Info or VBRI header, or estimates the duration from the bitrate of the first frame
+func getMP3Duration(file io.ReadSeeker) (time.Duration, error) {
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	frameStart, err := skipID3Tag(file)
+	if err != nil {
+		return 0, err
+	}
+	if frameStart+4 > end {
+		return 0, errInvalidAudio
+	}
+
+	frame := make([]byte, min(end-frameStart, 4+32+18))
+	if _, err := file.Seek(frameStart, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err := io.ReadFull(file, frame); err != nil {
+		return 0, errInvalidAudio
+	}
+	if frame[0] != 0xFF || frame[1]&0xE0 != 0xE0 {
+		return 0, errInvalidAudio
+	}
+
+	version := (frame[1] >> 3) & 0x03
+	layer := (frame[1] >> 1) & 0x03
+	bitrateIndex := frame[2] >> 4
+	sampleRateIndex := (frame[2] >> 2) & 0x03
+	sampleRates, knownVersion := mp3SampleRates[version]
+	if !knownVersion || layer != 1 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return 0, errInvalidAudio
+	}
+
+	sampleRate := sampleRates[sampleRateIndex]
+	mono := frame[3]>>6 == 3
+	bitrates, samplesPerFrame, sideInfoSize := mp3Version1Bitrates, int64(1152), 32
+	if mono {
+		sideInfoSize = 17
+	}
+	if version != 3 {
+		bitrates, samplesPerFrame, sideInfoSize = mp3Version2Bitrates, 576, 17
+		if mono {
+			sideInfoSize = 9
+		}
+	}
+
+	if frames, ok := readMP3FrameCount(frame, 4+sideInfoSize); ok {
+		return secondsToDuration(float64(frames*samplesPerFrame) / float64(sampleRate))
+	}
+
+	bitsPerSecond := bitrates[bitrateIndex] * 1000
+	return secondsToDuration(float64((end-frameStart)*8) / float64(bitsPerSecond))
+}
+
+// readMP3FrameCount reads the number of frames from the Xing or Info header after the side information, or from a VBRI header
+func readMP3FrameCount(frame []byte, xingOffset int) (int64, bool) {
+	if len(frame) >= xingOffset+12 {
+		tag := string(frame[xingOffset : xingOffset+4])
+		flags := binary.BigEndian.Uint32(frame[xingOffset+4 : xingOffset+8])
+		if (tag == "Xing" || tag == "Info") && flags&0x01 != 0 {
+			return int64(binary.BigEndian.Uint32(frame[xingOffset+8 : xingOffset+12])), true
+		}
+	}
+
+	const vbriOffset = 4 + 32
+	if len(frame) >= vbriOffset+18 && string(frame[vbriOffset:vbriOffset+4]) == "VBRI" {
+		return int64(binary.BigEndian.Uint32(frame[vbriOffset+14 : vbriOffset+18])), true
+	}
+	return 0, false
+}
+
+// skipID3Tag returns the offset of the first audio frame, after the ID3v2 tag if there is one
+func skipID3Tag(file io.ReadSeeker) (int64, error) {
+	header := make([]byte, 10)
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err := io.ReadFull(file, header); err != nil {
+		return 0, errInvalidAudio
+	}
+	if string(header[:3]) != "ID3" {
+		return 0, nil
+	}
+
+	// the size is a syncsafe integer, the highest bit of every byte is always zero
+	size := int64(header[6])<<21 | int64(header[7])<<14 | int64(header[8])<<7 | int64(header[9])
+	if header[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+	return 10 + size, nil
+}
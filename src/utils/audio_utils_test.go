@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/go-playground/assert/v2"
+	"math"
+	"testing"
+	"time"
+)
+
+func oggPage(granule uint64, packet []byte) []byte {
+	page := make([]byte, oggPageHeaderSize, oggPageHeaderSize+1+len(packet))
+	copy(page, "OggS")
+	binary.LittleEndian.PutUint64(page[6:14], granule)
+	page[26] = 1
+	page = append(page, byte(len(packet)))
+	return append(page, packet...)
+}
+
+func opusFile(preSkip uint16, granule uint64) []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	binary.LittleEndian.PutUint16(head[10:12], preSkip)
+	return append(oggPage(0, head), oggPage(granule, []byte("audio"))...)
+}
+
+func vorbisFile(sampleRate uint32, granule uint64) []byte {
+	head := make([]byte, 30)
+	copy(head, "\x01vorbis")
+	binary.LittleEndian.PutUint32(head[12:16], sampleRate)
+	return append(oggPage(0, head), oggPage(granule, []byte("audio"))...)
+}
+
+// mp3Frame returns the first frame of an MPEG 1 layer III stereo file at 128 kbit/s and 44.1 kHz
+func mp3Frame(xingFrames uint32) []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	if xingFrames > 0 {
+		copy(frame[36:40], "Xing")
+		binary.BigEndian.PutUint32(frame[40:44], 0x01)
+		binary.BigEndian.PutUint32(frame[44:48], xingFrames)
+	}
+	return frame
+}
+
+func TestDetectAudioFormat(t *testing.T) {
+	files := map[string][]byte{
+		M4AAudio:  append(mp4Box("ftyp", []byte("M4A ")), mp4Box("moov", nil)...),
+		MP3Audio:  mp3Frame(0),
+		OggAudio:  opusFile(0, 48000),
+		WebMAudio: webmFile(nil, []byte{0x40, 0xC3, 0x88, 0, 0, 0, 0, 0}),
+	}
+
+	for expected, file := range files {
+		format, err := DetectAudioFormat(bytes.NewReader(file))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, format, expected)
+	}
+
+	withID3 := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x00"), mp3Frame(0)...)
+	format, err := DetectAudioFormat(bytes.NewReader(withID3))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, format, MP3Audio)
+}
+
+func TestDetectAudioFormatUnknown(t *testing.T) {
+	_, err := DetectAudioFormat(bytes.NewReader([]byte("<html>not audio</html>")))
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetAudioDurationM4A(t *testing.T) {
+	audio := append(mp4Box("ftyp", []byte("M4A ")), mp4Box("moov", mp4Box("mvhd", mvhdVersion0(44100, 44100*15)))...)
+
+	duration, err := GetAudioDuration(bytes.NewReader(audio), M4AAudio)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 15*time.Second)
+}
+
+func TestGetAudioDurationM4AWithOverflowingLargeSize(t *testing.T) {
+	moov := append(mp4Box("free", nil), mp4LargeBox("mvhd", 1<<63-4)...)
+	audio := append(mp4Box("ftyp", []byte("M4A ")), mp4Box("moov", moov)...)
+
+	_, err := GetAudioDuration(bytes.NewReader(audio), M4AAudio)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetAudioDurationM4AWithOversizedDuration(t *testing.T) {
+	audio := append(mp4Box("ftyp", []byte("M4A ")), mp4Box("moov", mp4Box("mvhd", mvhdVersion1(1, math.MaxUint64)))...)
+
+	_, err := GetAudioDuration(bytes.NewReader(audio), M4AAudio)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetAudioDurationWebMWithOversizedOrNaNDuration(t *testing.T) {
+	for _, value := range []float64{math.MaxFloat64, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		duration := make([]byte, 8)
+		binary.BigEndian.PutUint64(duration, math.Float64bits(value))
+
+		_, err := GetAudioDuration(bytes.NewReader(webmFile(nil, duration)), WebMAudio)
+		assert.NotEqual(t, err, nil)
+	}
+}
+
+func TestGetAudioDurationOpusWithOversizedGranule(t *testing.T) {
+	_, err := GetAudioDuration(bytes.NewReader(opusFile(0, math.MaxInt64)), OggAudio)
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetAudioDurationOpus(t *testing.T) {
+	duration, err := GetAudioDuration(bytes.NewReader(opusFile(312, 48000*20+312)), OggAudio)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 20*time.Second)
+}
+
+func TestGetAudioDurationVorbis(t *testing.T) {
+	duration, err := GetAudioDuration(bytes.NewReader(vorbisFile(44100, 44100*8)), OggAudio)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 8*time.Second)
+}
+
+func TestGetAudioDurationMP3Xing(t *testing.T) {
+	// 1152 samples per frame at 44.1 kHz, 1225 frames are 32 seconds
+	audio := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x0A"), make([]byte, 10)...)
+	audio = append(audio, mp3Frame(1225)...)
+
+	duration, err := GetAudioDuration(bytes.NewReader(audio), MP3Audio)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 32*time.Second)
+}
+
+func TestGetAudioDurationMP3ConstantBitrate(t *testing.T) {
+	// 128 kbit/s are 16000 bytes per second
+	audio := append(mp3Frame(0), make([]byte, 16000*3-417)...)
+
+	duration, err := GetAudioDuration(bytes.NewReader(audio), MP3Audio)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 3*time.Second)
+}
+
+func TestGetAudioDurationInvalidFile(t *testing.T) {
+	for _, format := range []string{M4AAudio, MP3Audio, OggAudio, WebMAudio, "wav"} {
+		_, err := GetAudioDuration(bytes.NewReader([]byte("not audio")), format)
+		assert.NotEqual(t, err, nil)
+	}
+}
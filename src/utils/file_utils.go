@@ -2,6 +2,8 @@ package utils
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/google/uuid"
 	"io"
@@ -34,10 +36,18 @@ func getReader(fileBytes multipart.File) (*bytes.Reader, error) {
 	return bytes.NewReader(buf.Bytes()), nil
 }
 
-func UploadFile(file *multipart.FileHeader) (string, error) {
+// UploadedFile describes a file stored by UploadFile
+type UploadedFile struct {
+	Key         string
+	Url         string
+	Size        int64
+	ContentHash string
+}
+
+func UploadFile(file *multipart.FileHeader) (UploadedFile, error) {
 	fileBytes, err := file.Open()
 	if err != nil {
-		return "", fmt.Errorf("error while opening file: %w", err)
+		return UploadedFile{}, fmt.Errorf("error while opening file: %w", err)
 	}
 	defer func(fileBytes multipart.File) {
 		err := fileBytes.Close()
@@ -48,23 +58,45 @@ func UploadFile(file *multipart.FileHeader) (string, error) {
 
 	reader, err := getReader(fileBytes)
 	if err != nil {
-		return "", fmt.Errorf("error while getting reader: %w", err)
+		return UploadedFile{}, fmt.Errorf("error while getting reader: %w", err)
+	}
+
+	contentHash, err := getContentHash(reader)
+	if err != nil {
+		return UploadedFile{}, fmt.Errorf("error while hashing file: %w", err)
 	}
 
 	storageService, err := storage.GetStorage()
 	if err != nil {
-		return "", fmt.Errorf("error while getting storage service: %w", err)
+		return UploadedFile{}, fmt.Errorf("error while getting storage service: %w", err)
 	}
 
 	generatedFileName, err := generateRandomFileName(file.Filename)
 	if err != nil {
-		return "", fmt.Errorf("error while generating random file name: %w", err)
+		return UploadedFile{}, fmt.Errorf("error while generating random file name: %w", err)
 	}
 
 	url, err := storageService.UploadFile(*reader, generatedFileName)
 	if err != nil {
-		return "", fmt.Errorf("error while uploading file: %w", err)
+		return UploadedFile{}, fmt.Errorf("error while uploading file: %w", err)
 	}
 
-	return url, nil
+	return UploadedFile{
+		Key:         generatedFileName,
+		Url:         url,
+		Size:        reader.Size(),
+		ContentHash: contentHash,
+	}, nil
+}
+
+// getContentHash returns the hex encoded sha256 of the file and rewinds the reader for the upload
+func getContentHash(reader *bytes.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
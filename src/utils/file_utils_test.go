@@ -8,6 +8,7 @@ import (
 	"mime/multipart"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	test "the-wedding-game-api/_tests"
 )
@@ -226,12 +227,18 @@ func TestUploadFile(t *testing.T) {
 		return
 	}
 
-	url, err := UploadFile(fileHeader)
+	uploaded, err := UploadFile(fileHeader)
 	if err != nil {
 		t.Errorf(fmt.Errorf("error while uploading file: %w", err).Error())
 		return
 	}
 
+	url := uploaded.Url
+	if uploaded.Size != fileHeader.Size || len(uploaded.ContentHash) != 64 || !strings.HasSuffix(url, uploaded.Key) {
+		t.Errorf("incorrect upload details: %v", uploaded)
+		return
+	}
+
 	if !IsURLStrict(url) {
 		t.Errorf("invalid URL")
 		return
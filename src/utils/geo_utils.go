@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const earthRadiusMeters = 6371000
+
+// DistanceInMeters returns the great-circle distance between two coordinates using the haversine formula
+func DistanceInMeters(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// ParseDeviceLocation parses a location such as "52.3676,4.9041,12" into latitude, longitude and accuracy in meters.
+// The accuracy may be omitted when the device does not report it.
+func ParseDeviceLocation(s string) (float64, float64, float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return 0, 0, 0, errors.New("invalid location")
+	}
+
+	values := make([]float64, 3)
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return 0, 0, 0, errors.New("invalid location")
+		}
+		values[i] = value
+	}
+
+	latitude, longitude, accuracy := values[0], values[1], values[2]
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 || accuracy < 0 {
+		return 0, 0, 0, errors.New("invalid location")
+	}
+	return latitude, longitude, accuracy, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceInMeters(t *testing.T) {
+	// Amsterdam Centraal to the Dam, roughly 850 meters
+	distance := DistanceInMeters(52.3791, 4.9003, 52.3731, 4.8926)
+	if math.Abs(distance-850) > 10 {
+		t.Errorf("expected about 850 meters but got %f", distance)
+	}
+
+	if distance := DistanceInMeters(52.3791, 4.9003, 52.3791, 4.9003); distance != 0 {
+		t.Errorf("expected 0 for the same point but got %f", distance)
+	}
+}
+
+func TestParseDeviceLocation(t *testing.T) {
+	latitude, longitude, accuracy, err := ParseDeviceLocation("52.3676, 4.9041, 12.5")
+	if err != nil {
+		t.Errorf("expected nil but got %s", err.Error())
+		return
+	}
+	if latitude != 52.3676 || longitude != 4.9041 || accuracy != 12.5 {
+		t.Errorf("expected 52.3676 4.9041 12.5 but got %f %f %f", latitude, longitude, accuracy)
+	}
+
+	_, _, accuracy, err = ParseDeviceLocation("52.3676,4.9041")
+	if err != nil || accuracy != 0 {
+		t.Errorf("expected a location without accuracy to be accepted but got %f %v", accuracy, err)
+	}
+}
+
+func TestParseDeviceLocationInvalid(t *testing.T) {
+	for _, location := range []string{"", "52.3676", "52.3676,4.9041,12,1", "north,east", "91,4.9041", "52.3676,181", "52.3676,4.9041,-1", "NaN,4.9041"} {
+		if _, _, _, err := ParseDeviceLocation(location); err == nil {
+			t.Errorf("expected error for %q but got nil", location)
+		}
+	}
+}
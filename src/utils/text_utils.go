@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"errors"
+	"golang.org/x/text/unicode/norm"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Letters that do not decompose into a base letter and a combining mark
+var foldedLetters = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o",
+	'ł': "l",
+	'đ': "d",
+	'ð': "d",
+	'þ': "th",
+}
+
+var leadingArticles = []string{"the ", "a ", "an "}
+
+// NormalizeAnswer lower-cases the answer, folds diacritics, replaces punctuation with spaces,
+// collapses whitespace and drops a leading English article, so "The Eiffel-Tower " becomes "eiffel tower".
+func NormalizeAnswer(answer string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(answer)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if folded, ok := foldedLetters[r]; ok {
+			builder.WriteString(folded)
+			continue
+		}
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			builder.WriteRune(' ')
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	normalized := strings.Join(strings.Fields(builder.String()), " ")
+	for _, article := range leadingArticles {
+		if strings.HasPrefix(normalized, article) && len(normalized) > len(article) {
+			return normalized[len(article):]
+		}
+	}
+	return normalized
+}
+
+// NormalizeCode makes join and invite codes case-insensitive, guests type them from a card on their table.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// LevenshteinDistance returns the number of single character edits needed to turn a into b.
+func LevenshteinDistance(a string, b string) int {
+	source := []rune(a)
+	target := []rune(b)
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
+
+// ParseNumber parses a number typed by a player, accepting a comma as the decimal separator.
+func ParseNumber(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+
+	number, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.New("invalid number")
+	}
+	return number, nil
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+)
+
+func TestNormalizeAnswer(t *testing.T) {
+	assert.Equal(t, NormalizeAnswer("The Eiffel Tower "), "eiffel tower")
+	assert.Equal(t, NormalizeAnswer("eiffel tower"), "eiffel tower")
+	assert.Equal(t, NormalizeAnswer("  Eiffel-Tower!!"), "eiffel tower")
+	assert.Equal(t, NormalizeAnswer("Crème Brûlée"), "creme brulee")
+	assert.Equal(t, NormalizeAnswer("Straße"), "strasse")
+	assert.Equal(t, NormalizeAnswer("Ålesund"), "alesund")
+	assert.Equal(t, NormalizeAnswer("A  new\tbeginning"), "new beginning")
+	assert.Equal(t, NormalizeAnswer("The"), "the")
+	assert.Equal(t, NormalizeAnswer(""), "")
+}
+
+func TestNormalizeCode(t *testing.T) {
+	assert.Equal(t, NormalizeCode(" may123\t"), "MAY123")
+	assert.Equal(t, NormalizeCode("TABLE7"), "TABLE7")
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, LevenshteinDistance("", ""), 0)
+	assert.Equal(t, LevenshteinDistance("paris", "paris"), 0)
+	assert.Equal(t, LevenshteinDistance("paris", "pariss"), 1)
+	assert.Equal(t, LevenshteinDistance("paris", "prais"), 2)
+	assert.Equal(t, LevenshteinDistance("kitten", "sitting"), 3)
+	assert.Equal(t, LevenshteinDistance("", "abc"), 3)
+	assert.Equal(t, LevenshteinDistance("café", "cafe"), 1)
+}
+
+func TestParseNumber(t *testing.T) {
+	number, err := ParseNumber("42")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, number, 42.0)
+
+	number, err = ParseNumber(" 3.5 ")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, number, 3.5)
+
+	number, err = ParseNumber("3,5")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, number, 3.5)
+
+	number, err = ParseNumber("1 000")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, number, 1000.0)
+}
+
+func TestParseNumberInvalid(t *testing.T) {
+	invalidNumbers := []string{"", "abc", "1,000,000", "1.2.3", "twelve"}
+	for _, number := range invalidNumbers {
+		_, err := ParseNumber(number)
+		if err == nil {
+			t.Errorf("expected error for %q but got nil", number)
+		}
+	}
+}
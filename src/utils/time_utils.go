@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Layouts accepted for timestamps without an explicit offset, interpreted in the event timezone
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// ParseEventTime parses a timestamp sent by an admin. Timestamps with an offset (RFC 3339) are used as is,
+// timestamps without one are interpreted in the given location. An empty string returns nil.
+func ParseEventTime(s string, location *time.Location) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, s); err == nil {
+		return &parsed, nil
+	}
+
+	for _, layout := range localTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, s, location); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, errors.New("invalid timestamp")
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"github.com/go-playground/assert/v2"
+	"testing"
+	"time"
+)
+
+func TestParseEventTimeWithOffset(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/Berlin")
+
+	parsed, err := ParseEventTime("2025-06-21T18:30:00Z", location)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed.Equal(time.Date(2025, 6, 21, 18, 30, 0, 0, time.UTC)), true)
+}
+
+func TestParseEventTimeWithoutOffset(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/Berlin")
+
+	parsed, err := ParseEventTime("2025-06-21T18:30", location)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed.Equal(time.Date(2025, 6, 21, 16, 30, 0, 0, time.UTC)), true)
+
+	parsed, err = ParseEventTime("2025-06-21 18:30:15", location)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed.Equal(time.Date(2025, 6, 21, 16, 30, 15, 0, time.UTC)), true)
+}
+
+func TestParseEventTimeEmpty(t *testing.T) {
+	parsed, err := ParseEventTime(" ", time.UTC)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed == nil, true)
+}
+
+func TestParseEventTimeInvalid(t *testing.T) {
+	_, err := ParseEventTime("tomorrow evening", time.UTC)
+	assert.NotEqual(t, err, nil)
+}
@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"errors"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -24,3 +26,17 @@ func IsURLStrict(s string) bool {
 	r := regexp.MustCompile(`^(?:http(s)?://)?[\w.-]+(?:\.[\w.-]+)+(?::\d+)?(?:/[\w\-._~:/?#[\]@!$&'()*+,;=]*)?$`)
 	return r.MatchString(s)
 }
+
+// ParseIdList parses a comma separated list of ids such as "3,1,2", preserving the order.
+func ParseIdList(s string) ([]uint, error) {
+	parts := strings.Split(s, ",")
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err != nil || id == 0 {
+			return nil, errors.New("invalid id list")
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
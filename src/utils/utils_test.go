@@ -39,3 +39,23 @@ func TestIsURLStrictWithInvalidUrls(t *testing.T) {
 	assert.Equal(t, IsURLStrict("https://invalid"), false)
 	assert.Equal(t, IsURLStrict("www.google.com"), false)
 }
+
+func TestParseIdList(t *testing.T) {
+	ids, err := ParseIdList("3,1, 2")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ids, []uint{3, 1, 2})
+
+	ids, err = ParseIdList("7")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, ids, []uint{7})
+}
+
+func TestParseIdListInvalid(t *testing.T) {
+	invalidLists := []string{"", "a", "1,,2", "1,b", "-1", "0", "1.5"}
+	for _, list := range invalidLists {
+		_, err := ParseIdList(list)
+		if err == nil {
+			t.Errorf("expected error for %q but got nil", list)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+const (
+	ebmlSegmentID       = 0x18538067
+	ebmlInfoID          = 0x1549A966
+	ebmlTimecodeScaleID = 0x2AD7B1
+	ebmlDurationID      = 0x4489
+
+	defaultTimecodeScale = 1000000 // nanoseconds per timecode unit
+
+	maxMP4HeaderSize = 1024 // the movie header box is about 100 bytes, anything much larger is not a real one
+
+	maxMediaDuration = 24 * time.Hour // no recording of the wedding is this long, larger headers are crafted
+)
+
+var errInvalidVideo = errors.New("invalid video container")
+
+// GetVideoDuration reads the duration of an mp4, mov or webm video from its container header
+func GetVideoDuration(file io.ReadSeeker, extension string) (time.Duration, error) {
+	switch strings.ToLower(extension) {
+	case ".mp4", ".mov":
+		return getMP4Duration(file)
+	case ".webm":
+		return getWebMDuration(file)
+	default:
+		return 0, errInvalidVideo
+	}
+}
+
+// getMP4Duration reads the movie header box (moov/mvhd) of an ISO base media file, used by mp4 and mov
+func getMP4Duration(file io.ReadSeeker) (time.Duration, error) {
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	moovStart, moovEnd, err := findMP4Box(file, 0, end, "moov")
+	if err != nil {
+		return 0, err
+	}
+
+	mvhdStart, mvhdEnd, err := findMP4Box(file, moovStart, moovEnd, "mvhd")
+	if err != nil {
+		return 0, err
+	}
+
+	headerSize := mvhdEnd - mvhdStart
+	if headerSize < 20 || headerSize > maxMP4HeaderSize {
+		return 0, errInvalidVideo
+	}
+	header := make([]byte, headerSize)
+	if _, err := file.Seek(mvhdStart, io.SeekStart); err != nil {
+		return 0, err
+	}
+	if _, err := io.ReadFull(file, header); err != nil {
+		return 0, errInvalidVideo
+	}
+
+	var timescale uint32
+	var duration uint64
+	if header[0] == 1 {
+		if len(header) < 32 {
+			return 0, errInvalidVideo
+		}
+		timescale = binary.BigEndian.Uint32(header[20:24])
+		duration = binary.BigEndian.Uint64(header[24:32])
+	} else {
+		timescale = binary.BigEndian.Uint32(header[12:16])
+		duration = uint64(binary.BigEndian.Uint32(header[16:20]))
+	}
+
+	if timescale == 0 {
+		return 0, errInvalidVideo
+	}
+	return secondsToDuration(float64(duration) / float64(timescale))
+}
+
+// findMP4Box returns the start and end of the content of the first box of the given type between start and end
+func findMP4Box(file io.ReadSeeker, start int64, end int64, boxType string) (int64, int64, error) {
+	header := make([]byte, 16)
+	for offset := start; offset+8 <= end; {
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return 0, 0, err
+		}
+		if _, err := io.ReadFull(file, header[:8]); err != nil {
+			return 0, 0, errInvalidVideo
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		headerSize := int64(8)
+		switch size {
+		case 0:
+			size = end - offset
+		case 1:
+			if _, err := io.ReadFull(file, header[8:16]); err != nil {
+				return 0, 0, errInvalidVideo
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+
+		// size can be close to the largest int64, offset+size would overflow
+		if size < headerSize || size > end-offset {
+			return 0, 0, errInvalidVideo
+		}
+		if string(header[4:8]) == boxType {
+			return offset + headerSize, offset + size, nil
+		}
+		offset += size
+	}
+
+	return 0, 0, errInvalidVideo
+}
+
+// getWebMDuration reads the duration from the segment information of a webm (matroska) file
+func getWebMDuration(file io.ReadSeeker) (time.Duration, error) {
+	end, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	segmentStart, segmentEnd, err := findEBMLElement(file, 0, end, ebmlSegmentID)
+	if err != nil {
+		return 0, err
+	}
+
+	infoStart, infoEnd, err := findEBMLElement(file, segmentStart, segmentEnd, ebmlInfoID)
+	if err != nil {
+		return 0, err
+	}
+
+	timecodeScale := uint64(defaultTimecodeScale)
+	duration := -1.0
+	for offset := infoStart; offset < infoEnd; {
+		id, dataStart, dataEnd, err := readEBMLElementHeader(file, offset, infoEnd)
+		if err != nil {
+			return 0, err
+		}
+
+		// Both values are at most 8 bytes long, larger elements are not read into memory
+		var data []byte
+		if (id == ebmlTimecodeScaleID || id == ebmlDurationID) && dataEnd-dataStart <= 8 {
+			data = make([]byte, dataEnd-dataStart)
+			if _, err := io.ReadFull(file, data); err != nil {
+				return 0, errInvalidVideo
+			}
+		}
+
+		switch {
+		case id == ebmlTimecodeScaleID && data != nil:
+			timecodeScale = 0
+			for _, b := range data {
+				timecodeScale = timecodeScale<<8 | uint64(b)
+			}
+		case id == ebmlDurationID && len(data) == 4:
+			duration = float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+		case id == ebmlDurationID && len(data) == 8:
+			duration = math.Float64frombits(binary.BigEndian.Uint64(data))
+		}
+		offset = dataEnd
+	}
+
+	if timecodeScale == 0 {
+		return 0, errInvalidVideo
+	}
+	return secondsToDuration(duration * float64(timecodeScale) / float64(time.Second))
+}
+
+// secondsToDuration converts a duration read from a header, values that would overflow time.Duration are rejected
+// because they wrap around to negative durations that pass every length limit
+func secondsToDuration(seconds float64) (time.Duration, error) {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) || seconds < 0 || seconds > maxMediaDuration.Seconds() {
+		return 0, errInvalidVideo
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// findEBMLElement returns the start and end of the data of the first element with the given id between start and end
+func findEBMLElement(file io.ReadSeeker, start int64, end int64, elementId uint64) (int64, int64, error) {
+	for offset := start; offset < end; {
+		id, dataStart, dataEnd, err := readEBMLElementHeader(file, offset, end)
+		if err != nil {
+			return 0, 0, err
+		}
+		if id == elementId {
+			return dataStart, dataEnd, nil
+		}
+		offset = dataEnd
+	}
+
+	return 0, 0, errInvalidVideo
+}
+
+// readEBMLElementHeader reads the id and size of the element at offset, elements of unknown size extend to the end
+func readEBMLElementHeader(file io.ReadSeeker, offset int64, end int64) (uint64, int64, int64, error) {
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, 0, 0, err
+	}
+
+	id, idLength, err := readEBMLVint(file, true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	size, sizeLength, err := readEBMLVint(file, false)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	dataStart := offset + int64(idLength+sizeLength)
+	unknownSize := size == 1<<(7*sizeLength)-1
+	if dataStart > end {
+		return 0, 0, 0, errInvalidVideo
+	}
+	if unknownSize || size > uint64(end-dataStart) {
+		return id, dataStart, end, nil
+	}
+	return id, dataStart, dataStart + int64(size), nil
+}
+
+// readEBMLVint reads a variable length integer, ids keep their length marker while sizes do not
+func readEBMLVint(file io.Reader, keepMarker bool) (uint64, int, error) {
+	buffer := make([]byte, 8)
+	if _, err := io.ReadFull(file, buffer[:1]); err != nil {
+		return 0, 0, errInvalidVideo
+	}
+
+	length := bits.LeadingZeros8(buffer[0]) + 1
+	if length > 8 {
+		return 0, 0, errInvalidVideo
+	}
+	if _, err := io.ReadFull(file, buffer[1:length]); err != nil {
+		return 0, 0, errInvalidVideo
+	}
+
+	value := uint64(buffer[0])
+	if !keepMarker {
+		value &= uint64(0xFF >> length)
+	}
+	for _, b := range buffer[1:length] {
+		value = value<<8 | uint64(b)
+	}
+	return value, length, nil
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/go-playground/assert/v2"
+	"math"
+	"testing"
+	"time"
+)
+
+func mp4Box(boxType string, content []byte) []byte {
+	box := make([]byte, 8, 8+len(content))
+	binary.BigEndian.PutUint32(box[:4], uint32(8+len(content)))
+	copy(box[4:8], boxType)
+	return append(box, content...)
+}
+
+func mvhdVersion0(timescale uint32, duration uint32) []byte {
+	content := make([]byte, 100)
+	binary.BigEndian.PutUint32(content[12:16], timescale)
+	binary.BigEndian.PutUint32(content[16:20], duration)
+	return content
+}
+
+func mvhdVersion1(timescale uint32, duration uint64) []byte {
+	content := make([]byte, 112)
+	content[0] = 1
+	binary.BigEndian.PutUint32(content[20:24], timescale)
+	binary.BigEndian.PutUint64(content[24:32], duration)
+	return content
+}
+
+func ebmlElement(id []byte, content []byte) []byte {
+	element := append([]byte{}, id...)
+	element = append(element, 0x08, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(element[len(id):], uint64(len(content)))
+	element[len(id)] = 0x01
+	return append(element, content...)
+}
+
+func webmFile(timecodeScale []byte, duration []byte) []byte {
+	var info []byte
+	if timecodeScale != nil {
+		info = append(info, ebmlElement([]byte{0x2A, 0xD7, 0xB1}, timecodeScale)...)
+	}
+	info = append(info, ebmlElement([]byte{0x44, 0x89}, duration)...)
+
+	header := ebmlElement([]byte{0x1A, 0x45, 0xDF, 0xA3}, []byte{0x42, 0x82, 0x84, 'w', 'e', 'b', 'm'})
+	segment := ebmlElement([]byte{0x18, 0x53, 0x80, 0x67}, append(
+		ebmlElement([]byte{0x11, 0x4D, 0x9B, 0x74}, []byte{0xEC, 0x80}),
+		ebmlElement([]byte{0x15, 0x49, 0xA9, 0x66}, info)...,
+	))
+	return append(header, segment...)
+}
+
+func TestGetVideoDurationMP4(t *testing.T) {
+	video := append(mp4Box("ftyp", []byte("isom")), mp4Box("moov", mp4Box("mvhd", mvhdVersion0(1000, 12500)))...)
+
+	duration, err := GetVideoDuration(bytes.NewReader(video), ".mp4")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 12500*time.Millisecond)
+}
+
+func TestGetVideoDurationMOVVersion1(t *testing.T) {
+	video := append(mp4Box("ftyp", []byte("qt  ")), mp4Box("moov", append(mp4Box("udta", nil), mp4Box("mvhd", mvhdVersion1(600, 18000))...))...)
+
+	duration, err := GetVideoDuration(bytes.NewReader(video), ".MOV")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, duration, 30*time.Second)
+}
+
+func TestGetVideoDurationMP4WithoutMovieHeader(t *testing.T) {
+	video := append(mp4Box("ftyp", []byte("isom")), mp4Box("mdat", []byte("data"))...)
+
+	_, err := GetVideoDuration(bytes.NewReader(video), ".mp4")
+	assert.NotEqual(t, err, nil)
+}
+
+// mp4LargeBox returns a box header with a 64-bit size, without the content it claims to have
+func mp4LargeBox(boxType string, size uint64) []byte {
+	box := make([]byte, 16)
+	binary.BigEndian.PutUint32(box[:4], 1)
+	copy(box[4:8], boxType)
+	binary.BigEndian.PutUint64(box[8:16], size)
+	return box
+}
+
+func TestGetVideoDurationMP4WithOverflowingLargeSize(t *testing.T) {
+	moov := append(mp4Box("free", nil), mp4LargeBox("mvhd", 1<<63-4)...)
+	video := append(mp4Box("ftyp", []byte("isom")), mp4Box("moov", moov)...)
+
+	_, err := GetVideoDuration(bytes.NewReader(video), ".mp4")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetVideoDurationMP4WithOversizedMovieHeader(t *testing.T) {
+	video := append(mp4Box("ftyp", []byte("isom")), mp4Box("moov", mp4Box("mvhd", make([]byte, 1<<20)))...)
+
+	_, err := GetVideoDuration(bytes.NewReader(video), ".mp4")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetVideoDurationWebM(t *testing.T) {
+	duration := make([]byte, 8)
+	binary.BigEndian.PutUint64(duration, math.Float64bits(4500))
+
+	video := webmFile(nil, duration)
+	parsed, err := GetVideoDuration(bytes.NewReader(video), ".webm")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, 4500*time.Millisecond)
+}
+
+func TestGetVideoDurationWebMWithTimecodeScale(t *testing.T) {
+	duration := make([]byte, 4)
+	binary.BigEndian.PutUint32(duration, math.Float32bits(20))
+
+	video := webmFile([]byte{0x3B, 0x9A, 0xCA, 0x00}, duration)
+	parsed, err := GetVideoDuration(bytes.NewReader(video), ".webm")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, parsed, 20*time.Second)
+}
+
+func TestGetVideoDurationInvalidFile(t *testing.T) {
+	_, err := GetVideoDuration(bytes.NewReader([]byte("not a video")), ".webm")
+	assert.NotEqual(t, err, nil)
+
+	_, err = GetVideoDuration(bytes.NewReader([]byte("not a video")), ".mp4")
+	assert.NotEqual(t, err, nil)
+
+	_, err = GetVideoDuration(bytes.NewReader([]byte("not a video")), ".avi")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetVideoDurationMP4WithOversizedDuration(t *testing.T) {
+	video := append(mp4Box("ftyp", []byte("isom")), mp4Box("moov", mp4Box("mvhd", mvhdVersion1(1, math.MaxUint64)))...)
+
+	_, err := GetVideoDuration(bytes.NewReader(video), ".mp4")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetVideoDurationWebMWithOversizedDuration(t *testing.T) {
+	duration := make([]byte, 8)
+	binary.BigEndian.PutUint64(duration, math.Float64bits(math.MaxFloat64))
+
+	_, err := GetVideoDuration(bytes.NewReader(webmFile(nil, duration)), ".webm")
+	assert.NotEqual(t, err, nil)
+}
+
+func TestGetVideoDurationWebMWithNaNOrInfiniteDuration(t *testing.T) {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		duration := make([]byte, 8)
+		binary.BigEndian.PutUint64(duration, math.Float64bits(value))
+
+		_, err := GetVideoDuration(bytes.NewReader(webmFile(nil, duration)), ".webm")
+		assert.NotEqual(t, err, nil)
+	}
+
+	duration := make([]byte, 4)
+	binary.BigEndian.PutUint32(duration, math.Float32bits(float32(math.NaN())))
+	_, err := GetVideoDuration(bytes.NewReader(webmFile(nil, duration)), ".webm")
+	assert.NotEqual(t, err, nil)
+}